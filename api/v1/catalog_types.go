@@ -0,0 +1,83 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name=LastUnpacked,type=date,JSONPath=`.status.lastUnpacked`
+//+kubebuilder:printcolumn:name="Serving",type=string,JSONPath=`.status.conditions[?(@.type=="Serving")].status`
+//+kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Catalog makes File-Based Catalog (FBC) data available to a single namespace.
+//
+// A Catalog is unpacked and served like a ClusterCatalog, but it is only considered
+// when resolving ClusterExtensions whose service account is in the namespace of the
+// Catalog. This allows tenants to provide their own catalogs without affecting
+// installations in other namespaces.
+//
+// Catalogs do not support a priority, and a non-zero priority is rejected. Catalogs are
+// considered with the lowest possible priority, so that they never take precedence over
+// ClusterCatalogs.
+type Catalog struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata"`
+
+	// spec is a required field that defines the desired state of the Catalog.
+	// The controller ensures that the catalog is unpacked and served over the catalog content HTTP server.
+	// priority must be omitted or 0.
+	// +required
+	// +kubebuilder:validation:XValidation:rule="!has(self.priority) || self.priority == 0",message="priority is not supported for Catalogs"
+	Spec ClusterCatalogSpec `json:"spec"`
+
+	// status contains information about the state of the Catalog, in the same form as
+	// the status of a ClusterCatalog.
+	// +optional
+	Status ClusterCatalogStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CatalogList contains a list of Catalog
+type CatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata"`
+
+	// items is a list of Catalogs.
+	// items is required.
+	// +required
+	Items []Catalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &Catalog{}, &CatalogList{})
+		return nil
+	})
+}
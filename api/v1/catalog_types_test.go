@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const catalogCRDFilePath = "../../helm/olmv1/base/catalogd/crd/experimental/olm.operatorframework.io_catalogs.yaml"
+
+func TestCatalogSpecCELValidation(t *testing.T) {
+	validators := fieldValidatorsFromFile(t, catalogCRDFilePath)
+	pth := "openAPIV3Schema.properties.spec"
+	validator, found := validators[GroupVersion.Version][pth]
+	require.True(t, found)
+	for name, tc := range map[string]struct {
+		spec     ClusterCatalogSpec
+		wantErrs []string
+	}{
+		"priority omitted": {
+			spec:     ClusterCatalogSpec{},
+			wantErrs: []string{},
+		},
+		"non-zero priority": {
+			spec: ClusterCatalogSpec{
+				Priority: 1000,
+			},
+			wantErrs: []string{
+				fmt.Sprintf("%s: Invalid value: priority is not supported for Catalogs", pth),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&tc.spec) //nolint:gosec
+			require.NoError(t, err)
+			errs := validator(obj, nil)
+			require.Len(t, errs, len(tc.wantErrs))
+			for i := range tc.wantErrs {
+				assert.Equal(t, tc.wantErrs[i], errs[i].Error())
+			}
+		})
+	}
+}
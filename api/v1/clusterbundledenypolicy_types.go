@@ -0,0 +1,162 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BundleDenyRemediation defines how installed ClusterExtensions are remediated
+// when their installed bundle is denied.
+type BundleDenyRemediation string
+
+const (
+	// BundleDenyRemediationNone leaves denied bundles installed. Denied bundles are
+	// not resolved for new installations or upgrades.
+	BundleDenyRemediationNone BundleDenyRemediation = "None"
+	// BundleDenyRemediationUpgrade upgrades ClusterExtensions away from denied bundles
+	// to an allowed successor.
+	BundleDenyRemediationUpgrade BundleDenyRemediation = "Upgrade"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name=Remediation,type=string,JSONPath=`.spec.remediation`
+//+kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterBundleDenyPolicy denies bundles cluster-wide, e.g. bundles with known
+// vulnerabilities, without changing the catalogs that provide them.
+//
+// Denied bundles are never resolved for the installation or upgrade of a
+// ClusterExtension. ClusterExtensions that have a denied bundle installed report
+// it in their BundleDenied condition.
+type ClusterBundleDenyPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata"`
+
+	// spec is a required field that defines the bundles denied by the policy.
+	// +required
+	Spec ClusterBundleDenyPolicySpec `json:"spec"`
+}
+
+// ClusterBundleDenyPolicySpec defines the bundles denied by a ClusterBundleDenyPolicy.
+type ClusterBundleDenyPolicySpec struct {
+	// entries is a required list of the bundles denied by the policy.
+	// A bundle is denied when it matches any of the entries.
+	// You can specify no more than 256 entries.
+	//
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=256
+	// +listType=atomic
+	// +required
+	Entries []BundleDenyEntry `json:"entries"`
+
+	// remediation is an optional field that defines how ClusterExtensions that have a
+	// bundle denied by the policy installed are remediated.
+	//
+	// Allowed values are: "None" and "Upgrade".
+	//
+	// When set to "None", the denied bundle remains installed, and the BundleDenied
+	// condition of the ClusterExtension reports it.
+	//
+	// When set to "Upgrade", the ClusterExtension is upgraded to an allowed successor of
+	// the denied bundle, resolved from its catalog source. The denied bundle remains
+	// installed until such a successor is available.
+	//
+	// When unspecified, the default value is "None".
+	//
+	// +kubebuilder:validation:Enum:="None";"Upgrade"
+	// +optional
+	Remediation BundleDenyRemediation `json:"remediation,omitempty"`
+}
+
+// BundleDenyEntry identifies denied bundles by package and version range, or by the
+// digest of their image.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.packageName) || has(self.imageDigest)",message="at least one of packageName or imageDigest is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.versionRange) || has(self.packageName)",message="versionRange requires packageName"
+type BundleDenyEntry struct {
+	// packageName is an optional field that denies the bundles of the named package.
+	// When versionRange is unspecified, all bundles of the package are denied.
+	//
+	// It follows the DNS subdomain standard as defined in [RFC 1123].
+	// It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.),
+	// start and end with an alphanumeric character, and be no longer than 253 characters.
+	//
+	// [RFC 1123]: https://tools.ietf.org/html/rfc1123
+	//
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$\")",message="packageName must be a valid DNS1123 subdomain. It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.), start and end with an alphanumeric character, and be no longer than 253 characters"
+	// +optional
+	PackageName string `json:"packageName,omitempty"`
+
+	// versionRange is an optional field that restricts the denied bundles of the package
+	// to the versions within the range. It accepts the same version range expressions as
+	// the version field of a ClusterExtension catalog source, e.g. ">=1.2.0, <1.2.5".
+	//
+	// +kubebuilder:validation:MaxLength:=64
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^(\\\\s*(=||!=|>|<|>=|=>|<=|=<|~|~>|\\\\^)\\\\s*(v?(0|[1-9]\\\\d*|[x|X|\\\\*])(\\\\.(0|[1-9]\\\\d*|x|X|\\\\*]))?(\\\\.(0|[1-9]\\\\d*|x|X|\\\\*))?(-([0-9A-Za-z\\\\-]+(\\\\.[0-9A-Za-z\\\\-]+)*))?(\\\\+([0-9A-Za-z\\\\-]+(\\\\.[0-9A-Za-z\\\\-]+)*))?)\\\\s*)((?:\\\\s+|,\\\\s*|\\\\s*\\\\|\\\\|\\\\s*)(=||!=|>|<|>=|=>|<=|=<|~|~>|\\\\^)\\\\s*(v?(0|[1-9]\\\\d*|x|X|\\\\*])(\\\\.(0|[1-9]\\\\d*|x|X|\\\\*))?(\\\\.(0|[1-9]\\\\d*|x|X|\\\\*]))?(-([0-9A-Za-z\\\\-]+(\\\\.[0-9A-Za-z\\\\-]+)*))?(\\\\+([0-9A-Za-z\\\\-]+(\\\\.[0-9A-Za-z\\\\-]+)*))?)\\\\s*)*$\")",message="invalid version expression"
+	// +optional
+	VersionRange string `json:"versionRange,omitempty"`
+
+	// imageDigest is an optional field that denies the bundles whose image is referenced
+	// by the given digest, e.g. "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae".
+	// When packageName is specified as well, only bundles of the package are denied.
+	//
+	// +kubebuilder:validation:MaxLength:=256
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[a-z0-9]+([+._-][a-z0-9]+)*:[a-fA-F0-9]{32,}$\")",message="imageDigest must be a valid digest, e.g. sha256:<hex>"
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+
+	// reason is a required, human-readable explanation of why the bundles are denied,
+	// e.g. the identifier of a vulnerability. It is reported in the conditions of
+	// ClusterExtensions that have a denied bundle installed.
+	//
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=1024
+	// +required
+	Reason string `json:"reason"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterBundleDenyPolicyList contains a list of ClusterBundleDenyPolicy
+type ClusterBundleDenyPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata"`
+
+	// items is a list of ClusterBundleDenyPolicies.
+	// items is required.
+	// +required
+	Items []ClusterBundleDenyPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &ClusterBundleDenyPolicy{}, &ClusterBundleDenyPolicyList{})
+		return nil
+	})
+}
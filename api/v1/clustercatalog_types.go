@@ -28,21 +28,43 @@ type SourceType string
 // AvailabilityMode defines the availability of the catalog
 type AvailabilityMode string
 
+// ValidationMode defines how strictly the content of a catalog is validated
+type ValidationMode string
+
 const (
 	SourceTypeImage SourceType = "Image"
 
 	MetadataNameLabel = "olm.operatorframework.io/metadata.name"
 
+	// RefreshRequestedAnnotation requests that the content of a ClusterCatalog is unpacked
+	// again from its source, regardless of its poll interval, whenever its value changes.
+	// It is set to the time of the request by the catalogd registry webhook receiver, and
+	// can be set to any new value to request a refresh manually.
+	RefreshRequestedAnnotation = "olm.operatorframework.io/refresh-requested"
+
 	AvailabilityModeAvailable   AvailabilityMode = "Available"
 	AvailabilityModeUnavailable AvailabilityMode = "Unavailable"
 
+	ValidationModeNone   ValidationMode = "None"
+	ValidationModeWarn   ValidationMode = "Warn"
+	ValidationModeStrict ValidationMode = "Strict"
+
 	// Condition types
-	TypeServing = "Serving"
+	TypeServing     = "Serving"
+	TypeValidated   = "Validated"
+	TypeOverlapping = "Overlapping"
 
 	// Serving Reasons
 	ReasonAvailable                = "Available"
 	ReasonUnavailable              = "Unavailable"
 	ReasonUserSpecifiedUnavailable = "UserSpecifiedUnavailable"
+
+	// Progressing Reasons
+	ReasonLimitExceeded = "LimitExceeded"
+
+	// Overlapping Reasons
+	ReasonPackagesOverlap = "PackagesOverlap"
+	ReasonNoOverlap       = "NoOverlap"
 )
 
 // +genclient
@@ -150,6 +172,30 @@ type ClusterCatalogSpec struct {
 	// +kubebuilder:default:="Available"
 	// +optional
 	AvailabilityMode AvailabilityMode `json:"availabilityMode,omitempty"`
+
+	// validationMode is an optional field that defines how strictly the catalog contents are validated
+	// before they are served.
+	//
+	// Allowed values are "None", "Warn", "Strict", or omitted.
+	//
+	// When omitted, the default value is "None".
+	//
+	// When set to "None", the catalog contents are not validated.
+	//
+	// When set to "Warn", the catalog contents are validated and served even when problems are found.
+	// Problems are reported in the Validated condition.
+	//
+	// When set to "Strict", the catalog contents are validated and only served when no problems are found.
+	// When problems are found, they are reported in the Validated condition and the previously served contents,
+	// if any, continue to be served.
+	//
+	// Validation detects problems such as channel entries that reference missing bundles, duplicate bundle names,
+	// cyclic replaces chains, bundle versions that are not valid semver, and packages without a valid default channel.
+	//
+	// <opcon:experimental>
+	// +kubebuilder:validation:Enum:="None";"Warn";"Strict"
+	// +optional
+	ValidationMode ValidationMode `json:"validationMode,omitempty"`
 }
 
 // ClusterCatalogStatus defines the observed state of ClusterCatalog
@@ -167,6 +213,19 @@ type ClusterCatalogStatus struct {
 	//   - When status is True and reason is Retrying, an error occurred that may be resolved on subsequent reconciliation attempts.
 	//   - When status is True and reason is Succeeded, the ClusterCatalog has successfully progressed to a new state and is ready to continue progressing.
 	//   - When status is False and reason is Blocked, an error occurred that requires manual intervention for recovery.
+	//   - When status is False and reason is LimitExceeded, the catalog contents exceed a size or shape limit configured for catalogd, and are not served until the catalog is updated or a refresh is requested.
+	//
+	// <opcon:experimental:description>
+	// The Validated condition represents whether the most recently unpacked catalog contents passed validation,
+	// and is only present when validationMode is "Warn" or "Strict":
+	//   - When status is True and reason is Succeeded, no problems were found.
+	//   - When status is False and reason is Failed, the message lists the problems that were found.
+	// The Overlapping condition represents whether packages of the served catalog contents are also provided by
+	// other served ClusterCatalogs with the same priority, which makes their resolution fail.
+	// It is only present when overlap detection is enabled and the catalog contents are being served:
+	//   - When status is False and reason is NoOverlap, no such packages were found.
+	//   - When status is True and reason is PackagesOverlap, the message lists the overlapping packages of each of these catalogs.
+	// </opcon:experimental:description>
 	//
 	// If the system initially fetched contents and polling identifies updates, both conditions can be active simultaneously:
 	//   - The Serving condition remains True with reason Available because the previous contents are still served via the HTTP(S) web server.
@@ -329,6 +388,76 @@ type ImageSource struct {
 	// +kubebuilder:validation:Minimum:=1
 	// +optional
 	PollIntervalMinutes *int `json:"pollIntervalMinutes,omitempty"`
+
+	// pullConfig is an optional field that configures the credentials and registries used to pull the image.
+	//
+	// For a ClusterCatalog, the pull secret must exist in the namespace that catalogd runs in.
+	// For a Catalog, the pull secret must exist in the namespace of the Catalog.
+	//
+	// When omitted, the image is pulled with the global pull secret and registry configuration of catalogd.
+	//
+	// <opcon:experimental>
+	// +optional
+	PullConfig *ImagePullConfig `json:"pullConfig,omitempty"`
+}
+
+// ImagePullConfig configures how an image is pulled, in addition to the global pull secret and
+// registry configuration.
+type ImagePullConfig struct {
+	// pullSecret is an optional field that specifies the name of a Secret that holds credentials
+	// for pulling the image, in the .dockerconfigjson or .dockercfg key.
+	// For the registries it holds credentials for, they take precedence over those of the global pull secret.
+	//
+	// The pullSecret field follows the DNS subdomain standard as defined in [RFC 1123].
+	// It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.),
+	// start and end with an alphanumeric character, and be no longer than 253 characters.
+	//
+	// [RFC 1123]: https://tools.ietf.org/html/rfc1123
+	//
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$\")",message="pullSecret must be a valid DNS1123 subdomain. It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.), start and end with an alphanumeric character, and be no longer than 253 characters"
+	// +optional
+	PullSecret string `json:"pullSecret,omitempty"`
+
+	// registries is an optional list of settings for the registries that images are pulled from.
+	// For the prefixes they specify, they take precedence over the registry configuration of the host.
+	//
+	// +kubebuilder:validation:MaxItems:=16
+	// +listType=map
+	// +listMapKey=prefix
+	// +optional
+	Registries []RegistryConfig `json:"registries,omitempty"`
+}
+
+// RegistryConfig configures how the images under a prefix are pulled.
+type RegistryConfig struct {
+	// prefix is a required field that selects the images that the settings apply to.
+	// It is a registry host, optionally followed by a port and a repository path,
+	// such as "quay.io", "registry.example.com:5000" or "quay.io/operatorhubio".
+	// It has the same meaning as the prefix of a registry in containers-registries.conf(5),
+	// except that wildcards are not allowed.
+	//
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=1000
+	// +kubebuilder:validation:XValidation:rule="!self.contains('*')",message="prefix must not contain wildcards"
+	// +required
+	Prefix string `json:"prefix"`
+
+	// mirrors is an optional list of locations that images under the prefix are pulled from,
+	// in order, before the prefix itself.
+	// A location replaces the prefix in image references, such as "mirror.example.com/operatorhubio".
+	//
+	// +kubebuilder:validation:MaxItems:=8
+	// +kubebuilder:validation:items:MaxLength:=1000
+	// +optional
+	Mirrors []string `json:"mirrors,omitempty"`
+
+	// insecure is an optional field that allows pulling images under the prefix, and from its mirrors,
+	// over plain HTTP or over HTTPS without verifying the TLS certificate of the registry.
+	// This is a dangerous setting that should only be used with registries that cannot present a trusted certificate.
+	//
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
 }
 
 func init() {
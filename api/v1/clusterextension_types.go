@@ -26,6 +26,8 @@ var ClusterExtensionKind = "ClusterExtension"
 
 type (
 	UpgradeConstraintPolicy     string
+	UpgradeScope                string
+	CatalogStickiness           string
 	CRDUpgradeSafetyEnforcement string
 
 	ClusterExtensionConfigType string
@@ -44,6 +46,32 @@ const (
 	// disastrous results such as data loss.
 	UpgradeConstraintPolicySelfCertified UpgradeConstraintPolicy = "SelfCertified"
 
+	// The extension will only upgrade to versions with the same
+	// major and minor version as the installed version.
+	UpgradeScopePatchOnly UpgradeScope = "PatchOnly"
+
+	// The extension will only upgrade to versions with the same
+	// major version as the installed version, and a minor version
+	// at most one greater than the installed minor version.
+	UpgradeScopeMinorOnly UpgradeScope = "MinorOnly"
+
+	// The extension will only upgrade to versions with the same
+	// major version as the installed version.
+	UpgradeScopeNoMajor UpgradeScope = "NoMajor"
+
+	// Upgrades are resolved from the catalog that provided the
+	// installed bundle when it provides a matching bundle, regardless
+	// of the priority of the other catalogs.
+	CatalogStickinessPreferred CatalogStickiness = "Preferred"
+
+	// Upgrades are only resolved from the catalog that provided
+	// the installed bundle.
+	CatalogStickinessRequired CatalogStickiness = "Required"
+
+	// Upgrades are resolved from all catalogs, regardless of the
+	// catalog that provided the installed bundle.
+	CatalogStickinessNone CatalogStickiness = "None"
+
 	ClusterExtensionConfigTypeInline ClusterExtensionConfigType = "Inline"
 )
 
@@ -79,8 +107,14 @@ type ClusterExtensionSpec struct {
 	// source is required and selects the installation source of content for this ClusterExtension.
 	// Set the sourceType field to perform the selection.
 	//
+	// <opcon:standard:description>
 	// Catalog is currently the only implemented sourceType.
 	// Setting sourceType to "Catalog" requires the catalog field to also be defined.
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Setting sourceType to "Catalog" requires the catalog field to also be defined.
+	// Setting sourceType to "ProvidedAPI" requires the providedAPI field to also be defined.
+	// </opcon:experimental:description>
 	//
 	// Below is a minimal example of a source definition (in yaml):
 	//
@@ -89,6 +123,7 @@ type ClusterExtensionSpec struct {
 	//   catalog:
 	//     packageName: example-package
 	//
+	// <opcon:experimental:validation:XValidation:rule="has(self.sourceType) && self.sourceType == 'ProvidedAPI' ? has(self.providedAPI) : !has(self.providedAPI)",message="providedAPI is required when sourceType is ProvidedAPI, and forbidden otherwise">
 	// +required
 	Source SourceConfig `json:"source"`
 
@@ -122,7 +157,10 @@ type ClusterExtensionSpec struct {
 	ProgressDeadlineMinutes int32 `json:"progressDeadlineMinutes,omitempty"`
 }
 
-const SourceTypeCatalog = "Catalog"
+const (
+	SourceTypeCatalog     = "Catalog"
+	SourceTypeProvidedAPI = "ProvidedAPI"
+)
 
 // SourceConfig is a discriminated union which selects the installation source.
 //
@@ -131,14 +169,26 @@ const SourceTypeCatalog = "Catalog"
 type SourceConfig struct {
 	// sourceType is required and specifies the type of install source.
 	//
+	// <opcon:standard:description>
 	// The only allowed value is "Catalog".
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Allowed values are "Catalog" and "ProvidedAPI".
+	// </opcon:experimental:description>
 	//
 	// When set to "Catalog", information for determining the appropriate bundle of content to install
 	// is fetched from ClusterCatalog resources on the cluster.
 	// When using the Catalog sourceType, the catalog field must also be set.
 	//
+	// <opcon:experimental:description>
+	// When set to "ProvidedAPI", the bundle to install is the one that provides an API, whatever
+	// its package, and is fetched from ClusterCatalog resources on the cluster.
+	// When using the ProvidedAPI sourceType, the providedAPI field must also be set.
+	// </opcon:experimental:description>
+	//
+	// <opcon:standard:validation:Enum=Catalog>
+	// <opcon:experimental:validation:Enum=Catalog;ProvidedAPI>
 	// +unionDiscriminator
-	// +kubebuilder:validation:Enum:="Catalog"
 	// +required
 	SourceType string `json:"sourceType"`
 
@@ -147,6 +197,13 @@ type SourceConfig struct {
 	//
 	// +optional
 	Catalog *CatalogFilter `json:"catalog,omitempty"`
+
+	// providedAPI configures how the bundle providing an API is sourced from catalogs.
+	// It is required when sourceType is "ProvidedAPI", and forbidden otherwise.
+	//
+	// <opcon:experimental>
+	// +optional
+	ProvidedAPI *ProvidedAPIFilter `json:"providedAPI,omitempty"`
 }
 
 // ClusterExtensionInstallConfig is a union which selects the clusterExtension installation config.
@@ -305,6 +362,30 @@ type CatalogFilter struct {
 	// +optional
 	Version string `json:"version,omitempty"`
 
+	// release is an optional constraint on the release of the bundles of the package, in addition to the
+	// version field. A bundle that is rebuilt without changing its version, for example to fix a CVE, is
+	// published with a new release.
+	//
+	// A release constraint is composed of one or more comma-delimited comparison strings, all of which
+	// must be satisfied. A comparison string is a release, optionally preceded by one of the operators
+	// "=", "!=", ">", ">=", "<" or "<=". Without an operator, the release of the bundle must match exactly.
+	// Releases are period-delimited identifiers, such as "3" or "2.1", and are compared like the
+	// pre-release part of a semver version. A bundle without a release is lower than any release.
+	//
+	// The constraint applies to the release of every candidate bundle, whatever its version, so it is
+	// typically combined with a pinned version. For example, setting version to "1.4.2" and release to ">=3"
+	// installs release 3 or a later release of version 1.4.2.
+	//
+	// When unspecified, bundles of any release are installed.
+	//
+	// Acceptable release constraints are no longer than 64 characters.
+	//
+	// <opcon:experimental>
+	// +kubebuilder:validation:MaxLength:=64
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^\\\\s*(=|!=|>|>=|<|<=)?\\\\s*[0-9A-Za-z-]+(\\\\.[0-9A-Za-z-]+)*\\\\s*(,\\\\s*(=|!=|>|>=|<|<=)?\\\\s*[0-9A-Za-z-]+(\\\\.[0-9A-Za-z-]+)*\\\\s*)*$\")",message="invalid release expression"
+	// +optional
+	Release string `json:"release,omitempty"`
+
 	// channels is optional and specifies a set of channels belonging to the package
 	// specified in the packageName field.
 	//
@@ -376,6 +457,137 @@ type CatalogFilter struct {
 	// +kubebuilder:default:=CatalogProvided
 	// +optional
 	UpgradeConstraintPolicy UpgradeConstraintPolicy `json:"upgradeConstraintPolicy,omitempty"`
+
+	// upgradeScope is optional and restricts the versions that an installed bundle is upgraded to, relative to
+	// the version of the installed bundle. It applies in addition to the upgradeConstraintPolicy, so that, for
+	// example, upgrade edges defined in the catalog are only followed within the upgrade scope.
+	//
+	// Allowed values are "PatchOnly", "MinorOnly", "NoMajor", or omitted.
+	//
+	// When set to "PatchOnly", only versions with the same major and minor version as the installed bundle are
+	// considered. For example, 1.2.3 may be upgraded to 1.2.4, but not to 1.3.0.
+	//
+	// When set to "MinorOnly", only versions with the same major version as the installed bundle, and a minor
+	// version at most one greater, are considered. For example, 1.2.3 may be upgraded to 1.3.5, but not to 1.4.0.
+	//
+	// When set to "NoMajor", only versions with the same major version as the installed bundle are considered.
+	// For example, 1.2.3 may be upgraded to 1.9.0, but not to 2.0.0.
+	//
+	// When omitted, upgrades are not restricted relative to the version of the installed bundle.
+	// The upgrade scope does not apply to the initial installation of a bundle.
+	//
+	// <opcon:experimental>
+	// +kubebuilder:validation:Enum:=PatchOnly;MinorOnly;NoMajor
+	// +optional
+	UpgradeScope UpgradeScope `json:"upgradeScope,omitempty"`
+
+	// catalogStickiness is optional and controls whether upgrades are resolved from the catalog that provided
+	// the installed bundle, which is reported in the status.install.catalog field, when the package is available
+	// in multiple catalogs.
+	//
+	// Allowed values are "Preferred", "Required", "None", or omitted.
+	//
+	// When set to "Preferred", upgrades are resolved from the catalog that provided the installed bundle when it
+	// provides a bundle matching the other fields, even when other catalogs have a higher or the same priority.
+	// Otherwise, upgrades are resolved from all catalogs.
+	//
+	// When set to "Required", upgrades are only resolved from the catalog that provided the installed bundle.
+	// Upgrades are blocked while that catalog is not selected by the selector field, or is unavailable.
+	//
+	// When set to "None", upgrades are resolved from all catalogs, and the priority of the catalogs decides which
+	// catalog is used when several catalogs provide a matching bundle.
+	//
+	// When omitted, the default value is "Preferred".
+	// Catalog stickiness has no effect on the initial installation of a bundle, or when the catalog that
+	// provided the installed bundle is unknown.
+	//
+	// <opcon:experimental>
+	// +kubebuilder:validation:Enum:=Preferred;Required;None
+	// +optional
+	CatalogStickiness CatalogStickiness `json:"catalogStickiness,omitempty"`
+
+	// bundlePullConfig is an optional field that configures the credentials and registries used to pull
+	// the image of the resolved bundle. The pull secret must exist in the namespace of the ClusterExtension.
+	//
+	// When omitted, the bundle image is pulled with the global pull secret and registry configuration
+	// of operator-controller.
+	//
+	// <opcon:experimental>
+	// +optional
+	BundlePullConfig *ImagePullConfig `json:"bundlePullConfig,omitempty"`
+}
+
+// ProvidedAPIFilter defines the API that the bundle to install must provide, and the attributes used to filter
+// the bundles that provide it from catalogs.
+type ProvidedAPIFilter struct {
+	// group is optional and specifies the API group of the API that the bundle must provide.
+	// When omitted, the API must be in the core group.
+	//
+	// group follows the DNS subdomain standard as defined in [RFC 1123].
+	// It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.),
+	// start and end with an alphanumeric character, and be no longer than 253 characters.
+	//
+	// [RFC 1123]: https://tools.ietf.org/html/rfc1123
+	//
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$\")",message="group must be a valid DNS1123 subdomain. It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.), start and end with an alphanumeric character, and be no longer than 253 characters"
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// version is required and specifies the version of the API that the bundle must provide, e.g. "v1".
+	//
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?$\")",message="version must be a valid DNS1123 label"
+	// +required
+	Version string `json:"version"`
+
+	// kind is required and specifies the kind of the API that the bundle must provide, e.g. "Certificate".
+	//
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[A-Z][A-Za-z0-9]*$\")",message="kind must start with an uppercase letter and contain only alphanumeric characters"
+	// +required
+	Kind string `json:"kind"`
+
+	// bundleVersion is optional and sets the version range of the bundles that may be installed, in the same
+	// format as the version field of a catalog source, e.g. ">=1.14.0, <2.0.0".
+	//
+	// When unspecified, bundles of any version are considered.
+	//
+	// +kubebuilder:validation:MaxLength:=64
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^(\\\\s*(=||!=|>|<|>=|=>|<=|=<|~|~>|\\\\^)\\\\s*(v?(0|[1-9]\\\\d*|[x|X|\\\\*])(\\\\.(0|[1-9]\\\\d*|x|X|\\\\*]))?(\\\\.(0|[1-9]\\\\d*|x|X|\\\\*))?(-([0-9A-Za-z\\\\-]+(\\\\.[0-9A-Za-z\\\\-]+)*))?(\\\\+([0-9A-Za-z\\\\-]+(\\\\.[0-9A-Za-z\\\\-]+)*))?)\\\\s*)((?:\\\\s+|,\\\\s*|\\\\s*\\\\|\\\\|\\\\s*)(=||!=|>|<|>=|=>|<=|=<|~|~>|\\\\^)\\\\s*(v?(0|[1-9]\\\\d*|x|X|\\\\*])(\\\\.(0|[1-9]\\\\d*|x|X|\\\\*))?(\\\\.(0|[1-9]\\\\d*|x|X|\\\\*]))?(-([0-9A-Za-z\\\\-]+(\\\\.[0-9A-Za-z\\\\-]+)*))?(\\\\+([0-9A-Za-z\\\\-]+(\\\\.[0-9A-Za-z\\\\-]+)*))?)\\\\s*)*$\")",message="invalid version expression"
+	// +optional
+	BundleVersion string `json:"bundleVersion,omitempty"`
+
+	// selector is optional and filters the set of ClusterCatalogs searched for bundles that provide the API.
+	//
+	// When unspecified, all ClusterCatalogs are searched.
+	//
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// upgradeConstraintPolicy is optional and controls whether the upgrade paths defined in the catalog
+	// are enforced for the package of the installed bundle.
+	//
+	// Allowed values are "CatalogProvided", "SelfCertified", or omitted.
+	//
+	// When set to "CatalogProvided", automatic upgrades only occur when upgrade constraints specified by the package
+	// author are met.
+	//
+	// When set to "SelfCertified", the upgrade constraints specified by the package author are ignored.
+	// This allows upgrades and downgrades to any version of the package.
+	// This is considered a dangerous operation as it can lead to unknown and potentially disastrous outcomes,
+	// such as data loss.
+	// Use this option only if you have independently verified the changes.
+	//
+	// When omitted, the default value is "CatalogProvided".
+	//
+	// Upgrades are always resolved from the package of the installed bundle, even when other packages also
+	// provide the API.
+	//
+	// +kubebuilder:validation:Enum:=CatalogProvided;SelfCertified
+	// +kubebuilder:default:=CatalogProvided
+	// +optional
+	UpgradeConstraintPolicy UpgradeConstraintPolicy `json:"upgradeConstraintPolicy,omitempty"`
 }
 
 // ServiceAccountReference identifies the serviceAccount used fo install a ClusterExtension.
@@ -445,6 +657,10 @@ const (
 	TypeChannelDeprecated = "ChannelDeprecated"
 	TypeBundleDeprecated  = "BundleDeprecated"
 
+	// TypeBundleDenied reports whether the installed bundle is denied by a
+	// ClusterBundleDenyPolicy.
+	TypeBundleDenied = "BundleDenied"
+
 	// None will not perform CRD upgrade safety checks.
 	CRDUpgradeSafetyEnforcementNone CRDUpgradeSafetyEnforcement = "None"
 	// Strict will enforce the CRD upgrade safety check and block the upgrade if the CRD would not pass the check.
@@ -560,6 +776,16 @@ type ClusterExtensionInstallStatus struct {
 	//
 	// +required
 	Bundle BundleMetadata `json:"bundle"`
+
+	// catalog is the name of the ClusterCatalog that provided the installed bundle, or the key
+	// <namespace>_<name> of the namespaced Catalog that provided it.
+	//
+	// It is omitted when the catalog is unknown, such as for bundles installed before the catalog
+	// was recorded.
+	//
+	// <opcon:experimental>
+	// +optional
+	Catalog string `json:"catalog,omitempty"`
 }
 
 // +genclient
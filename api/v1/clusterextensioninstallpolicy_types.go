@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterExtensionInstallPolicy restricts what ClusterExtensions may install with
+// CEL expressions.
+//
+// The validations of a policy are evaluated against the ClusterExtension when it
+// is admitted, and against the ClusterExtension and its resolved bundle before the
+// bundle is unpacked. A ClusterExtension that fails a validation is rejected at
+// admission, or blocked from installing the resolved bundle.
+type ClusterExtensionInstallPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata"`
+
+	// spec is a required field that defines the validations of the policy.
+	// +required
+	Spec ClusterExtensionInstallPolicySpec `json:"spec"`
+}
+
+// ClusterExtensionInstallPolicySpec defines the validations of a ClusterExtensionInstallPolicy.
+type ClusterExtensionInstallPolicySpec struct {
+	// namespaceSelector is an optional field that restricts the policy to the
+	// ClusterExtensions whose namespace, as defined by their spec.namespace field,
+	// has labels matching the selector.
+	//
+	// When unspecified, the policy applies to all ClusterExtensions.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// validations is a required list of the CEL expressions that ClusterExtensions
+	// must satisfy. A ClusterExtension must satisfy all of them.
+	// You can specify no more than 64 validations.
+	//
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=64
+	// +listType=atomic
+	// +required
+	Validations []InstallPolicyValidation `json:"validations"`
+}
+
+// InstallPolicyValidation is a CEL expression that ClusterExtensions must satisfy.
+type InstallPolicyValidation struct {
+	// expression is a required CEL expression that must evaluate to true for
+	// the ClusterExtension to be allowed. The expression can access the following
+	// variables:
+	//
+	//   - "object" is the ClusterExtension, e.g. object.spec.source.catalog.packageName.
+	//   - "bundle" is the resolved bundle, with the fields "name", "package", "version",
+	//     "catalog", "properties" (a list of objects with "type" and "value"),
+	//     "providedGVKs" (a list of objects with "group", "version" and "kind") and
+	//     "installModes" (a list of the supported install mode types, e.g. "AllNamespaces").
+	//
+	// Expressions that access the "bundle" variable are only evaluated once a bundle is
+	// resolved, and not at admission. An expression that fails to evaluate is treated as
+	// not satisfied.
+	//
+	// Example: !has(object.spec.source.catalog.upgradeConstraintPolicy) || object.spec.source.catalog.upgradeConstraintPolicy != 'SelfCertified'
+	//
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=4096
+	// +required
+	Expression string `json:"expression"`
+
+	// message is a required, human-readable explanation of the validation. It is
+	// reported when a ClusterExtension does not satisfy the expression.
+	//
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=1024
+	// +required
+	Message string `json:"message"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterExtensionInstallPolicyList contains a list of ClusterExtensionInstallPolicy
+type ClusterExtensionInstallPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata"`
+
+	// items is a list of ClusterExtensionInstallPolicies.
+	// items is required.
+	// +required
+	Items []ClusterExtensionInstallPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &ClusterExtensionInstallPolicy{}, &ClusterExtensionInstallPolicyList{})
+		return nil
+	})
+}
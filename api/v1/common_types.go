@@ -34,6 +34,10 @@ const (
 	ReasonNotDeprecated            = "NotDeprecated"
 	ReasonDeprecationStatusUnknown = "DeprecationStatusUnknown"
 
+	// Bundle denial reasons
+	ReasonDenied    = "Denied"
+	ReasonNotDenied = "NotDenied"
+
 	// Common reasons
 	ReasonSucceeded                = "Succeeded"
 	ReasonFailed                   = "Failed"
@@ -43,6 +43,21 @@ func (in *Assertion) DeepCopy() *Assertion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleDenyEntry) DeepCopyInto(out *BundleDenyEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleDenyEntry.
+func (in *BundleDenyEntry) DeepCopy() *BundleDenyEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleDenyEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleMetadata) DeepCopyInto(out *BundleMetadata) {
 	*out = *in
@@ -78,6 +93,33 @@ func (in *CRDUpgradeSafetyPreflightConfig) DeepCopy() *CRDUpgradeSafetyPreflight
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Catalog) DeepCopyInto(out *Catalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Catalog.
+func (in *Catalog) DeepCopy() *Catalog {
+	if in == nil {
+		return nil
+	}
+	out := new(Catalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Catalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatalogFilter) DeepCopyInto(out *CatalogFilter) {
 	*out = *in
@@ -90,6 +132,11 @@ func (in *CatalogFilter) DeepCopyInto(out *CatalogFilter) {
 		in, out := &in.Selector, &out.Selector
 		*out = (*in).DeepCopy()
 	}
+	if in.BundlePullConfig != nil {
+		in, out := &in.BundlePullConfig, &out.BundlePullConfig
+		*out = new(ImagePullConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogFilter.
@@ -102,6 +149,38 @@ func (in *CatalogFilter) DeepCopy() *CatalogFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogList) DeepCopyInto(out *CatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Catalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogList.
+func (in *CatalogList) DeepCopy() *CatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatalogSource) DeepCopyInto(out *CatalogSource) {
 	*out = *in
@@ -122,6 +201,84 @@ func (in *CatalogSource) DeepCopy() *CatalogSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBundleDenyPolicy) DeepCopyInto(out *ClusterBundleDenyPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBundleDenyPolicy.
+func (in *ClusterBundleDenyPolicy) DeepCopy() *ClusterBundleDenyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBundleDenyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBundleDenyPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBundleDenyPolicyList) DeepCopyInto(out *ClusterBundleDenyPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterBundleDenyPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBundleDenyPolicyList.
+func (in *ClusterBundleDenyPolicyList) DeepCopy() *ClusterBundleDenyPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBundleDenyPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBundleDenyPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBundleDenyPolicySpec) DeepCopyInto(out *ClusterBundleDenyPolicySpec) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]BundleDenyEntry, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBundleDenyPolicySpec.
+func (in *ClusterBundleDenyPolicySpec) DeepCopy() *ClusterBundleDenyPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBundleDenyPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterCatalog) DeepCopyInto(out *ClusterCatalog) {
 	*out = *in
@@ -314,6 +471,89 @@ func (in *ClusterExtensionInstallConfig) DeepCopy() *ClusterExtensionInstallConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtensionInstallPolicy) DeepCopyInto(out *ClusterExtensionInstallPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionInstallPolicy.
+func (in *ClusterExtensionInstallPolicy) DeepCopy() *ClusterExtensionInstallPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtensionInstallPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterExtensionInstallPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtensionInstallPolicyList) DeepCopyInto(out *ClusterExtensionInstallPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterExtensionInstallPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionInstallPolicyList.
+func (in *ClusterExtensionInstallPolicyList) DeepCopy() *ClusterExtensionInstallPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtensionInstallPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterExtensionInstallPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtensionInstallPolicySpec) DeepCopyInto(out *ClusterExtensionInstallPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make([]InstallPolicyValidation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionInstallPolicySpec.
+func (in *ClusterExtensionInstallPolicySpec) DeepCopy() *ClusterExtensionInstallPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtensionInstallPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterExtensionInstallStatus) DeepCopyInto(out *ClusterExtensionInstallStatus) {
 	*out = *in
@@ -622,6 +862,28 @@ func (in *FieldsEqualProbe) DeepCopy() *FieldsEqualProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullConfig) DeepCopyInto(out *ImagePullConfig) {
+	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]RegistryConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullConfig.
+func (in *ImagePullConfig) DeepCopy() *ImagePullConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSource) DeepCopyInto(out *ImageSource) {
 	*out = *in
@@ -630,6 +892,11 @@ func (in *ImageSource) DeepCopyInto(out *ImageSource) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.PullConfig != nil {
+		in, out := &in.PullConfig, &out.PullConfig
+		*out = new(ImagePullConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSource.
@@ -642,6 +909,21 @@ func (in *ImageSource) DeepCopy() *ImageSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallPolicyValidation) DeepCopyInto(out *InstallPolicyValidation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallPolicyValidation.
+func (in *InstallPolicyValidation) DeepCopy() *InstallPolicyValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallPolicyValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectSelector) DeepCopyInto(out *ObjectSelector) {
 	*out = *in
@@ -730,6 +1012,46 @@ func (in *ProgressionProbe) DeepCopy() *ProgressionProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvidedAPIFilter) DeepCopyInto(out *ProvidedAPIFilter) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvidedAPIFilter.
+func (in *ProvidedAPIFilter) DeepCopy() *ProvidedAPIFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvidedAPIFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryConfig) DeepCopyInto(out *RegistryConfig) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryConfig.
+func (in *RegistryConfig) DeepCopy() *RegistryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedCatalogSource) DeepCopyInto(out *ResolvedCatalogSource) {
 	*out = *in
@@ -810,6 +1132,11 @@ func (in *SourceConfig) DeepCopyInto(out *SourceConfig) {
 		*out = new(CatalogFilter)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProvidedAPI != nil {
+		in, out := &in.ProvidedAPI, &out.ProvidedAPI
+		*out = new(ProvidedAPIFilter)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceConfig.
@@ -123,6 +123,26 @@ type CatalogFilterApplyConfiguration struct {
 	//
 	// For more information on semver, please see https://semver.org/
 	Version *string `json:"version,omitempty"`
+	// release is an optional constraint on the release of the bundles of the package, in addition to the
+	// version field. A bundle that is rebuilt without changing its version, for example to fix a CVE, is
+	// published with a new release.
+	//
+	// A release constraint is composed of one or more comma-delimited comparison strings, all of which
+	// must be satisfied. A comparison string is a release, optionally preceded by one of the operators
+	// "=", "!=", ">", ">=", "<" or "<=". Without an operator, the release of the bundle must match exactly.
+	// Releases are period-delimited identifiers, such as "3" or "2.1", and are compared like the
+	// pre-release part of a semver version. A bundle without a release is lower than any release.
+	//
+	// The constraint applies to the release of every candidate bundle, whatever its version, so it is
+	// typically combined with a pinned version. For example, setting version to "1.4.2" and release to ">=3"
+	// installs release 3 or a later release of version 1.4.2.
+	//
+	// When unspecified, bundles of any release are installed.
+	//
+	// Acceptable release constraints are no longer than 64 characters.
+	//
+	// <opcon:experimental>
+	Release *string `json:"release,omitempty"`
 	// channels is optional and specifies a set of channels belonging to the package
 	// specified in the packageName field.
 	//
@@ -181,6 +201,56 @@ type CatalogFilterApplyConfiguration struct {
 	//
 	// When omitted, the default value is "CatalogProvided".
 	UpgradeConstraintPolicy *apiv1.UpgradeConstraintPolicy `json:"upgradeConstraintPolicy,omitempty"`
+	// upgradeScope is optional and restricts the versions that an installed bundle is upgraded to, relative to
+	// the version of the installed bundle. It applies in addition to the upgradeConstraintPolicy, so that, for
+	// example, upgrade edges defined in the catalog are only followed within the upgrade scope.
+	//
+	// Allowed values are "PatchOnly", "MinorOnly", "NoMajor", or omitted.
+	//
+	// When set to "PatchOnly", only versions with the same major and minor version as the installed bundle are
+	// considered. For example, 1.2.3 may be upgraded to 1.2.4, but not to 1.3.0.
+	//
+	// When set to "MinorOnly", only versions with the same major version as the installed bundle, and a minor
+	// version at most one greater, are considered. For example, 1.2.3 may be upgraded to 1.3.5, but not to 1.4.0.
+	//
+	// When set to "NoMajor", only versions with the same major version as the installed bundle are considered.
+	// For example, 1.2.3 may be upgraded to 1.9.0, but not to 2.0.0.
+	//
+	// When omitted, upgrades are not restricted relative to the version of the installed bundle.
+	// The upgrade scope does not apply to the initial installation of a bundle.
+	//
+	// <opcon:experimental>
+	UpgradeScope *apiv1.UpgradeScope `json:"upgradeScope,omitempty"`
+	// catalogStickiness is optional and controls whether upgrades are resolved from the catalog that provided
+	// the installed bundle, which is reported in the status.install.catalog field, when the package is available
+	// in multiple catalogs.
+	//
+	// Allowed values are "Preferred", "Required", "None", or omitted.
+	//
+	// When set to "Preferred", upgrades are resolved from the catalog that provided the installed bundle when it
+	// provides a bundle matching the other fields, even when other catalogs have a higher or the same priority.
+	// Otherwise, upgrades are resolved from all catalogs.
+	//
+	// When set to "Required", upgrades are only resolved from the catalog that provided the installed bundle.
+	// Upgrades are blocked while that catalog is not selected by the selector field, or is unavailable.
+	//
+	// When set to "None", upgrades are resolved from all catalogs, and the priority of the catalogs decides which
+	// catalog is used when several catalogs provide a matching bundle.
+	//
+	// When omitted, the default value is "Preferred".
+	// Catalog stickiness has no effect on the initial installation of a bundle, or when the catalog that
+	// provided the installed bundle is unknown.
+	//
+	// <opcon:experimental>
+	CatalogStickiness *apiv1.CatalogStickiness `json:"catalogStickiness,omitempty"`
+	// bundlePullConfig is an optional field that configures the credentials and registries used to pull
+	// the image of the resolved bundle. The pull secret must exist in the namespace of the ClusterExtension.
+	//
+	// When omitted, the bundle image is pulled with the global pull secret and registry configuration
+	// of operator-controller.
+	//
+	// <opcon:experimental>
+	BundlePullConfig *ImagePullConfigApplyConfiguration `json:"bundlePullConfig,omitempty"`
 }
 
 // CatalogFilterApplyConfiguration constructs a declarative configuration of the CatalogFilter type for use with
@@ -205,6 +275,14 @@ func (b *CatalogFilterApplyConfiguration) WithVersion(value string) *CatalogFilt
 	return b
 }
 
+// WithRelease sets the Release field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Release field is set to the value of the last call.
+func (b *CatalogFilterApplyConfiguration) WithRelease(value string) *CatalogFilterApplyConfiguration {
+	b.Release = &value
+	return b
+}
+
 // WithChannels adds the given value to the Channels field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Channels field.
@@ -230,3 +308,27 @@ func (b *CatalogFilterApplyConfiguration) WithUpgradeConstraintPolicy(value apiv
 	b.UpgradeConstraintPolicy = &value
 	return b
 }
+
+// WithUpgradeScope sets the UpgradeScope field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeScope field is set to the value of the last call.
+func (b *CatalogFilterApplyConfiguration) WithUpgradeScope(value apiv1.UpgradeScope) *CatalogFilterApplyConfiguration {
+	b.UpgradeScope = &value
+	return b
+}
+
+// WithCatalogStickiness sets the CatalogStickiness field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CatalogStickiness field is set to the value of the last call.
+func (b *CatalogFilterApplyConfiguration) WithCatalogStickiness(value apiv1.CatalogStickiness) *CatalogFilterApplyConfiguration {
+	b.CatalogStickiness = &value
+	return b
+}
+
+// WithBundlePullConfig sets the BundlePullConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BundlePullConfig field is set to the value of the last call.
+func (b *CatalogFilterApplyConfiguration) WithBundlePullConfig(value *ImagePullConfigApplyConfiguration) *CatalogFilterApplyConfiguration {
+	b.BundlePullConfig = value
+	return b
+}
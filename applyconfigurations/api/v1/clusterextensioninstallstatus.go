@@ -27,6 +27,14 @@ type ClusterExtensionInstallStatusApplyConfiguration struct {
 	// A "bundle" is a versioned set of content that represents the resources that need to be applied
 	// to a cluster to install a package.
 	Bundle *BundleMetadataApplyConfiguration `json:"bundle,omitempty"`
+	// catalog is the name of the ClusterCatalog that provided the installed bundle, or the key
+	// <namespace>_<name> of the namespaced Catalog that provided it.
+	//
+	// It is omitted when the catalog is unknown, such as for bundles installed before the catalog
+	// was recorded.
+	//
+	// <opcon:experimental>
+	Catalog *string `json:"catalog,omitempty"`
 }
 
 // ClusterExtensionInstallStatusApplyConfiguration constructs a declarative configuration of the ClusterExtensionInstallStatus type for use with
@@ -42,3 +50,11 @@ func (b *ClusterExtensionInstallStatusApplyConfiguration) WithBundle(value *Bund
 	b.Bundle = value
 	return b
 }
+
+// WithCatalog sets the Catalog field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Catalog field is set to the value of the last call.
+func (b *ClusterExtensionInstallStatusApplyConfiguration) WithCatalog(value string) *ClusterExtensionInstallStatusApplyConfiguration {
+	b.Catalog = &value
+	return b
+}
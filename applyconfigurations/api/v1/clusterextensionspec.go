@@ -43,8 +43,14 @@ type ClusterExtensionSpecApplyConfiguration struct {
 	// source is required and selects the installation source of content for this ClusterExtension.
 	// Set the sourceType field to perform the selection.
 	//
+	// <opcon:standard:description>
 	// Catalog is currently the only implemented sourceType.
 	// Setting sourceType to "Catalog" requires the catalog field to also be defined.
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Setting sourceType to "Catalog" requires the catalog field to also be defined.
+	// Setting sourceType to "ProvidedAPI" requires the providedAPI field to also be defined.
+	// </opcon:experimental:description>
 	//
 	// Below is a minimal example of a source definition (in yaml):
 	//
@@ -52,6 +58,8 @@ type ClusterExtensionSpecApplyConfiguration struct {
 	// sourceType: Catalog
 	// catalog:
 	// packageName: example-package
+	//
+	// <opcon:experimental:validation:XValidation:rule="has(self.sourceType) && self.sourceType == 'ProvidedAPI' ? has(self.providedAPI) : !has(self.providedAPI)",message="providedAPI is required when sourceType is ProvidedAPI, and forbidden otherwise">
 	Source *SourceConfigApplyConfiguration `json:"source,omitempty"`
 	// install is optional and configures installation options for the ClusterExtension,
 	// such as the pre-flight check configuration.
@@ -67,6 +67,15 @@ type ImageSourceApplyConfiguration struct {
 	//
 	// When omitted, the image is not polled for new content.
 	PollIntervalMinutes *int `json:"pollIntervalMinutes,omitempty"`
+	// pullConfig is an optional field that configures the credentials and registries used to pull the image.
+	//
+	// For a ClusterCatalog, the pull secret must exist in the namespace that catalogd runs in.
+	// For a Catalog, the pull secret must exist in the namespace of the Catalog.
+	//
+	// When omitted, the image is pulled with the global pull secret and registry configuration of catalogd.
+	//
+	// <opcon:experimental>
+	PullConfig *ImagePullConfigApplyConfiguration `json:"pullConfig,omitempty"`
 }
 
 // ImageSourceApplyConfiguration constructs a declarative configuration of the ImageSource type for use with
@@ -90,3 +99,11 @@ func (b *ImageSourceApplyConfiguration) WithPollIntervalMinutes(value int) *Imag
 	b.PollIntervalMinutes = &value
 	return b
 }
+
+// WithPullConfig sets the PullConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullConfig field is set to the value of the last call.
+func (b *ImageSourceApplyConfiguration) WithPullConfig(value *ImagePullConfigApplyConfiguration) *ImageSourceApplyConfiguration {
+	b.PullConfig = value
+	return b
+}
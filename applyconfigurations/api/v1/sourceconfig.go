@@ -24,15 +24,34 @@ package v1
 type SourceConfigApplyConfiguration struct {
 	// sourceType is required and specifies the type of install source.
 	//
+	// <opcon:standard:description>
 	// The only allowed value is "Catalog".
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Allowed values are "Catalog" and "ProvidedAPI".
+	// </opcon:experimental:description>
 	//
 	// When set to "Catalog", information for determining the appropriate bundle of content to install
 	// is fetched from ClusterCatalog resources on the cluster.
 	// When using the Catalog sourceType, the catalog field must also be set.
+	//
+	// <opcon:experimental:description>
+	// When set to "ProvidedAPI", the bundle to install is the one that provides an API, whatever
+	// its package, and is fetched from ClusterCatalog resources on the cluster.
+	// When using the ProvidedAPI sourceType, the providedAPI field must also be set.
+	// </opcon:experimental:description>
+	//
+	// <opcon:standard:validation:Enum=Catalog>
+	// <opcon:experimental:validation:Enum=Catalog;ProvidedAPI>
 	SourceType *string `json:"sourceType,omitempty"`
 	// catalog configures how information is sourced from a catalog.
 	// It is required when sourceType is "Catalog", and forbidden otherwise.
 	Catalog *CatalogFilterApplyConfiguration `json:"catalog,omitempty"`
+	// providedAPI configures how the bundle providing an API is sourced from catalogs.
+	// It is required when sourceType is "ProvidedAPI", and forbidden otherwise.
+	//
+	// <opcon:experimental>
+	ProvidedAPI *ProvidedAPIFilterApplyConfiguration `json:"providedAPI,omitempty"`
 }
 
 // SourceConfigApplyConfiguration constructs a declarative configuration of the SourceConfig type for use with
@@ -56,3 +75,11 @@ func (b *SourceConfigApplyConfiguration) WithCatalog(value *CatalogFilterApplyCo
 	b.Catalog = value
 	return b
 }
+
+// WithProvidedAPI sets the ProvidedAPI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProvidedAPI field is set to the value of the last call.
+func (b *SourceConfigApplyConfiguration) WithProvidedAPI(value *ProvidedAPIFilterApplyConfiguration) *SourceConfigApplyConfiguration {
+	b.ProvidedAPI = value
+	return b
+}
@@ -55,6 +55,23 @@ var schemaYAML = typed.YAMLObject(`types:
         namedType: com.github.operator-framework.operator-controller.api.v1.ProbeType
 - name: com.github.operator-framework.operator-controller.api.v1.AvailabilityMode
   scalar: string
+- name: com.github.operator-framework.operator-controller.api.v1.BundleDenyEntry
+  map:
+    fields:
+    - name: imageDigest
+      type:
+        scalar: string
+    - name: packageName
+      type:
+        scalar: string
+    - name: reason
+      type:
+        scalar: string
+    - name: versionRange
+      type:
+        scalar: string
+- name: com.github.operator-framework.operator-controller.api.v1.BundleDenyRemediation
+  scalar: string
 - name: com.github.operator-framework.operator-controller.api.v1.BundleMetadata
   map:
     fields:
@@ -75,9 +92,33 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: enforcement
       type:
         namedType: com.github.operator-framework.operator-controller.api.v1.CRDUpgradeSafetyEnforcement
+- name: com.github.operator-framework.operator-controller.api.v1.Catalog
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+    - name: spec
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.ClusterCatalogSpec
+    - name: status
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.ClusterCatalogStatus
 - name: com.github.operator-framework.operator-controller.api.v1.CatalogFilter
   map:
     fields:
+    - name: bundlePullConfig
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.ImagePullConfig
+    - name: catalogStickiness
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.CatalogStickiness
     - name: channels
       type:
         list:
@@ -87,6 +128,9 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: packageName
       type:
         scalar: string
+    - name: release
+      type:
+        scalar: string
     - name: selector
       type:
         namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
@@ -94,9 +138,14 @@ var schemaYAML = typed.YAMLObject(`types:
       type:
         namedType: com.github.operator-framework.operator-controller.api.v1.UpgradeConstraintPolicy
       default: CatalogProvided
+    - name: upgradeScope
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.UpgradeScope
     - name: version
       type:
         scalar: string
+- name: com.github.operator-framework.operator-controller.api.v1.CatalogStickiness
+  scalar: string
 - name: com.github.operator-framework.operator-controller.api.v1.CatalogSource
   map:
     fields:
@@ -106,6 +155,33 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: type
       type:
         namedType: com.github.operator-framework.operator-controller.api.v1.SourceType
+- name: com.github.operator-framework.operator-controller.api.v1.ClusterBundleDenyPolicy
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+    - name: spec
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.ClusterBundleDenyPolicySpec
+- name: com.github.operator-framework.operator-controller.api.v1.ClusterBundleDenyPolicySpec
+  map:
+    fields:
+    - name: entries
+      type:
+        list:
+          elementType:
+            namedType: com.github.operator-framework.operator-controller.api.v1.BundleDenyEntry
+          elementRelationship: atomic
+    - name: remediation
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.BundleDenyRemediation
 - name: com.github.operator-framework.operator-controller.api.v1.ClusterCatalog
   map:
     fields:
@@ -199,12 +275,42 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: preflight
       type:
         namedType: com.github.operator-framework.operator-controller.api.v1.PreflightConfig
+- name: com.github.operator-framework.operator-controller.api.v1.ClusterExtensionInstallPolicy
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+    - name: spec
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.ClusterExtensionInstallPolicySpec
+- name: com.github.operator-framework.operator-controller.api.v1.ClusterExtensionInstallPolicySpec
+  map:
+    fields:
+    - name: namespaceSelector
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
+    - name: validations
+      type:
+        list:
+          elementType:
+            namedType: com.github.operator-framework.operator-controller.api.v1.InstallPolicyValidation
+          elementRelationship: atomic
 - name: com.github.operator-framework.operator-controller.api.v1.ClusterExtensionInstallStatus
   map:
     fields:
     - name: bundle
       type:
         namedType: com.github.operator-framework.operator-controller.api.v1.BundleMetadata
+    - name: catalog
+      type:
+        scalar: string
 - name: com.github.operator-framework.operator-controller.api.v1.ClusterExtensionSpec
   map:
     fields:
@@ -372,15 +478,41 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: fieldB
       type:
         scalar: string
+- name: com.github.operator-framework.operator-controller.api.v1.ImagePullConfig
+  map:
+    fields:
+    - name: pullSecret
+      type:
+        scalar: string
+    - name: registries
+      type:
+        list:
+          elementType:
+            namedType: com.github.operator-framework.operator-controller.api.v1.RegistryConfig
+          elementRelationship: associative
+          keys:
+          - prefix
 - name: com.github.operator-framework.operator-controller.api.v1.ImageSource
   map:
     fields:
     - name: pollIntervalMinutes
       type:
         scalar: numeric
+    - name: pullConfig
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.ImagePullConfig
     - name: ref
       type:
         scalar: string
+- name: com.github.operator-framework.operator-controller.api.v1.InstallPolicyValidation
+  map:
+    fields:
+    - name: expression
+      type:
+        scalar: string
+    - name: message
+      type:
+        scalar: string
 - name: com.github.operator-framework.operator-controller.api.v1.ObjectSelector
   map:
     fields:
@@ -434,6 +566,42 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: selector
       type:
         namedType: com.github.operator-framework.operator-controller.api.v1.ObjectSelector
+- name: com.github.operator-framework.operator-controller.api.v1.ProvidedAPIFilter
+  map:
+    fields:
+    - name: bundleVersion
+      type:
+        scalar: string
+    - name: group
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: selector
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
+    - name: upgradeConstraintPolicy
+      type:
+        scalar: string
+    - name: version
+      type:
+        scalar: string
+- name: com.github.operator-framework.operator-controller.api.v1.RegistryConfig
+  map:
+    fields:
+    - name: insecure
+      type:
+        scalar: boolean
+    - name: mirrors
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
+    - name: prefix
+      type:
+        scalar: string
 - name: com.github.operator-framework.operator-controller.api.v1.ResolvedCatalogSource
   map:
     fields:
@@ -477,6 +645,9 @@ var schemaYAML = typed.YAMLObject(`types:
     - name: catalog
       type:
         namedType: com.github.operator-framework.operator-controller.api.v1.CatalogFilter
+    - name: providedAPI
+      type:
+        namedType: com.github.operator-framework.operator-controller.api.v1.ProvidedAPIFilter
     - name: sourceType
       type:
         scalar: string
@@ -484,6 +655,8 @@ var schemaYAML = typed.YAMLObject(`types:
   scalar: string
 - name: com.github.operator-framework.operator-controller.api.v1.UpgradeConstraintPolicy
   scalar: string
+- name: com.github.operator-framework.operator-controller.api.v1.UpgradeScope
+  scalar: string
 - name: io.k8s.apiextensions-apiserver.pkg.apis.apiextensions.v1.JSON
   scalar: untyped
   list:
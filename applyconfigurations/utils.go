@@ -33,12 +33,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=olm.operatorframework.io, Version=v1
 	case v1.SchemeGroupVersion.WithKind("Assertion"):
 		return &apiv1.AssertionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BundleDenyEntry"):
+		return &apiv1.BundleDenyEntryApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("BundleMetadata"):
 		return &apiv1.BundleMetadataApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Catalog"):
+		return &apiv1.CatalogApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CatalogFilter"):
 		return &apiv1.CatalogFilterApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CatalogSource"):
 		return &apiv1.CatalogSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterBundleDenyPolicy"):
+		return &apiv1.ClusterBundleDenyPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterBundleDenyPolicySpec"):
+		return &apiv1.ClusterBundleDenyPolicySpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterCatalog"):
 		return &apiv1.ClusterCatalogApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterCatalogSpec"):
@@ -53,6 +61,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1.ClusterExtensionConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterExtensionInstallConfig"):
 		return &apiv1.ClusterExtensionInstallConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterExtensionInstallPolicy"):
+		return &apiv1.ClusterExtensionInstallPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterExtensionInstallPolicySpec"):
+		return &apiv1.ClusterExtensionInstallPolicySpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterExtensionInstallStatus"):
 		return &apiv1.ClusterExtensionInstallStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterExtensionSpec"):
@@ -77,8 +89,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1.FieldsEqualProbeApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("FieldValueProbe"):
 		return &apiv1.FieldValueProbeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ImagePullConfig"):
+		return &apiv1.ImagePullConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ImageSource"):
 		return &apiv1.ImageSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("InstallPolicyValidation"):
+		return &apiv1.InstallPolicyValidationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectSelector"):
 		return &apiv1.ObjectSelectorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectSourceRef"):
@@ -89,6 +105,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1.PreflightConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ProgressionProbe"):
 		return &apiv1.ProgressionProbeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ProvidedAPIFilter"):
+		return &apiv1.ProvidedAPIFilterApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RegistryConfig"):
+		return &apiv1.RegistryConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ResolvedCatalogSource"):
 		return &apiv1.ResolvedCatalogSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ResolvedImageSource"):
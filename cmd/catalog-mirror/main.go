@@ -0,0 +1,127 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.podman.io/image/v5/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/operator-controller/internal/catalogmirror"
+	"github.com/operator-framework/operator-controller/internal/shared/version"
+)
+
+type config struct {
+	packages      []string
+	channels      []string
+	versionRange  string
+	toRegistry    string
+	toOCILayout   string
+	outputDir     string
+	authFile      string
+	srcTLSVerify  bool
+	destTLSVerify bool
+	parallelism   int
+}
+
+var cfg = &config{}
+
+var catalogMirrorCmd = &cobra.Command{
+	Use:   "catalog-mirror <catalog-image>",
+	Short: "Mirrors a catalog image and the bundle and related images it references",
+	Long: `Mirrors a catalog image, and the bundle images and related images referenced by the
+packages, channels and versions of its file-based catalog selected by the filter flags, to
+a registry or an OCI layout directory.
+
+When mirroring to a registry, the output directory receives the FBC of the selected part of
+the catalog, with images referenced by their mirrors, and a containers-registries.conf(5)
+configuration pulling all mirrored images from their mirrors.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		_, err := catalogmirror.Run(ctx, catalogmirror.Options{
+			CatalogImage: args[0],
+			Filter: catalogmirror.Filter{
+				Packages:     cfg.packages,
+				Channels:     cfg.channels,
+				VersionRange: cfg.versionRange,
+			},
+			Destination: catalogmirror.Destination{
+				Registry:  cfg.toRegistry,
+				OCILayout: cfg.toOCILayout,
+			},
+			OutputDir: cfg.outputDir,
+			SourceCtx: &types.SystemContext{
+				AuthFilePath:                cfg.authFile,
+				DockerInsecureSkipTLSVerify: types.NewOptionalBool(!cfg.srcTLSVerify),
+			},
+			DestinationCtx: &types.SystemContext{
+				AuthFilePath:                cfg.authFile,
+				DockerInsecureSkipTLSVerify: types.NewOptionalBool(!cfg.destTLSVerify),
+			},
+			Parallelism: cfg.parallelism,
+		})
+		return err
+	},
+}
+
+var versionCommand = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version information",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("%#v\n", version.String())
+	},
+}
+
+func init() {
+	flags := catalogMirrorCmd.Flags()
+	flags.StringSliceVar(&cfg.packages, "package", nil, "Package to mirror. May be repeated. All packages are mirrored when not set.")
+	flags.StringSliceVar(&cfg.channels, "channel", nil, "Channel to mirror. May be repeated. All channels of the mirrored packages are mirrored when not set.")
+	flags.StringVar(&cfg.versionRange, "version", "", "Semver range, with the syntax of the version field of ClusterExtensions, of the versions of the bundles to mirror")
+	flags.StringVar(&cfg.toRegistry, "to-registry", "", "Registry, and optionally repository namespace, to mirror images to, e.g. mirror.example.com:5000/olm")
+	flags.StringVar(&cfg.toOCILayout, "to-oci-layout", "", "Directory of the OCI layout to mirror images to")
+	flags.StringVar(&cfg.outputDir, "output-dir", ".", "Directory the mirrored FBC and registries configuration are written to")
+	flags.StringVar(&cfg.authFile, "authfile", "", "Path of the authentication file for the source and destination registries. The default authentication files of containers-auth.json(5) are used when not set.")
+	flags.BoolVar(&cfg.srcTLSVerify, "src-tls-verify", true, "Require HTTPS and verify certificates when pulling images from their source registries")
+	flags.BoolVar(&cfg.destTLSVerify, "dest-tls-verify", true, "Require HTTPS and verify certificates when pushing images to the destination registry")
+	flags.IntVar(&cfg.parallelism, "parallelism", 4, "Number of images mirrored concurrently")
+	catalogMirrorCmd.MarkFlagsOneRequired("to-registry", "to-oci-layout")
+	catalogMirrorCmd.MarkFlagsMutuallyExclusive("to-registry", "to-oci-layout")
+
+	catalogMirrorCmd.AddCommand(versionCommand)
+
+	klog.InitFlags(flag.CommandLine)
+	flags.AddGoFlagSet(flag.CommandLine)
+	log.SetLogger(klog.NewKlogr())
+}
+
+func main() {
+	if err := catalogMirrorCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"go.podman.io/image/v5/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	apimachineryrand "k8s.io/apimachinery/pkg/util/rand"
@@ -51,12 +53,15 @@ import (
 	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogd/auth"
 	corecontrollers "github.com/operator-framework/operator-controller/internal/catalogd/controllers/core"
 	"github.com/operator-framework/operator-controller/internal/catalogd/features"
 	"github.com/operator-framework/operator-controller/internal/catalogd/garbagecollection"
 	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
+	"github.com/operator-framework/operator-controller/internal/catalogd/registrywebhook"
 	"github.com/operator-framework/operator-controller/internal/catalogd/serverutil"
 	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage/s3"
 	"github.com/operator-framework/operator-controller/internal/catalogd/webhook"
 	sharedcontrollers "github.com/operator-framework/operator-controller/internal/shared/controllers"
 	cacheutil "github.com/operator-framework/operator-controller/internal/shared/util/cache"
@@ -81,22 +86,41 @@ const (
 )
 
 type config struct {
-	metricsAddr          string
-	enableLeaderElection bool
-	probeAddr            string
-	pprofAddr            string
-	systemNamespace      string
-	catalogServerAddr    string
-	externalAddr         string
-	cacheDir             string
-	gcInterval           time.Duration
-	certFile             string
-	keyFile              string
-	webhookPort          int
-	pullCasDir           string
-	globalPullSecret     string
+	metricsAddr              string
+	enableLeaderElection     bool
+	probeAddr                string
+	pprofAddr                string
+	systemNamespace          string
+	catalogServerAddr        string
+	externalAddr             string
+	cacheDir                 string
+	gcInterval               time.Duration
+	gcDiskBudget             string
+	gcMinFreeSpace           string
+	gcFreeSpaceCheckInterval time.Duration
+	certFile                 string
+	keyFile                  string
+	webhookPort              int
+	pullCasDir               string
+	globalPullSecret         string
+	catalogServerAuth        bool
+	registryWebhookTokenFile string
+	objectStoreEndpoint      string
+	objectStoreBucket        string
+	objectStoreRegion        string
+	objectStorePrefix        string
+	objectStoreMode          string
+	catalogMaxImageSize      string
+	catalogMaxBlobSize       string
+	catalogMaxMetas          int
+	catalogMaxPackages       int
+	catalogMaxGraphQLFields  int
 	// Generated config
-	globalPullSecretKey *k8stypes.NamespacedName
+	globalPullSecretKey      *k8stypes.NamespacedName
+	gcDiskBudgetBytes        int64
+	gcMinFreeSpaceBytes      int64
+	catalogMaxImageSizeBytes int64
+	catalogMaxBlobSizeBytes  int64
 }
 
 var catalogdCmd = &cobra.Command{
@@ -131,11 +155,26 @@ func init() {
 	flags.StringVar(&cfg.externalAddr, "external-address", "catalogd-service.olmv1-system.svc", "External address for http(s) server")
 	flags.StringVar(&cfg.cacheDir, "cache-dir", "/var/cache/", "Directory for file based caching")
 	flags.DurationVar(&cfg.gcInterval, "gc-interval", 12*time.Hour, "Garbage collection interval")
+	flags.StringVar(&cfg.gcDiskBudget, "gc-disk-budget", "", "Maximum disk space used by the image unpack cache and stored catalog content, as a quantity (e.g. 2Gi). Unpacked images are evicted by garbage collection to stay within it. Unlimited when empty.")
+	flags.StringVar(&cfg.gcMinFreeSpace, "gc-min-free-space", "", "Minimum free space on the filesystem of the cache directory, as a quantity (e.g. 500Mi). Garbage collection runs and evicts unpacked images when free space drops below it. Disabled when empty.")
+	flags.DurationVar(&cfg.gcFreeSpaceCheckInterval, "gc-free-space-check-interval", time.Minute, "Interval at which free space is checked against gc-min-free-space")
 	flags.StringVar(&cfg.certFile, "tls-cert", "", "Certificate file for TLS")
 	flags.StringVar(&cfg.keyFile, "tls-key", "", "Key file for TLS")
 	flags.IntVar(&cfg.webhookPort, "webhook-server-port", 9443, "Webhook server port")
 	flags.StringVar(&cfg.pullCasDir, "pull-cas-dir", "", "The directory of TLS certificate authorities to use for verifying HTTPS connections to image registries.")
 	flags.StringVar(&cfg.globalPullSecret, "global-pull-secret", "", "Global pull secret (<namespace>/<name>)")
+	flags.StringVar(&cfg.objectStoreEndpoint, "object-store-endpoint", "", "Endpoint URL of an S3-compatible object store to store catalog content in, instead of only on local disk. Credentials are read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.")
+	flags.StringVar(&cfg.objectStoreBucket, "object-store-bucket", "", "Bucket of the object store to store catalog content in. Required with object-store-endpoint.")
+	flags.StringVar(&cfg.objectStoreRegion, "object-store-region", "us-east-1", "Region of the object store used to sign requests")
+	flags.StringVar(&cfg.objectStorePrefix, "object-store-prefix", "catalogs/", "Prefix of the keys of objects storing catalog content")
+	flags.StringVar(&cfg.objectStoreMode, "object-store-content-mode", string(storage.ObjectContentProxy), "How requests for the full content of a catalog stored in the object store are served: 'proxy' serves the content from catalogd, 'redirect' redirects clients to a presigned URL of the object store")
+	flags.BoolVar(&cfg.catalogServerAuth, "catalogs-server-auth", false, "Require clients of the catalogs server to present a bearer token and be authorized to get the clustercatalogs/content subresource of the requested catalog")
+	flags.StringVar(&cfg.catalogMaxImageSize, "catalog-max-uncompressed-size", "", "Maximum uncompressed size of the layers of a catalog image, as a quantity (e.g. 1Gi). Unlimited when empty.")
+	flags.StringVar(&cfg.catalogMaxBlobSize, "catalog-max-blob-size", "", "Maximum size of a single object of the content of a catalog, as a quantity (e.g. 1Mi). Unlimited when empty.")
+	flags.IntVar(&cfg.catalogMaxMetas, "catalog-max-metas", 0, "Maximum number of objects of the content of a catalog. Unlimited when 0.")
+	flags.IntVar(&cfg.catalogMaxPackages, "catalog-max-packages", 0, "Maximum number of packages of the content of a catalog. Unlimited when 0.")
+	flags.IntVar(&cfg.catalogMaxGraphQLFields, "catalog-max-graphql-fields", 0, "Maximum number of fields of the GraphQL schema of the content of a catalog, when GraphQL queries are enabled. Unlimited when 0.")
+	flags.StringVar(&cfg.registryWebhookTokenFile, "registry-webhook-token-file", "", "File containing the token registries must present to notify pushes at the "+registrywebhook.Path+" endpoint of the catalogs server. The endpoint is disabled when empty.")
 
 	// adds version subcommand
 	catalogdCmd.AddCommand(versionCommand)
@@ -188,6 +227,79 @@ func validateConfig(cfg *config) error {
 		cfg.globalPullSecretKey = &k8stypes.NamespacedName{Name: secretParts[1], Namespace: secretParts[0]}
 	}
 
+	if cfg.gcInterval <= 0 {
+		err := fmt.Errorf("gc-interval must be positive")
+		setupLog.Error(err, "invalid garbage collection configuration", "gcInterval", cfg.gcInterval)
+		return err
+	}
+	for _, q := range []struct {
+		flag  string
+		value string
+		bytes *int64
+	}{
+		{"gc-disk-budget", cfg.gcDiskBudget, &cfg.gcDiskBudgetBytes},
+		{"gc-min-free-space", cfg.gcMinFreeSpace, &cfg.gcMinFreeSpaceBytes},
+	} {
+		if q.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil || quantity.Sign() < 0 {
+			err := fmt.Errorf("value of %s should be a non-negative quantity: %q", q.flag, q.value)
+			setupLog.Error(err, "invalid garbage collection configuration")
+			return err
+		}
+		*q.bytes = quantity.Value()
+	}
+	for _, q := range []struct {
+		flag  string
+		value string
+		bytes *int64
+	}{
+		{"catalog-max-uncompressed-size", cfg.catalogMaxImageSize, &cfg.catalogMaxImageSizeBytes},
+		{"catalog-max-blob-size", cfg.catalogMaxBlobSize, &cfg.catalogMaxBlobSizeBytes},
+	} {
+		if q.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil || quantity.Sign() < 0 {
+			err := fmt.Errorf("value of %s should be a non-negative quantity: %q", q.flag, q.value)
+			setupLog.Error(err, "invalid catalog limits configuration")
+			return err
+		}
+		*q.bytes = quantity.Value()
+	}
+	for flag, value := range map[string]int{
+		"catalog-max-metas":          cfg.catalogMaxMetas,
+		"catalog-max-packages":       cfg.catalogMaxPackages,
+		"catalog-max-graphql-fields": cfg.catalogMaxGraphQLFields,
+	} {
+		if value < 0 {
+			err := fmt.Errorf("value of %s should not be negative: %d", flag, value)
+			setupLog.Error(err, "invalid catalog limits configuration")
+			return err
+		}
+	}
+	if cfg.gcMinFreeSpaceBytes > 0 && cfg.gcFreeSpaceCheckInterval <= 0 {
+		err := fmt.Errorf("gc-free-space-check-interval must be positive when gc-min-free-space is set")
+		setupLog.Error(err, "invalid garbage collection configuration", "gcFreeSpaceCheckInterval", cfg.gcFreeSpaceCheckInterval)
+		return err
+	}
+
+	if cfg.objectStoreEndpoint != "" && cfg.objectStoreBucket == "" {
+		err := errors.New("object-store-endpoint requires object-store-bucket flag")
+		setupLog.Error(err, "invalid object store configuration", "objectStoreEndpoint", cfg.objectStoreEndpoint)
+		return err
+	}
+	switch storage.ObjectContentMode(cfg.objectStoreMode) {
+	case storage.ObjectContentProxy, storage.ObjectContentRedirect:
+	default:
+		err := fmt.Errorf("invalid object-store-content-mode %q: must be %q or %q", cfg.objectStoreMode, storage.ObjectContentProxy, storage.ObjectContentRedirect)
+		setupLog.Error(err, "invalid object store configuration")
+		return err
+	}
+
 	return nil
 }
 
@@ -348,10 +460,14 @@ func run(ctx context.Context) error {
 			}
 			return srcContext, nil
 		},
+		PulledBytesFunc:     catalogdmetrics.RecordImagePull,
+		MaxUncompressedSize: cfg.catalogMaxImageSizeBytes,
 	}
 
 	var localStorage storage.Instance
 	metrics.Registry.MustRegister(catalogdmetrics.RequestDurationMetric)
+	metrics.Registry.MustRegister(catalogdmetrics.CatalogCollectors()...)
+	metrics.Registry.MustRegister(catalogdmetrics.GCCollectors()...)
 
 	storeDir := filepath.Join(cfg.cacheDir, storageDir)
 	if err := os.MkdirAll(storeDir, 0700); err != nil {
@@ -379,12 +495,40 @@ func run(ctx context.Context) error {
 		graphqlMode = storage.GraphQLQueriesDisabled
 	}
 
-	localStorage = storage.NewLocalDirV1(
+	localDir := storage.NewLocalDirV1(
 		storeDir,
 		baseStorageURL,
 		metasMode,
 		graphqlMode,
 	)
+	localDir.Limits = storage.ContentLimits{
+		MaxMetas:         cfg.catalogMaxMetas,
+		MaxBlobBytes:     cfg.catalogMaxBlobSizeBytes,
+		MaxPackages:      cfg.catalogMaxPackages,
+		MaxGraphQLFields: cfg.catalogMaxGraphQLFields,
+	}
+	localStorage = localDir
+
+	if cfg.objectStoreEndpoint != "" {
+		endpoint, err := url.Parse(cfg.objectStoreEndpoint)
+		if err != nil {
+			setupLog.Error(err, "unable to parse object store endpoint")
+			return err
+		}
+		credentials := s3.Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		bucket, err := s3.NewClient(endpoint, cfg.objectStoreBucket, cfg.objectStoreRegion, credentials, &http.Client{Timeout: 5 * time.Minute})
+		if err != nil {
+			setupLog.Error(err, "unable to create object store client")
+			return err
+		}
+		// The local directory caches the content in the object store, so that
+		// restarted and additional replicas do not need to unpack catalogs again.
+		localStorage = storage.NewObjectStoreV1(bucket, cfg.objectStorePrefix, storage.ObjectContentMode(cfg.objectStoreMode), localDir)
+	}
 
 	// Config for the catalogd web server
 	catalogServerConfig := serverutil.CatalogServerConfig{
@@ -396,22 +540,73 @@ func run(ctx context.Context) error {
 		TLSOpts:      []func(*tls.Config){tlsOpts, tlsProfile},
 	}
 
+	if cfg.catalogServerAuth {
+		contentFilter, err := auth.NewDelegatingContentFilter(mgr.GetConfig(), mgr.GetHTTPClient(), baseStorageURL, ctrl.Log.WithName("catalog-content-auth"))
+		if err != nil {
+			setupLog.Error(err, "unable to configure catalog server authentication and authorization")
+			return err
+		}
+		catalogServerConfig.ContentAuthFilter = contentFilter.Wrap
+	}
+	if cfg.registryWebhookTokenFile != "" {
+		catalogServerConfig.RegistryWebhook = registrywebhook.NewReceiver(mgr.GetClient(), cfg.registryWebhookTokenFile, ctrl.Log.WithName("registry-webhook"))
+	}
+
 	err = serverutil.AddCatalogServerToManager(mgr, catalogServerConfig)
 	if err != nil {
 		setupLog.Error(err, "unable to configure catalog server")
 		return err
 	}
 
+	namespacedCatalogs := features.CatalogdFeatureGate.Enabled(features.NamespacedCatalogs)
+	unpacks := garbagecollection.NewUnpackTracker()
+
 	if err = (&corecontrollers.ClusterCatalogReconciler{
-		Client:      mgr.GetClient(),
-		ImageCache:  imageCache,
-		ImagePuller: imagePuller,
-		Storage:     localStorage,
+		Client:             mgr.GetClient(),
+		ImageCache:         imageCache,
+		ImagePuller:        imagePuller,
+		Storage:            localStorage,
+		Unpacks:            unpacks,
+		NamespacedCatalogs: namespacedCatalogs,
+		SecretReader:       mgr.GetAPIReader(),
+		SystemNamespace:    cfg.systemNamespace,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterCatalog")
 		return err
 	}
 
+	// Replicas that are not the leader store the content resolved by the leader, so that
+	// every replica serves catalog content. Replicas only become ready once they do.
+	replicaReconciler := &corecontrollers.ClusterCatalogReplicaReconciler{
+		Client:             mgr.GetClient(),
+		ImageCache:         imageCache,
+		ImagePuller:        imagePuller,
+		Storage:            localStorage,
+		Unpacks:            unpacks,
+		NamespacedCatalogs: namespacedCatalogs,
+		SecretReader:       mgr.GetAPIReader(),
+		SystemNamespace:    cfg.systemNamespace,
+	}
+	if err = replicaReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterCatalogReplica")
+		return err
+	}
+	if err := mgr.AddReadyzCheck("catalog-content", replicaReconciler.ReadyzCheck()); err != nil {
+		setupLog.Error(err, "unable to set up catalog content ready check")
+		return err
+	}
+
+	catalogOverlapDetection := features.CatalogdFeatureGate.Enabled(features.CatalogOverlapDetection)
+	if catalogOverlapDetection {
+		if err = (&corecontrollers.ClusterCatalogOverlapReconciler{
+			Client:  mgr.GetClient(),
+			Storage: localStorage,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterCatalogOverlap")
+			return err
+		}
+	}
+
 	setupLog.Info("creating SecretSyncer controller for watching secret", "Secret", cfg.globalPullSecret)
 	err = (&sharedcontrollers.PullSecretReconciler{
 		Client:            mgr.GetClient(),
@@ -441,18 +636,35 @@ func run(ctx context.Context) error {
 	}
 
 	gc := &garbagecollection.GarbageCollector{
-		CachePaths:     []string{unpackCacheBasePath, storeDir},
-		Logger:         ctrl.Log.WithName("garbage-collector"),
-		MetadataClient: metaClient,
-		Interval:       cfg.gcInterval,
+		CachePaths:         []string{unpackCacheBasePath, storeDir},
+		Logger:             ctrl.Log.WithName("garbage-collector"),
+		MetadataClient:     metaClient,
+		Interval:           cfg.gcInterval,
+		NamespacedCatalogs: namespacedCatalogs,
+	}
+	if cfg.gcDiskBudgetBytes > 0 || cfg.gcMinFreeSpaceBytes > 0 {
+		gc.DiskBudget = &garbagecollection.DiskBudget{
+			UnpackCachePath:        unpackCacheBasePath,
+			StoredDigest:           localDir.GetCatalogDigest,
+			MaxBytes:               cfg.gcDiskBudgetBytes,
+			MinFreeBytes:           cfg.gcMinFreeSpaceBytes,
+			FreeSpaceCheckInterval: cfg.gcFreeSpaceCheckInterval,
+			Unpacks:                unpacks,
+		}
 	}
 	if err := mgr.Add(gc); err != nil {
 		setupLog.Error(err, "unable to add garbage collector to manager")
 		return err
 	}
 
-	// mutating webhook that labels ClusterCatalogs with name label
-	if err = (&webhook.ClusterCatalog{}).SetupWebhookWithManager(mgr); err != nil {
+	// mutating webhook that labels ClusterCatalogs with name label, and validating webhook
+	// that warns about packages overlapping with other ClusterCatalogs of the same priority
+	clusterCatalogWebhook := &webhook.ClusterCatalog{}
+	if catalogOverlapDetection {
+		clusterCatalogWebhook.Client = mgr.GetClient()
+		clusterCatalogWebhook.Packages = localStorage
+	}
+	if err = clusterCatalogWebhook.SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterCatalog")
 		return err
 	}
@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -41,6 +42,7 @@ import (
 	"k8s.io/client-go/discovery/cached/memory"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/transport"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"pkg.package-operator.run/boxcutter/managedcache"
@@ -54,6 +56,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	helmclient "github.com/operator-framework/helm-operator-plugins/pkg/client"
 
@@ -67,14 +70,17 @@ import (
 	"github.com/operator-framework/operator-controller/internal/operator-controller/contentmanager"
 	cmcache "github.com/operator-framework/operator-controller/internal/operator-controller/contentmanager/cache"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/controllers"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/crdownership"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/features"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/finalizers"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/installpolicy"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/preflights/crdupgradesafety"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/render"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/render/certproviders"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/render/registryv1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/scheme"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/webhook"
 	sharedcontrollers "github.com/operator-framework/operator-controller/internal/shared/controllers"
 	cacheutil "github.com/operator-framework/operator-controller/internal/shared/util/cache"
 	fsutil "github.com/operator-framework/operator-controller/internal/shared/util/fs"
@@ -94,17 +100,20 @@ var (
 )
 
 type config struct {
-	metricsAddr          string
-	pprofAddr            string
-	certFile             string
-	keyFile              string
-	enableLeaderElection bool
-	probeAddr            string
-	cachePath            string
-	systemNamespace      string
-	catalogdCasDir       string
-	pullCasDir           string
-	globalPullSecret     string
+	metricsAddr              string
+	pprofAddr                string
+	certFile                 string
+	keyFile                  string
+	enableLeaderElection     bool
+	probeAddr                string
+	cachePath                string
+	systemNamespace          string
+	catalogdCasDir           string
+	catalogdTokenFile        string
+	pullCasDir               string
+	globalPullSecret         string
+	deprecationMergeStrategy string
+	webhookPort              int
 }
 
 type reconcilerConfigurator interface {
@@ -114,6 +123,7 @@ type reconcilerConfigurator interface {
 type boxcutterReconcilerConfigurator struct {
 	mgr                   manager.Manager
 	preflights            []applier.Preflight
+	crdClient             apiextensionsv1client.CustomResourceDefinitionInterface
 	regv1ManifestProvider applier.ManifestProvider
 	resolver              resolve.Resolver
 	imageCache            imageutil.Cache
@@ -124,6 +134,7 @@ type boxcutterReconcilerConfigurator struct {
 type helmReconcilerConfigurator struct {
 	mgr                   manager.Manager
 	preflights            []applier.Preflight
+	crdClient             apiextensionsv1client.CustomResourceDefinitionInterface
 	regv1ManifestProvider applier.ManifestProvider
 	resolver              resolve.Resolver
 	imageCache            imageutil.Cache
@@ -156,6 +167,9 @@ var operatorControllerCmd = &cobra.Command{
 		if err := validateMetricsFlags(); err != nil {
 			return err
 		}
+		if !slices.Contains(resolve.DeprecationMergeStrategies, resolve.DeprecationMergeStrategy(cfg.deprecationMergeStrategy)) {
+			return fmt.Errorf("invalid deprecation-merge-strategy %q: must be one of %v", cfg.deprecationMergeStrategy, resolve.DeprecationMergeStrategies)
+		}
 		return run()
 	},
 }
@@ -175,6 +189,7 @@ func init() {
 	flags.StringVar(&cfg.pprofAddr, "pprof-bind-address", "0", "The address the pprof endpoint binds to. an empty string or 0 disables pprof")
 	flags.StringVar(&cfg.probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flags.StringVar(&cfg.catalogdCasDir, "catalogd-cas-dir", "", "The directory of TLS certificate authorities to use for verifying HTTPS connections to the Catalogd web service.")
+	flags.StringVar(&cfg.catalogdTokenFile, "catalogd-bearer-token-file", "", "The file containing a bearer token to authenticate to the Catalogd web service, such as a projected service account token. The file is re-read periodically to pick up rotated tokens.")
 	flags.StringVar(&cfg.pullCasDir, "pull-cas-dir", "", "The directory of TLS certificate authorities to use for verifying HTTPS connections to image registries.")
 	flags.StringVar(&cfg.certFile, "tls-cert", "", "The certificate file used for the metrics server. Required to enable the metrics server. Requires tls-key.")
 	flags.StringVar(&cfg.keyFile, "tls-key", "", "The key file used for the metrics server. Required to enable the metrics server. Requires tls-cert")
@@ -184,6 +199,11 @@ func init() {
 	flags.StringVar(&cfg.cachePath, "cache-path", "/var/cache", "The local directory path used for filesystem based caching")
 	flags.StringVar(&cfg.systemNamespace, "system-namespace", "", "Configures the namespace that gets used to deploy system resources.")
 	flags.StringVar(&cfg.globalPullSecret, "global-pull-secret", "", "The <namespace>/<name> of the global pull secret that is going to be used to pull bundle images.")
+	flags.StringVar(&cfg.deprecationMergeStrategy, "deprecation-merge-strategy", string(resolve.DeprecationMergeHighestPriority),
+		fmt.Sprintf("How the deprecations of a package provided by multiple catalogs are merged. One of %v. "+
+			"HighestPriority uses the deprecations of the catalog the bundle is resolved from, Union those of all catalogs providing the package.",
+			resolve.DeprecationMergeStrategies))
+	flags.IntVar(&cfg.webhookPort, "webhook-server-port", 9443, "Webhook server port. The webhook server is only started when the InstallPolicies feature is enabled.")
 
 	//adds version sub command
 	operatorControllerCmd.AddCommand(versionCommand)
@@ -259,6 +279,17 @@ func run() error {
 		// Memory optimization: strip managed fields and large annotations from cached objects
 		DefaultTransform: cacheutil.StripAnnotations(),
 	}
+	if features.OperatorControllerFeatureGate.Enabled(features.NamespacedCatalogs) {
+		cacheOptions.ByObject[&ocv1.Catalog{}] = crcache.ByObject{Namespaces: map[string]crcache.Config{}, Label: k8slabels.Everything()}
+	}
+	if features.OperatorControllerFeatureGate.Enabled(features.BundleDenyPolicies) {
+		cacheOptions.ByObject[&ocv1.ClusterBundleDenyPolicy{}] = crcache.ByObject{Label: k8slabels.Everything()}
+	}
+	if features.OperatorControllerFeatureGate.Enabled(features.InstallPolicies) {
+		// Install policies select ClusterExtensions by the labels of their namespace.
+		cacheOptions.ByObject[&ocv1.ClusterExtensionInstallPolicy{}] = crcache.ByObject{Label: k8slabels.Everything()}
+		cacheOptions.ByObject[&corev1.Namespace{}] = crcache.ByObject{Label: k8slabels.Everything()}
+	}
 
 	if features.OperatorControllerFeatureGate.Enabled(features.BoxcutterRuntime) {
 		cacheOptions.ByObject[&ocv1.ClusterObjectSet{}] = crcache.ByObject{
@@ -325,6 +356,32 @@ func run() error {
 			"Metrics will not be served since the TLS certificate and key file are not provided.")
 	}
 
+	// The webhook server is only needed to enforce install policies at admission.
+	var webhookServer crwebhook.Server
+	if features.OperatorControllerFeatureGate.Enabled(features.InstallPolicies) {
+		if certWatcher == nil {
+			err := errors.New("the InstallPolicies feature requires the tls-cert and tls-key flags to serve its webhooks")
+			setupLog.Error(err, "invalid webhook configuration")
+			return err
+		}
+		tlsProfile, err := tlsprofiles.GetTLSConfigFunc()
+		if err != nil {
+			setupLog.Error(err, "failed to get TLS profile")
+			return err
+		}
+		webhookServer = crwebhook.NewServer(crwebhook.Options{
+			Port: cfg.webhookPort,
+			TLSOpts: []func(*tls.Config){
+				func(config *tls.Config) {
+					config.GetCertificate = certWatcher.GetCertificate
+					// Disable http/2 like for the metrics server.
+					config.NextProtos = []string{"http/1.1"}
+				},
+				tlsProfile,
+			},
+		})
+	}
+
 	restConfig := ctrl.GetConfigOrDie()
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                        scheme.Scheme,
@@ -340,7 +397,8 @@ func run() error {
 		RenewDeadline: ptr.To(107 * time.Second),
 		RetryPeriod:   ptr.To(26 * time.Second),
 
-		Cache: cacheOptions,
+		WebhookServer: webhookServer,
+		Cache:         cacheOptions,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -431,23 +489,30 @@ func run() error {
 	}
 	catalogClientBackend := cache.NewFilesystemCache(catalogsCachePath)
 	catalogClient := catalogclient.New(catalogClientBackend, func() (*http.Client, error) {
-		return catalogclient.BuildHTTPClient(cpwCatalogd)
+		httpClient, err := catalogclient.BuildHTTPClient(cpwCatalogd)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.catalogdTokenFile != "" {
+			httpClient.Transport, err = transport.NewBearerAuthWithRefreshRoundTripper("", cfg.catalogdTokenFile, httpClient.Transport)
+			if err != nil {
+				return nil, fmt.Errorf("failed to configure catalogd bearer token: %w", err)
+			}
+		}
+		return httpClient, nil
 	})
 
+	catalogLister := resolve.CatalogLister(cl, features.OperatorControllerFeatureGate.Enabled(features.NamespacedCatalogs))
 	resolver := &resolve.CatalogResolver{
-		WalkCatalogsFunc: resolve.CatalogWalker(
-			func(ctx context.Context, option ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
-				var catalogs ocv1.ClusterCatalogList
-				if err := cl.List(ctx, &catalogs, option...); err != nil {
-					return nil, err
-				}
-				return catalogs.Items, nil
-			},
-			catalogClient.GetPackage,
-		),
+		WalkCatalogsFunc:        resolve.CatalogWalker(catalogLister, catalogClient.GetPackage),
+		WalkCatalogPackagesFunc: resolve.CatalogPackagesWalker(catalogLister, catalogClient.ListPackagesProvidingGVK),
 		Validations: []resolve.ValidationFunc{
 			resolve.NoDependencyValidation,
 		},
+		DeprecationMergeStrategy: resolve.DeprecationMergeStrategy(cfg.deprecationMergeStrategy),
+	}
+	if features.OperatorControllerFeatureGate.Enabled(features.BundleDenyPolicies) {
+		resolver.ListBundleDenyPoliciesFunc = resolve.BundleDenyPolicyLister(cl)
 	}
 
 	aeClient, err := apiextensionsv1client.NewForConfig(mgr.GetConfig())
@@ -464,6 +529,12 @@ func run() error {
 	if features.OperatorControllerFeatureGate.Enabled(features.BoxcutterRuntime) {
 		ctrlBuilderOpts = append(ctrlBuilderOpts, controllers.WithOwns(&ocv1.ClusterObjectSet{}))
 	}
+	if features.OperatorControllerFeatureGate.Enabled(features.BundleDenyPolicies) {
+		ctrlBuilderOpts = append(ctrlBuilderOpts, controllers.WithBundleDenyPolicies(cl, mgr.GetLogger()))
+	}
+	if features.OperatorControllerFeatureGate.Enabled(features.InstallPolicies) {
+		ctrlBuilderOpts = append(ctrlBuilderOpts, controllers.WithInstallPolicies(cl, mgr.GetLogger()))
+	}
 
 	ceReconciler := &controllers.ClusterExtensionReconciler{
 		Client: cl,
@@ -474,6 +545,20 @@ func run() error {
 		return err
 	}
 
+	if features.OperatorControllerFeatureGate.Enabled(features.InstallPolicies) {
+		// validating webhooks that reject ClusterExtensions violating install policies,
+		// and install policies with invalid expressions
+		ceWebhook := &webhook.ClusterExtension{Checker: &installpolicy.Checker{Client: cl}}
+		if err := ceWebhook.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterExtension")
+			return err
+		}
+		if err := (&webhook.ClusterExtensionInstallPolicy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterExtensionInstallPolicy")
+			return err
+		}
+	}
+
 	certProvider := getCertificateProvider()
 	regv1ManifestProvider := &applier.RegistryV1ManifestProvider{
 		BundleRenderer:              registryv1.Renderer,
@@ -487,6 +572,7 @@ func run() error {
 		cerCfg = &boxcutterReconcilerConfigurator{
 			mgr:                   mgr,
 			preflights:            preflights,
+			crdClient:             aeClient.CustomResourceDefinitions(),
 			regv1ManifestProvider: regv1ManifestProvider,
 			resolver:              resolver,
 			imageCache:            imageCache,
@@ -497,6 +583,7 @@ func run() error {
 		cerCfg = &helmReconcilerConfigurator{
 			mgr:                   mgr,
 			preflights:            preflights,
+			crdClient:             aeClient.CustomResourceDefinitions(),
 			regv1ManifestProvider: regv1ManifestProvider,
 			resolver:              resolver,
 			imageCache:            imageCache,
@@ -514,6 +601,7 @@ func run() error {
 		Client:                cl,
 		CatalogCache:          catalogClientBackend,
 		CatalogCachePopulator: catalogClient,
+		NamespacedCatalogs:    features.OperatorControllerFeatureGate.Enabled(features.NamespacedCatalogs),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterCatalog")
 		return err
@@ -637,8 +725,11 @@ func (c *boxcutterReconcilerConfigurator) Configure(ceReconciler *controllers.Cl
 		),
 		controllers.MigrateStorage(storageMigrator),
 		controllers.RetrieveRevisionStates(revisionStatesGetter),
+		controllers.CheckBundleDenial(resolve.BundleDenyPolicyLister(c.mgr.GetClient())),
 		controllers.ResolveBundle(c.resolver, c.mgr.GetClient()),
-		controllers.UnpackBundle(c.imagePuller, c.imageCache),
+		controllers.CheckInstallPolicies((&installpolicy.Checker{Client: c.mgr.GetClient()}).Check),
+		controllers.CheckCRDOwnership((&crdownership.Checker{Client: c.crdClient}).Check),
+		controllers.UnpackBundle(c.imagePuller, c.imageCache, c.mgr.GetAPIReader()),
 		controllers.ApplyBundleWithBoxcutter(appl.Apply),
 	}
 
@@ -768,8 +859,11 @@ func (c *helmReconcilerConfigurator) Configure(ceReconciler *controllers.Cluster
 			controllers.ServiceAccountValidator(coreClient),
 		),
 		controllers.RetrieveRevisionStates(revisionStatesGetter),
+		controllers.CheckBundleDenial(resolve.BundleDenyPolicyLister(c.mgr.GetClient())),
 		controllers.ResolveBundle(c.resolver, c.mgr.GetClient()),
-		controllers.UnpackBundle(c.imagePuller, c.imageCache),
+		controllers.CheckInstallPolicies((&installpolicy.Checker{Client: c.mgr.GetClient()}).Check),
+		controllers.CheckCRDOwnership((&crdownership.Checker{Client: c.crdClient}).Check),
+		controllers.UnpackBundle(c.imagePuller, c.imageCache, c.mgr.GetAPIReader()),
 		controllers.ApplyBundle(appl),
 	}
 
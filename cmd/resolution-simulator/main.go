@@ -0,0 +1,187 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.podman.io/image/v5/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/features"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolutionsimulator"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+	"github.com/operator-framework/operator-controller/internal/shared/version"
+)
+
+type config struct {
+	filename                 string
+	catalogs                 map[string]string
+	installedBundle          string
+	installedVersion         string
+	installedRelease         string
+	installedCatalog         string
+	deprecationMergeStrategy string
+	output                   string
+	authFile                 string
+	tlsVerify                bool
+}
+
+var cfg = &config{}
+
+var resolutionSimulatorCmd = &cobra.Command{
+	Use:   "resolution-simulator -f <objects.yaml>",
+	Short: "Resolves the bundle of a ClusterExtension offline, against local catalogs",
+	Long: `Resolves the bundle of a ClusterExtension with the resolution algorithm of
+operator-controller, against catalogs loaded from local FBC directories or catalog images,
+and prints the resolved bundle, its deprecations and how the candidate bundles of each
+catalog were eliminated.
+
+The objects file holds the ClusterExtension, and optionally the ClusterCatalogs, with their
+labels and priorities, and the ClusterBundleDenyPolicies to resolve with. The installed
+bundle is read from the status of the ClusterExtension, unless set with the installed-*
+flags.
+
+The command exits with a non-zero status when the resolution fails.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(resolve.DeprecationMergeStrategies, resolve.DeprecationMergeStrategy(cfg.deprecationMergeStrategy)) {
+			return fmt.Errorf("invalid deprecation-merge-strategy %q: must be one of %v", cfg.deprecationMergeStrategy, resolve.DeprecationMergeStrategies)
+		}
+		if cfg.output != "text" && cfg.output != "json" {
+			return fmt.Errorf("invalid output %q: must be one of [text json]", cfg.output)
+		}
+		if (cfg.installedBundle == "") != (cfg.installedVersion == "") {
+			return errors.New("installed-bundle and installed-version must be set together")
+		}
+		cmd.SilenceUsage = true
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		objs, err := loadObjects(cfg.filename)
+		if err != nil {
+			return err
+		}
+		opts := resolutionsimulator.Options{
+			Objects:                  *objs,
+			CatalogSources:           cfg.catalogs,
+			InstalledCatalog:         cfg.installedCatalog,
+			DeprecationMergeStrategy: resolve.DeprecationMergeStrategy(cfg.deprecationMergeStrategy),
+			SourceCtx: &types.SystemContext{
+				AuthFilePath:                cfg.authFile,
+				DockerInsecureSkipTLSVerify: types.NewOptionalBool(!cfg.tlsVerify),
+			},
+		}
+		if cfg.installedBundle != "" {
+			opts.InstalledBundle = &ocv1.BundleMetadata{Name: cfg.installedBundle, Version: cfg.installedVersion}
+			if cfg.installedRelease != "" {
+				opts.InstalledBundle.Release = &cfg.installedRelease
+			}
+		}
+
+		result, err := resolutionsimulator.Run(ctx, opts)
+		if err != nil {
+			return err
+		}
+		if cfg.output == "json" {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			enc.SetEscapeHTML(false)
+			err = enc.Encode(result)
+		} else {
+			err = result.WriteText(cmd.OutOrStdout())
+		}
+		if err != nil {
+			return err
+		}
+		if result.Error != "" {
+			return errors.New("resolution failed")
+		}
+		return nil
+	},
+}
+
+// loadObjects loads the objects of the file, or of stdin when filename is "-".
+func loadObjects(filename string) (*resolutionsimulator.Objects, error) {
+	var r io.Reader = os.Stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	objs, err := resolutionsimulator.LoadObjects(r)
+	if err != nil {
+		return nil, fmt.Errorf("error loading objects from %q: %w", filename, err)
+	}
+	return objs, nil
+}
+
+var versionCommand = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version information",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("%#v\n", version.String())
+	},
+}
+
+func init() {
+	flags := resolutionSimulatorCmd.Flags()
+	flags.StringVarP(&cfg.filename, "filename", "f", "", "File holding the ClusterExtension, and optionally ClusterCatalogs and ClusterBundleDenyPolicies, in YAML or JSON. Use - for stdin.")
+	flags.StringToStringVar(&cfg.catalogs, "catalog", nil, "Catalog to resolve from, as <name>=<source>, where the source is a directory of FBC or a catalog image. May be repeated. ClusterCatalogs of the objects file without a source are pulled from their image.")
+	flags.StringVar(&cfg.installedBundle, "installed-bundle", "", "Name of the bundle installed for the ClusterExtension, overriding the installed bundle of its status")
+	flags.StringVar(&cfg.installedVersion, "installed-version", "", "Version of the installed bundle")
+	flags.StringVar(&cfg.installedRelease, "installed-release", "", "Release of the installed bundle")
+	flags.StringVar(&cfg.installedCatalog, "installed-catalog", "", "Catalog that provided the installed bundle, which upgrades stick to")
+	flags.StringVar(&cfg.deprecationMergeStrategy, "deprecation-merge-strategy", string(resolve.DeprecationMergeHighestPriority),
+		fmt.Sprintf("How the deprecations of a package provided by multiple catalogs are merged. One of %v.", resolve.DeprecationMergeStrategies))
+	flags.StringVarP(&cfg.output, "output", "o", "text", "Output format. One of [text json].")
+	flags.StringVar(&cfg.authFile, "authfile", "", "Path of the authentication file for the registries of catalog images. The default authentication files of containers-auth.json(5) are used when not set.")
+	flags.BoolVar(&cfg.tlsVerify, "tls-verify", true, "Require HTTPS and verify certificates when pulling catalog images")
+	_ = resolutionSimulatorCmd.MarkFlagRequired("filename")
+
+	resolutionSimulatorCmd.AddCommand(versionCommand)
+
+	klog.InitFlags(flag.CommandLine)
+	flags.AddGoFlagSet(flag.CommandLine)
+
+	// The BundleReleaseSupport feature changes how bundle releases are resolved.
+	features.OperatorControllerFeatureGate.AddFlag(flags)
+
+	log.SetLogger(klog.NewKlogr())
+}
+
+func main() {
+	if err := resolutionSimulatorCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"syscall"
 	"testing/fstest"
 
+	"github.com/opencontainers/go-digest"
+
 	"github.com/operator-framework/operator-controller/internal/catalogd/server"
 	"github.com/operator-framework/operator-controller/internal/catalogd/service"
 )
@@ -23,6 +25,14 @@ func (s *demoCatalogStore) GetCatalogData(catalog string) (*os.File, os.FileInfo
 	return nil, nil, fmt.Errorf("not implemented for demo")
 }
 
+func (s *demoCatalogStore) GetEncodedCatalogData(catalog string, encoding string) (*os.File, os.FileInfo, error) {
+	return nil, nil, fmt.Errorf("not implemented for demo")
+}
+
+func (s *demoCatalogStore) GetCatalogDigest(catalog string) (digest.Digest, error) {
+	return "", nil
+}
+
 func (s *demoCatalogStore) GetCatalogFS(catalog string) (fs.FS, error) {
 	catFS, ok := s.catalogs[catalog]
 	if !ok {
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/apis/apiserver"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/authenticatorfactory"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	authenticationv1 "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/rest"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+const (
+	// ContentResource is the resource that catalog content requests are authorized against.
+	ContentResource = "clustercatalogs"
+	// ContentSubresource is the virtual subresource of a ClusterCatalog that represents
+	// its content served by the catalogd web server. It does not exist in the API server,
+	// but can be referenced in RBAC rules to control who can read which catalogs, e.g.:
+	//
+	//	- apiGroups: ["olm.operatorframework.io"]
+	//	  resources: ["clustercatalogs/content"]
+	//	  resourceNames: ["operatorhubio"]
+	//	  verbs: ["get"]
+	ContentSubresource = "content"
+	// NamespacedContentResource is the resource that requests for the content of
+	// namespaced Catalogs are authorized against, in the namespace of the Catalog.
+	NamespacedContentResource = "catalogs"
+)
+
+// ContentFilter authenticates requests for catalog content and authorizes the
+// authenticated user to get the clustercatalogs/content subresource of the
+// requested catalog, or the catalogs/content subresource in the namespace of a
+// requested namespaced Catalog.
+type ContentFilter struct {
+	authenticator authenticator.Request
+	authorizer    authorizer.Authorizer
+	rootPath      string
+	logger        logr.Logger
+}
+
+// NewContentFilter returns a ContentFilter for catalog content served under rootURL
+// that authenticates and authorizes requests using the given authenticator and authorizer.
+func NewContentFilter(authn authenticator.Request, authz authorizer.Authorizer, rootURL *url.URL, logger logr.Logger) *ContentFilter {
+	rootPath := rootURL.Path
+	// A root URL without a scheme (e.g. "catalogd-service.olmv1-system.svc/catalogs/")
+	// has the host as the first element of its path, which is not part of request paths.
+	if i := strings.Index(rootPath, "/"); i > 0 {
+		rootPath = rootPath[i:]
+	}
+	if !strings.HasSuffix(rootPath, "/") {
+		rootPath += "/"
+	}
+	return &ContentFilter{
+		authenticator: authn,
+		authorizer:    authz,
+		rootPath:      rootPath,
+		logger:        logger,
+	}
+}
+
+// NewDelegatingContentFilter returns a ContentFilter that authenticates bearer tokens
+// using TokenReviews and authorizes requests using SubjectAccessReviews against the
+// API server identified by config. Catalogd needs permission to create both.
+func NewDelegatingContentFilter(config *rest.Config, httpClient *http.Client, rootURL *url.URL, logger logr.Logger) (*ContentFilter, error) {
+	authenticationV1Client, err := authenticationv1.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	authorizationV1Client, err := authorizationv1.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// The backoff matches the default used by the API server for its authn/authz webhooks.
+	webhookRetryBackoff := &wait.Backoff{
+		Duration: 500 * time.Millisecond,
+		Factor:   1.5,
+		Jitter:   0.2,
+		Steps:    5,
+	}
+
+	authenticatorConfig := authenticatorfactory.DelegatingAuthenticatorConfig{
+		Anonymous:                &apiserver.AnonymousAuthConfig{Enabled: false},
+		CacheTTL:                 1 * time.Minute,
+		TokenAccessReviewClient:  authenticationV1Client,
+		TokenAccessReviewTimeout: 10 * time.Second,
+		WebhookRetryBackoff:      webhookRetryBackoff,
+	}
+	delegatingAuthenticator, _, err := authenticatorConfig.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticator: %w", err)
+	}
+
+	authorizerConfig := authorizerfactory.DelegatingAuthorizerConfig{
+		SubjectAccessReviewClient: authorizationV1Client,
+		AllowCacheTTL:             5 * time.Minute,
+		DenyCacheTTL:              30 * time.Second,
+		WebhookRetryBackoff:       webhookRetryBackoff,
+	}
+	delegatingAuthorizer, err := authorizerConfig.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %w", err)
+	}
+
+	return NewContentFilter(delegatingAuthenticator, delegatingAuthorizer, rootURL, logger), nil
+}
+
+// Wrap returns a handler that only passes requests to handler once they have been
+// authenticated and authorized. Unauthenticated requests are rejected with 401
+// Unauthorized and unauthorized requests with 403 Forbidden. Every endpoint of a
+// catalog (including GraphQL queries, which are sent as POST requests) only reads
+// content, so all requests are authorized for the "get" verb.
+func (f *ContentFilter) Wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok, err := f.authenticator.AuthenticateRequest(r)
+		if err != nil {
+			f.logger.Error(err, "authentication failed")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		namespace, catalog, ok := f.catalogName(r.URL.Path)
+		if !ok {
+			// Not a catalog content path; let the handler respond with 404
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		resource := ContentResource
+		if namespace != "" {
+			resource = NamespacedContentResource
+		}
+		attributes := authorizer.AttributesRecord{
+			User:            res.User,
+			Verb:            "get",
+			Namespace:       namespace,
+			APIGroup:        ocv1.GroupVersion.Group,
+			APIVersion:      ocv1.GroupVersion.Version,
+			Resource:        resource,
+			Subresource:     ContentSubresource,
+			Name:            catalog,
+			ResourceRequest: true,
+			Path:            r.URL.Path,
+		}
+		decision, reason, err := f.authorizer.Authorize(r.Context(), attributes)
+		if err != nil {
+			f.logger.Error(err, "authorization failed", "user", res.User.GetName(), "namespace", namespace, "catalog", catalog)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if decision != authorizer.DecisionAllow {
+			f.logger.V(4).Info("authorization denied", "user", res.User.GetName(), "namespace", namespace, "catalog", catalog, "reason", reason)
+			msg := fmt.Sprintf("user %q cannot get %s/%s of catalog %q", res.User.GetName(), resource, ContentSubresource, catalog)
+			if namespace != "" {
+				msg += fmt.Sprintf(" in namespace %q", namespace)
+			}
+			http.Error(w, msg, http.StatusForbidden)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// catalogName extracts the namespace and name of the requested catalog from a path of
+// the form <rootPath><catalog>/api/v1/<endpoint>, or
+// <rootPath>namespaces/<namespace>/<catalog>/api/v1/<endpoint> for namespaced Catalogs.
+// Like the catalog content server, it tells both apart by their number of segments, so
+// that a ClusterCatalog named "namespaces" is still authorized as a ClusterCatalog.
+func (f *ContentFilter) catalogName(path string) (string, string, bool) {
+	remainder, ok := strings.CutPrefix(path, f.rootPath)
+	if !ok {
+		return "", "", false
+	}
+	segments := strings.Split(remainder, "/")
+	if len(segments) == 6 && segments[0] == "namespaces" {
+		if segments[1] == "" || segments[2] == "" {
+			return "", "", false
+		}
+		return segments[1], segments[2], true
+	}
+	if segments[0] == "" {
+		return "", "", false
+	}
+	return "", segments[0], true
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+func TestContentFilter(t *testing.T) {
+	bearerAuthenticator := authenticator.RequestFunc(func(r *http.Request) (*authenticator.Response, bool, error) {
+		switch r.Header.Get("Authorization") {
+		case "":
+			return nil, false, nil
+		case "Bearer error":
+			return nil, false, errors.New("token review failed")
+		default:
+			return &authenticator.Response{User: &user.DefaultInfo{Name: "system:serviceaccount:olmv1-system:reader"}}, true, nil
+		}
+	})
+
+	for _, tc := range []struct {
+		name              string
+		rootURL           string
+		path              string
+		token             string
+		authorizer        authorizer.AuthorizerFunc
+		expectedStatus    int
+		expectedNamespace string
+		expectedName      string
+	}{
+		{
+			name:           "unauthenticated request is rejected",
+			rootURL:        "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:           "/catalogs/test-catalog/api/v1/all",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "authentication error returns internal server error",
+			rootURL:        "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:           "/catalogs/test-catalog/api/v1/all",
+			token:          "error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:    "authorized request is served",
+			rootURL: "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:    "/catalogs/test-catalog/api/v1/all",
+			token:   "valid",
+			authorizer: func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+				return authorizer.DecisionAllow, "", nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedName:   "test-catalog",
+		},
+		{
+			name:    "authorized request with scheme-less root URL is served",
+			rootURL: "catalogd-service.olmv1-system.svc/catalogs/",
+			path:    "/catalogs/test-catalog/api/v1/metas",
+			token:   "valid",
+			authorizer: func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+				return authorizer.DecisionAllow, "", nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedName:   "test-catalog",
+		},
+		{
+			name:    "unauthorized request is forbidden",
+			rootURL: "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:    "/catalogs/test-catalog/api/v1/all",
+			token:   "valid",
+			authorizer: func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+				return authorizer.DecisionNoOpinion, "no RBAC policy matched", nil
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedName:   "test-catalog",
+		},
+		{
+			name:    "authorization error returns internal server error",
+			rootURL: "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:    "/catalogs/test-catalog/api/v1/all",
+			token:   "valid",
+			authorizer: func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+				return authorizer.DecisionDeny, "", errors.New("subject access review failed")
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedName:   "test-catalog",
+		},
+		{
+			name:    "request for a namespaced catalog is authorized in its namespace",
+			rootURL: "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:    "/catalogs/namespaces/team-a/test-catalog/api/v1/all",
+			token:   "valid",
+			authorizer: func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+				return authorizer.DecisionAllow, "", nil
+			},
+			expectedStatus:    http.StatusOK,
+			expectedNamespace: "team-a",
+			expectedName:      "test-catalog",
+		},
+		{
+			name:    "unauthorized request for a namespaced catalog is forbidden",
+			rootURL: "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:    "/catalogs/namespaces/team-a/test-catalog/api/v1/all",
+			token:   "valid",
+			authorizer: func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+				return authorizer.DecisionNoOpinion, "no RBAC policy matched", nil
+			},
+			expectedStatus:    http.StatusForbidden,
+			expectedNamespace: "team-a",
+			expectedName:      "test-catalog",
+		},
+		{
+			name:    "request for a ClusterCatalog named namespaces is authorized as a ClusterCatalog",
+			rootURL: "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:    "/catalogs/namespaces/api/v1/all",
+			token:   "valid",
+			authorizer: func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+				return authorizer.DecisionAllow, "", nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedName:   "namespaces",
+		},
+		{
+			name:           "authenticated request outside of catalogs is passed through",
+			rootURL:        "http://catalogd-service.olmv1-system.svc/catalogs/",
+			path:           "/healthz",
+			token:          "valid",
+			expectedStatus: http.StatusOK,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rootURL, err := url.Parse(tc.rootURL)
+			require.NoError(t, err)
+
+			expectedResource := ContentResource
+			if tc.expectedNamespace != "" {
+				expectedResource = NamespacedContentResource
+			}
+			var authorizedNamespace, authorizedName string
+			authz := authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+				require.Equal(t, "get", a.GetVerb())
+				require.Equal(t, "olm.operatorframework.io", a.GetAPIGroup())
+				require.Equal(t, expectedResource, a.GetResource())
+				require.Equal(t, ContentSubresource, a.GetSubresource())
+				require.True(t, a.IsResourceRequest())
+				authorizedNamespace = a.GetNamespace()
+				authorizedName = a.GetName()
+				return tc.authorizer(ctx, a)
+			})
+
+			filter := NewContentFilter(bearerAuthenticator, authz, rootURL, logr.Discard())
+			handler := filter.Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatus, rec.Code)
+			require.Equal(t, tc.expectedNamespace, authorizedNamespace)
+			require.Equal(t, tc.expectedName, authorizedName)
+		})
+	}
+}
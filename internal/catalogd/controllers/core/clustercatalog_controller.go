@@ -20,7 +20,9 @@ import (
 	"context" // #nosec
 	"errors"
 	"fmt"
+	"io/fs"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -39,7 +41,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogd/garbagecollection"
+	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
 	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+	"github.com/operator-framework/operator-controller/internal/catalogd/validation"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
 	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
 	k8sutil "github.com/operator-framework/operator-controller/internal/shared/util/k8s"
@@ -50,6 +56,9 @@ const (
 	// CatalogSources are polled if PollInterval is mentioned, in intervals of wait.Jitter(pollDuration, maxFactor)
 	// wait.Jitter returns a time.Duration between pollDuration and pollDuration + maxFactor * pollDuration.
 	requeueJitterMaxFactor = 0.01
+	// maxReportedValidationProblems limits the number of problems listed in the Validated
+	// condition, so that the condition remains readable for badly broken catalogs.
+	maxReportedValidationProblems = 20
 )
 
 // ClusterCatalogReconciler reconciles a Catalog object
@@ -61,6 +70,22 @@ type ClusterCatalogReconciler struct {
 
 	Storage storage.Instance
 
+	// Unpacks tracks the catalogs being unpacked and stored, so that garbage collection
+	// does not evict their unpacked images meanwhile. It may be nil.
+	Unpacks *garbagecollection.UnpackTracker
+
+	// NamespacedCatalogs enables reconciling namespaced Catalogs. They are reconciled
+	// like ClusterCatalogs named after their key, so that their content is cached and
+	// stored alongside the content of ClusterCatalogs without colliding with it.
+	NamespacedCatalogs bool
+
+	// SecretReader reads the pull secrets referenced by the pull configuration of
+	// catalogs. The pull secrets of ClusterCatalogs are read from SystemNamespace,
+	// and those of namespaced Catalogs from their namespace. When it is nil, the
+	// Client is used.
+	SecretReader    client.Reader
+	SystemNamespace string
+
 	finalizers crfinalizer.Finalizers
 
 	// TODO: The below storedCatalogs fields are used for a quick a hack that helps
@@ -76,6 +101,11 @@ type storedCatalogData struct {
 	lastUnpack         time.Time
 	lastSuccessfulPoll time.Time
 	observedGeneration int64
+	validationMode     ocv1.ValidationMode
+	validationProblems []validation.Problem
+	// refreshRequested is the value of the refresh-requested annotation of the
+	// catalog when it was unpacked.
+	refreshRequested string
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -106,38 +136,81 @@ func (r *ClusterCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		r.deleteStoredCatalog(reconciledCatsrc.Name)
 	}
 
-	// Do checks before any Update()s, as Update() may modify the resource structure!
 	updateStatus := !equality.Semantic.DeepEqual(existingCatsrc.Status, reconciledCatsrc.Status)
-	updateFinalizers := !equality.Semantic.DeepEqual(existingCatsrc.Finalizers, reconciledCatsrc.Finalizers)
-	unexpectedFieldsChanged := k8sutil.CheckForUnexpectedFieldChange(&existingCatsrc, reconciledCatsrc)
+	if err := r.update(ctx, &existingCatsrc, reconciledCatsrc, updateStatus); err != nil {
+		reconcileErr = errors.Join(reconcileErr, err)
+	}
+	return res, reconcileErr
+}
+
+// reconcileNamespacedCatalog reconciles a namespaced Catalog as a ClusterCatalog named
+// after its key.
+func (r *ClusterCatalogReconciler) reconcileNamespacedCatalog(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := log.FromContext(ctx).WithName("catalogd-catalog-controller")
+	ctx = log.IntoContext(ctx, l)
+
+	l.Info("reconcile starting")
+	defer l.Info("reconcile ending")
+
+	existingCatalog := ocv1.Catalog{}
+	if err := r.Get(ctx, req.NamespacedName, &existingCatalog); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	clusterCatalog := catalogutil.AsClusterCatalog(&existingCatalog)
+	res, reconcileErr := r.reconcile(ctx, clusterCatalog)
+	if reconcileErr != nil {
+		r.deleteStoredCatalog(clusterCatalog.Name)
+	}
+
+	reconciledCatalog := existingCatalog.DeepCopy()
+	reconciledCatalog.Labels = clusterCatalog.Labels
+	reconciledCatalog.Annotations = clusterCatalog.Annotations
+	reconciledCatalog.Finalizers = clusterCatalog.Finalizers
+	reconciledCatalog.Spec = clusterCatalog.Spec
+	reconciledCatalog.Status = clusterCatalog.Status
+
+	updateStatus := !equality.Semantic.DeepEqual(existingCatalog.Status, reconciledCatalog.Status)
+	if err := r.update(ctx, &existingCatalog, reconciledCatalog, updateStatus); err != nil {
+		reconcileErr = errors.Join(reconcileErr, err)
+	}
+	return res, reconcileErr
+}
+
+// update updates the status and finalizers of a reconciled catalog on the server.
+func (r *ClusterCatalogReconciler) update(ctx context.Context, existing, reconciled client.Object, updateStatus bool) error {
+	// Do checks before any Update()s, as Update() may modify the resource structure!
+	updateFinalizers := !equality.Semantic.DeepEqual(existing.GetFinalizers(), reconciled.GetFinalizers())
+	unexpectedFieldsChanged := k8sutil.CheckForUnexpectedFieldChange(existing, reconciled)
 
 	if unexpectedFieldsChanged {
 		panic("spec or metadata changed by reconciler")
 	}
 
-	// Save the finalizers off to the side. If we update the status, the reconciledCatsrc will be updated
-	// to contain the new state of the ClusterCatalog, which contains the status update, but (critically)
+	// Save the finalizers off to the side. If we update the status, the reconciled object will be updated
+	// to contain the new state of the catalog, which contains the status update, but (critically)
 	// does not contain the finalizers. After the status update, we will use the saved finalizers in the
 	// CreateOrPatch()
-	finalizers := reconciledCatsrc.Finalizers
+	finalizers := reconciled.GetFinalizers()
 
+	var updateErr error
 	if updateStatus {
-		if err := r.Client.Status().Update(ctx, reconciledCatsrc); err != nil {
-			reconcileErr = errors.Join(reconcileErr, fmt.Errorf("error updating status: %v", err))
+		if err := r.Client.Status().Update(ctx, reconciled); err != nil {
+			updateErr = errors.Join(updateErr, fmt.Errorf("error updating status: %v", err))
 		}
 	}
 
 	if updateFinalizers {
 		// Use CreateOrPatch to update finalizers on the server
-		if _, err := controllerutil.CreateOrPatch(ctx, r.Client, reconciledCatsrc, func() error {
-			reconciledCatsrc.Finalizers = finalizers
+		if _, err := controllerutil.CreateOrPatch(ctx, r.Client, reconciled, func() error {
+			reconciled.SetFinalizers(finalizers)
 			return nil
 		}); err != nil {
-			reconcileErr = errors.Join(reconcileErr, fmt.Errorf("error updating finalizers: %v", err))
+			updateErr = errors.Join(updateErr, fmt.Errorf("error updating finalizers: %v", err))
 		}
 	}
 
-	return res, reconcileErr
+	return updateErr
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -150,10 +223,20 @@ func (r *ClusterCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return fmt.Errorf("failed to setup finalizers: %v", err)
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := ctrl.NewControllerManagedBy(mgr).
 		For(&ocv1.ClusterCatalog{}).
 		Named("catalogd-clustercatalog-controller").
-		Complete(r)
+		Complete(r); err != nil {
+		return err
+	}
+
+	if !r.NamespacedCatalogs {
+		return nil
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ocv1.Catalog{}).
+		Named("catalogd-catalog-controller").
+		Complete(reconcile.Func(r.reconcileNamespacedCatalog))
 }
 
 // Note: This function always returns ctrl.Result{}. The linter
@@ -216,6 +299,7 @@ func (r *ClusterCatalogReconciler) reconcile(ctx context.Context, catalog *ocv1.
 	//   - we have a stored catalog, but the content doesn't exist on disk
 	//   - we have a stored catalog, the content exists, but the expected status differs from the actual status
 	//   - we have a stored catalog, the content exists, the status looks correct, but the catalog generation is different from the observed generation in the stored catalog
+	//   - we have a stored catalog, the content exists, the status looks correct and reflects the catalog generation, but a refresh was requested since it was unpacked
 	//   - we have a stored catalog, the content exists, the status looks correct and reflects the catalog generation, but it is time to poll again
 	needsUnpack := false
 	switch {
@@ -231,6 +315,9 @@ func (r *ClusterCatalogReconciler) reconcile(ctx context.Context, catalog *ocv1.
 	case catalog.Generation != storedCatalog.observedGeneration:
 		l.Info("unpack required: catalog generation differs from observed generation")
 		needsUnpack = true
+	case catalog.Annotations[ocv1.RefreshRequestedAnnotation] != storedCatalog.refreshRequested:
+		l.Info("unpack required: refresh requested")
+		needsUnpack = true
 	case r.needsPoll(storedCatalog.lastSuccessfulPoll, catalog):
 		l.Info("unpack required: poll duration has elapsed")
 		needsUnpack = true
@@ -253,20 +340,12 @@ func (r *ClusterCatalogReconciler) reconcile(ctx context.Context, catalog *ocv1.
 		return ctrl.Result{}, err
 	}
 
-	fsys, canonicalRef, unpackTime, err := r.ImagePuller.Pull(ctx, catalog.Name, catalog.Spec.Source.Image.Ref, r.ImageCache)
+	unpackStart := time.Now()
+	canonicalRef, unpackTime, validationProblems, err := r.unpack(ctx, catalog)
 	if err != nil {
-		unpackErr := fmt.Errorf("source catalog content: %w", err)
-		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), unpackErr)
-		return ctrl.Result{}, unpackErr
-	}
-
-	// TODO: We should check to see if the unpacked result has the same content
-	//   as the already unpacked content. If it does, we should skip this rest
-	//   of the unpacking steps.
-	if err := r.Storage.Store(ctx, catalog.Name, fsys); err != nil {
-		storageErr := fmt.Errorf("error storing fbc: %v", err)
-		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), storageErr)
-		return ctrl.Result{}, storageErr
+		catalogdmetrics.RecordUnpackFailure(catalog.Name)
+		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), err)
+		return ctrl.Result{}, err
 	}
 	baseURL := r.Storage.BaseURL(catalog.Name)
 
@@ -274,17 +353,47 @@ func (r *ClusterCatalogReconciler) reconcile(ctx context.Context, catalog *ocv1.
 	updateStatusServing(&catalog.Status, canonicalRef, unpackTime, baseURL, catalog.GetGeneration())
 
 	lastSuccessfulPoll := time.Now()
+	catalogdmetrics.RecordUnpackSuccess(catalog.Name, lastSuccessfulPoll.Sub(unpackStart), lastSuccessfulPoll)
 	r.storedCatalogsMu.Lock()
 	r.storedCatalogs[catalog.Name] = storedCatalogData{
 		ref:                canonicalRef,
 		lastUnpack:         unpackTime,
 		lastSuccessfulPoll: lastSuccessfulPoll,
 		observedGeneration: catalog.GetGeneration(),
+		validationMode:     catalog.Spec.ValidationMode,
+		validationProblems: validationProblems,
+		refreshRequested:   catalog.Annotations[ocv1.RefreshRequestedAnnotation],
 	}
 	r.storedCatalogsMu.Unlock()
 	return nextPollResult(lastSuccessfulPoll, catalog), nil
 }
 
+// unpack pulls the image of a catalog, validates its content and stores it.
+func (r *ClusterCatalogReconciler) unpack(ctx context.Context, catalog *ocv1.ClusterCatalog) (reference.Canonical, time.Time, []validation.Problem, error) {
+	pullCtx, err := pullContext(ctx, r.secretReader(), r.SystemNamespace, catalog)
+	if err != nil {
+		return nil, time.Time{}, nil, err
+	}
+	defer r.Unpacks.Begin(catalog.Name)()
+	fsys, canonicalRef, unpackTime, err := r.ImagePuller.Pull(pullCtx, catalog.Name, catalog.Spec.Source.Image.Ref, r.ImageCache)
+	if err != nil {
+		return nil, time.Time{}, nil, fmt.Errorf("source catalog content: %w", err)
+	}
+
+	validationProblems, err := r.validate(ctx, catalog, fsys)
+	if err != nil {
+		return nil, time.Time{}, nil, err
+	}
+
+	// When the unpacked content is identical to the content that is already stored,
+	// e.g. because the catalog image was rebuilt without changes, Store only records
+	// the new digest and leaves the stored content untouched.
+	if err := r.Storage.Store(ctx, catalog.Name, canonicalRef.Digest(), fsys); err != nil {
+		return nil, time.Time{}, nil, fmt.Errorf("error storing fbc: %w", err)
+	}
+	return canonicalRef, unpackTime, validationProblems, nil
+}
+
 func (r *ClusterCatalogReconciler) getCurrentState(catalog *ocv1.ClusterCatalog) (*ocv1.ClusterCatalogStatus, storedCatalogData, bool) {
 	r.storedCatalogsMu.RLock()
 	storedCatalog, hasStoredCatalog := r.storedCatalogs[catalog.Name]
@@ -297,11 +406,36 @@ func (r *ClusterCatalogReconciler) getCurrentState(catalog *ocv1.ClusterCatalog)
 	if hasStoredCatalog && r.Storage.ContentExists(catalog.Name) {
 		updateStatusServing(expectedStatus, storedCatalog.ref, storedCatalog.lastUnpack, r.Storage.BaseURL(catalog.Name), storedCatalog.observedGeneration)
 		updateStatusProgressing(expectedStatus, storedCatalog.observedGeneration, nil)
+		updateStatusValidated(expectedStatus, storedCatalog.validationMode, storedCatalog.validationProblems, storedCatalog.observedGeneration)
 	}
 
 	return expectedStatus, storedCatalog, hasStoredCatalog
 }
 
+// validate validates the unpacked catalog content according to the validation mode of
+// the catalog and reflects the result in the Validated condition. In strict mode, an
+// error is returned if any problems are found, so that the previously stored content
+// continues to be served.
+func (r *ClusterCatalogReconciler) validate(ctx context.Context, catalog *ocv1.ClusterCatalog, fsys fs.FS) ([]validation.Problem, error) {
+	if catalog.Spec.ValidationMode != ocv1.ValidationModeWarn && catalog.Spec.ValidationMode != ocv1.ValidationModeStrict {
+		updateStatusValidated(&catalog.Status, catalog.Spec.ValidationMode, nil, catalog.GetGeneration())
+		return nil, nil
+	}
+
+	problems, err := validation.Validate(ctx, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("error validating fbc: %v", err)
+	}
+	updateStatusValidated(&catalog.Status, catalog.Spec.ValidationMode, problems, catalog.GetGeneration())
+	if len(problems) > 0 {
+		log.FromContext(ctx).Info("catalog content failed validation", "problems", len(problems), "validationMode", catalog.Spec.ValidationMode)
+		if catalog.Spec.ValidationMode == ocv1.ValidationModeStrict {
+			return nil, fmt.Errorf("catalog content failed validation with %d problem(s), previously stored content is still served", len(problems))
+		}
+	}
+	return problems, nil
+}
+
 func nextPollResult(lastSuccessfulPoll time.Time, catalog *ocv1.ClusterCatalog) ctrl.Result {
 	var requeueAfter time.Duration
 	switch catalog.Spec.Source.Type {
@@ -319,6 +453,8 @@ func clearUnknownConditions(status *ocv1.ClusterCatalogStatus) {
 	knownTypes := sets.New[string](
 		ocv1.TypeServing,
 		ocv1.TypeProgressing,
+		ocv1.TypeValidated,
+		ocv1.TypeOverlapping,
 	)
 	status.Conditions = slices.DeleteFunc(status.Conditions, func(cond metav1.Condition) bool {
 		return !knownTypes.Has(cond.Type)
@@ -342,7 +478,13 @@ func updateStatusProgressing(status *ocv1.ClusterCatalogStatus, generation int64
 
 	if errors.Is(err, reconcile.TerminalError(nil)) {
 		progressingCond.Status = metav1.ConditionFalse
-		progressingCond.Reason = ocv1.ReasonBlocked
+		// Terminal errors created with NewTerminalError, e.g. for content exceeding
+		// the configured limits, carry a more specific reason than "Blocked".
+		if reason, ok := errorutil.ExtractTerminalReason(err); ok {
+			progressingCond.Reason = reason
+		} else {
+			progressingCond.Reason = ocv1.ReasonBlocked
+		}
 	}
 
 	meta.SetStatusCondition(&status.Conditions, progressingCond)
@@ -368,6 +510,37 @@ func updateStatusServing(status *ocv1.ClusterCatalogStatus, ref reference.Canoni
 	})
 }
 
+// updateStatusValidated sets the Validated condition according to the problems found in
+// the catalog content, or removes it if the content is not validated.
+func updateStatusValidated(status *ocv1.ClusterCatalogStatus, mode ocv1.ValidationMode, problems []validation.Problem, generation int64) {
+	if mode != ocv1.ValidationModeWarn && mode != ocv1.ValidationModeStrict {
+		meta.RemoveStatusCondition(&status.Conditions, ocv1.TypeValidated)
+		return
+	}
+
+	validatedCond := metav1.Condition{
+		Type:               ocv1.TypeValidated,
+		Status:             metav1.ConditionTrue,
+		Reason:             ocv1.ReasonSucceeded,
+		Message:            "No problems found in catalog content",
+		ObservedGeneration: generation,
+	}
+	if len(problems) > 0 {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Found %d problem(s) in catalog content:", len(problems))
+		for _, p := range problems[:min(len(problems), maxReportedValidationProblems)] {
+			fmt.Fprintf(&sb, "\n- %s", p)
+		}
+		if len(problems) > maxReportedValidationProblems {
+			fmt.Fprintf(&sb, "\n- and %d more", len(problems)-maxReportedValidationProblems)
+		}
+		validatedCond.Status = metav1.ConditionFalse
+		validatedCond.Reason = ocv1.ReasonFailed
+		validatedCond.Message = sb.String()
+	}
+	meta.SetStatusCondition(&status.Conditions, validatedCond)
+}
+
 func updateStatusProgressingUserSpecifiedUnavailable(status *ocv1.ClusterCatalogStatus, generation int64) {
 	// Set Progressing condition to True with reason Succeeded
 	// since we have successfully progressed to the unavailable
@@ -461,3 +634,28 @@ func (r *ClusterCatalogReconciler) deleteCatalogCache(ctx context.Context, catal
 	r.deleteStoredCatalog(catalog.Name)
 	return nil
 }
+
+// pullContext returns a context that applies the pull configuration of a catalog to
+// the image pulls made with it. The pull secret of a ClusterCatalog is read from
+// systemNamespace, and that of a namespaced Catalog from its namespace.
+func pullContext(ctx context.Context, secrets client.Reader, systemNamespace string, catalog *ocv1.ClusterCatalog) (context.Context, error) {
+	if catalog.Spec.Source.Image == nil || catalog.Spec.Source.Image.PullConfig == nil {
+		return ctx, nil
+	}
+	namespace := systemNamespace
+	if catalogNamespace, _, ok := catalogutil.SplitNamespacedKey(catalog.Name); ok {
+		namespace = catalogNamespace
+	}
+	opts, err := imageutil.NewPullOptions(ctx, secrets, namespace, catalog.Spec.Source.Image.PullConfig)
+	if err != nil {
+		return nil, err
+	}
+	return imageutil.WithPullOptions(ctx, opts), nil
+}
+
+func (r *ClusterCatalogReconciler) secretReader() client.Reader {
+	if r.SecretReader != nil {
+		return r.SecretReader
+	}
+	return r.Client
+}
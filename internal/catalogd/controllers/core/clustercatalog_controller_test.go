@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"testing"
 	"testing/fstest"
 	"time"
@@ -14,14 +15,21 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.podman.io/image/v5/docker/reference"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
 	mockstorage "github.com/operator-framework/operator-controller/internal/testutil/mock/storage"
 )
@@ -29,10 +37,10 @@ import (
 func newMockStore(ctrl *gomock.Controller, shouldError bool) *mockstorage.MockInstance {
 	m := mockstorage.NewMockInstance(ctrl)
 	if shouldError {
-		m.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mockstore store error")).AnyTimes()
+		m.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("mockstore store error")).AnyTimes()
 		m.EXPECT().Delete(gomock.Any()).Return(errors.New("mockstore delete error")).AnyTimes()
 	} else {
-		m.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		m.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		m.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
 	}
 	m.EXPECT().BaseURL(gomock.Any()).Return("URL").AnyTimes()
@@ -178,6 +186,51 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "valid source type, unpack exceeds limits, status updated to reflect terminal error state(LimitExceeded) and error is returned",
+			expectedError: fmt.Errorf("source catalog content: %w", errorutil.NewTerminalError(ocv1.ReasonLimitExceeded, errors.New("mockpuller limit exceeded"))),
+			puller: &imageutil.FakePuller{
+				Error: errorutil.NewTerminalError(ocv1.ReasonLimitExceeded, errors.New("mockpuller limit exceeded")),
+			},
+			store: newMockStore(mockCtrl, false),
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeImage,
+						Image: &ocv1.ImageSource{
+							Ref: "my.org/someimage:latest",
+						},
+					},
+				},
+			},
+			expectedCatalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeImage,
+						Image: &ocv1.ImageSource{
+							Ref: "my.org/someimage:latest",
+						},
+					},
+				},
+				Status: ocv1.ClusterCatalogStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   ocv1.TypeProgressing,
+							Status: metav1.ConditionFalse,
+							Reason: ocv1.ReasonLimitExceeded,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "valid source type, unpack state == Unpacked, should reflect in status that it's progressing, and is serving",
 			puller: &imageutil.FakePuller{
@@ -241,6 +294,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 			expectedError: fmt.Errorf("error storing fbc: mockstore store error"),
 			puller: &imageutil.FakePuller{
 				ImageFS: &fstest.MapFS{},
+				Ref:     mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
 			},
 			store: newMockStore(mockCtrl, true),
 			catalog: &ocv1.ClusterCatalog{
@@ -1108,6 +1162,54 @@ func TestPollingReconcilerUnpack(t *testing.T) {
 			storedCatalogData: successfulStoredCatalogData(time.Now()),
 			expectedUnpackRun: true,
 		},
+		"ClusterCatalog not being resolved the first time, no pollInterval mentioned, refresh requested, unpack should run": {
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-catalog",
+					Finalizers:  []string{fbcDeletionFinalizer},
+					Generation:  2,
+					Annotations: map[string]string{ocv1.RefreshRequestedAnnotation: "2026-10-19T08:12:04Z"},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeImage,
+						Image: &ocv1.ImageSource{
+							Ref: "my.org/someimage:latest",
+						},
+					},
+				},
+				Status: successfulUnpackStatus(),
+			},
+			storedCatalogData: successfulStoredCatalogData(time.Now()),
+			expectedUnpackRun: true,
+		},
+		"ClusterCatalog not being resolved the first time, no pollInterval mentioned, refresh already handled, unpack should not run": {
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-catalog",
+					Finalizers:  []string{fbcDeletionFinalizer},
+					Generation:  2,
+					Annotations: map[string]string{ocv1.RefreshRequestedAnnotation: "2026-10-19T08:12:04Z"},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeImage,
+						Image: &ocv1.ImageSource{
+							Ref: "my.org/someimage:latest",
+						},
+					},
+				},
+				Status: successfulUnpackStatus(),
+			},
+			storedCatalogData: func() map[string]storedCatalogData {
+				scd := successfulStoredCatalogData(time.Now())
+				stored := scd["test-catalog"]
+				stored.refreshRequested = "2026-10-19T08:12:04Z"
+				scd["test-catalog"] = stored
+				return scd
+			}(),
+			expectedUnpackRun: false,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
@@ -1132,6 +1234,135 @@ func TestPollingReconcilerUnpack(t *testing.T) {
 	}
 }
 
+func TestCatalogdControllerReconcileValidation(t *testing.T) {
+	const validFBC = `{"schema":"olm.package","name":"foo","defaultChannel":"stable"}
+{"schema":"olm.channel","package":"foo","name":"stable","entries":[{"name":"foo.v1.0.0"}]}
+{"schema":"olm.bundle","package":"foo","name":"foo.v1.0.0","properties":[{"type":"olm.package","value":{"packageName":"foo","version":"1.0.0"}}]}
+`
+	const invalidFBC = `{"schema":"olm.package","name":"foo"}
+{"schema":"olm.channel","package":"foo","name":"stable","entries":[{"name":"foo.v1.0.0"}]}
+`
+	ref := mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+
+	for _, tc := range []struct {
+		name              string
+		mode              ocv1.ValidationMode
+		fbc               string
+		expectStore       bool
+		expectedError     string
+		expectedValidated *metav1.Condition
+	}{
+		{
+			name:        "validation disabled, invalid content is stored without a Validated condition",
+			mode:        ocv1.ValidationModeNone,
+			fbc:         invalidFBC,
+			expectStore: true,
+		},
+		{
+			name:        "warn mode, valid content is stored and reported as validated",
+			mode:        ocv1.ValidationModeWarn,
+			fbc:         validFBC,
+			expectStore: true,
+			expectedValidated: &metav1.Condition{
+				Type:    ocv1.TypeValidated,
+				Status:  metav1.ConditionTrue,
+				Reason:  ocv1.ReasonSucceeded,
+				Message: "No problems found in catalog content",
+			},
+		},
+		{
+			name:        "warn mode, invalid content is stored and problems are reported",
+			mode:        ocv1.ValidationModeWarn,
+			fbc:         invalidFBC,
+			expectStore: true,
+			expectedValidated: &metav1.Condition{
+				Type:   ocv1.TypeValidated,
+				Status: metav1.ConditionFalse,
+				Reason: ocv1.ReasonFailed,
+				Message: "Found 2 problem(s) in catalog content:\n" +
+					"- olm.package \"foo\": no default channel\n" +
+					"- package \"foo\" olm.channel \"stable\": entry \"foo.v1.0.0\" references missing bundle",
+			},
+		},
+		{
+			name:          "strict mode, invalid content is not stored and problems are reported",
+			mode:          ocv1.ValidationModeStrict,
+			fbc:           invalidFBC,
+			expectedError: "catalog content failed validation with 2 problem(s), previously stored content is still served",
+			expectedValidated: &metav1.Condition{
+				Type:   ocv1.TypeValidated,
+				Status: metav1.ConditionFalse,
+				Reason: ocv1.ReasonFailed,
+				Message: "Found 2 problem(s) in catalog content:\n" +
+					"- olm.package \"foo\": no default channel\n" +
+					"- package \"foo\" olm.channel \"stable\": entry \"foo.v1.0.0\" references missing bundle",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			store := mockstorage.NewMockInstance(mockCtrl)
+			store.EXPECT().ContentExists(gomock.Any()).Return(true).AnyTimes()
+			store.EXPECT().BaseURL(gomock.Any()).Return("URL").AnyTimes()
+			if tc.expectStore {
+				store.EXPECT().Store(gomock.Any(), "catalog", ref.Digest(), gomock.Any()).Return(nil)
+			}
+			reconciler := &ClusterCatalogReconciler{
+				ImagePuller: &imageutil.FakePuller{
+					ImageFS: fstest.MapFS{"catalog.json": &fstest.MapFile{Data: []byte(tc.fbc)}},
+					Ref:     ref,
+				},
+				ImageCache:     &imageutil.FakeCache{},
+				Storage:        store,
+				storedCatalogs: map[string]storedCatalogData{},
+			}
+			require.NoError(t, reconciler.setupFinalizers())
+			catalog := &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type:  ocv1.SourceTypeImage,
+						Image: &ocv1.ImageSource{Ref: "my.org/someimage:latest"},
+					},
+					ValidationMode: tc.mode,
+				},
+			}
+
+			_, err := reconciler.reconcile(context.Background(), catalog)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				require.NotNil(t, catalog.Status.ResolvedSource)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+				require.Nil(t, catalog.Status.ResolvedSource)
+				progressing := meta.FindStatusCondition(catalog.Status.Conditions, ocv1.TypeProgressing)
+				require.NotNil(t, progressing)
+				require.Equal(t, ocv1.ReasonRetrying, progressing.Reason)
+			}
+
+			validated := meta.FindStatusCondition(catalog.Status.Conditions, ocv1.TypeValidated)
+			if tc.expectedValidated == nil {
+				require.Nil(t, validated)
+				return
+			}
+			require.NotNil(t, validated)
+			require.Equal(t, tc.expectedValidated.Status, validated.Status)
+			require.Equal(t, tc.expectedValidated.Reason, validated.Reason)
+			require.Equal(t, tc.expectedValidated.Message, validated.Message)
+
+			if tc.expectedError == "" {
+				// The stored validation result is reflected in the expected status, so that an
+				// up-to-date catalog does not need to be unpacked again.
+				expectedStatus, _, _ := reconciler.getCurrentState(catalog)
+				require.Empty(t, cmp.Diff(catalog.Status, *expectedStatus))
+			}
+		})
+	}
+}
+
 func mustRef(t *testing.T, ref string) reference.Canonical {
 	t.Helper()
 	p, err := reference.Parse(ref)
@@ -1140,3 +1371,126 @@ func mustRef(t *testing.T, ref string) reference.Canonical {
 	}
 	return p.(reference.Canonical)
 }
+
+func TestNamespacedCatalogReconcile(t *testing.T) {
+	ctx := context.Background()
+	ref := mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	catalog := &ocv1.Catalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "catalog",
+			Namespace:  "team-a",
+			Finalizers: []string{fbcDeletionFinalizer},
+		},
+		Spec: ocv1.ClusterCatalogSpec{
+			Source: ocv1.CatalogSource{
+				Type:  ocv1.SourceTypeImage,
+				Image: &ocv1.ImageSource{Ref: "my.org/someimage:latest"},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	rootURL, err := url.Parse("https://catalogd-service.olmv1-system.svc/catalogs/")
+	require.NoError(t, err)
+	store := storage.NewLocalDirV1(t.TempDir(), rootURL, storage.MetasHandlerDisabled, storage.GraphQLQueriesDisabled)
+
+	reconciler := &ClusterCatalogReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(catalog).WithStatusSubresource(catalog).Build(),
+		ImagePuller: &imageutil.FakePuller{
+			ImageFS: fstest.MapFS{"catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"foo"}`)}},
+			Ref:     ref,
+		},
+		ImageCache:         &imageutil.FakeCache{},
+		Storage:            store,
+		NamespacedCatalogs: true,
+		storedCatalogs:     map[string]storedCatalogData{},
+	}
+	require.NoError(t, reconciler.setupFinalizers())
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "catalog"}}
+	_, err = reconciler.reconcileNamespacedCatalog(ctx, req)
+	require.NoError(t, err)
+
+	// The content is stored under the key of the catalog, so that it does not collide
+	// with the content of a ClusterCatalog with the same name.
+	require.True(t, store.ContentExists(catalogutil.NamespacedKey("team-a", "catalog")))
+	require.False(t, store.ContentExists("catalog"))
+
+	var reconciled ocv1.Catalog
+	require.NoError(t, reconciler.Get(ctx, req.NamespacedName, &reconciled))
+	require.True(t, meta.IsStatusConditionTrue(reconciled.Status.Conditions, ocv1.TypeServing))
+	require.Equal(t, ref.String(), reconciled.Status.ResolvedSource.Image.Ref)
+	require.Equal(t, "https://catalogd-service.olmv1-system.svc/catalogs/namespaces/team-a/catalog", reconciled.Status.URLs.Base)
+	require.Equal(t, []string{fbcDeletionFinalizer}, reconciled.Finalizers)
+
+	// Deleting the catalog deletes its stored content and removes the finalizer.
+	require.NoError(t, reconciler.Delete(ctx, &reconciled))
+	_, err = reconciler.reconcileNamespacedCatalog(ctx, req)
+	require.NoError(t, err)
+	require.False(t, store.ContentExists(catalogutil.NamespacedKey("team-a", "catalog")))
+	require.True(t, apierrors.IsNotFound(reconciler.Get(ctx, req.NamespacedName, &reconciled)))
+}
+
+func TestPullContext(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	secrets := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "olmv1-system", Name: "pull-secret"},
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"system.example.com":{}}}`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "pull-secret"},
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"team-a.example.com":{}}}`)},
+		},
+	).Build()
+	pullConfig := &ocv1.ImagePullConfig{
+		PullSecret: "pull-secret",
+		Registries: []ocv1.RegistryConfig{{Prefix: "quay.io", Insecure: true}},
+	}
+
+	for _, tc := range []struct {
+		name               string
+		catalogName        string
+		pullConfig         *ocv1.ImagePullConfig
+		expectedAuthConfig string
+	}{
+		{
+			name:        "catalog without pull config",
+			catalogName: "cluster",
+		},
+		{
+			name:               "pull secret of a cluster catalog is read from the system namespace",
+			catalogName:        "cluster",
+			pullConfig:         pullConfig,
+			expectedAuthConfig: `{"auths":{"system.example.com":{}}}`,
+		},
+		{
+			name:               "pull secret of a namespaced catalog is read from its namespace",
+			catalogName:        catalogutil.NamespacedKey("team-a", "tenant"),
+			pullConfig:         pullConfig,
+			expectedAuthConfig: `{"auths":{"team-a.example.com":{}}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			catalog := &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: tc.catalogName},
+				Spec: ocv1.ClusterCatalogSpec{Source: ocv1.CatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ImageSource{Ref: "quay.io/catalogs/catalog:latest", PullConfig: tc.pullConfig},
+				}},
+			}
+			ctx, err := pullContext(context.Background(), secrets, "olmv1-system", catalog)
+			require.NoError(t, err)
+
+			opts := imageutil.PullOptionsFromContext(ctx)
+			if tc.pullConfig == nil {
+				assert.Nil(t, opts)
+				return
+			}
+			require.NotNil(t, opts)
+			assert.JSONEq(t, tc.expectedAuthConfig, string(opts.AuthConfig))
+			assert.Equal(t, []imageutil.RegistryOptions{{Prefix: "quay.io", Insecure: true}}, opts.Registries)
+		})
+	}
+}
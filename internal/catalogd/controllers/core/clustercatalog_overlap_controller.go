@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogd/overlap"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+)
+
+// ClusterCatalogOverlapReconciler reports in the Overlapping condition of each served
+// ClusterCatalog the packages it shares with other served ClusterCatalogs of the same
+// priority, so that they can be fixed before resolving these packages fails.
+type ClusterCatalogOverlapReconciler struct {
+	client.Client
+
+	Storage storage.Instance
+}
+
+// Reconcile updates the Overlapping condition of a ClusterCatalog.
+func (r *ClusterCatalogOverlapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := log.FromContext(ctx).WithName("catalogd-overlap-controller")
+	ctx = log.IntoContext(ctx, l)
+
+	catalogs := ocv1.ClusterCatalogList{}
+	if err := r.List(ctx, &catalogs); err != nil {
+		return ctrl.Result{}, err
+	}
+	var existing *ocv1.ClusterCatalog
+	for i := range catalogs.Items {
+		if catalogs.Items[i].Name == req.Name {
+			existing = &catalogs.Items[i]
+		}
+	}
+	if existing == nil || !existing.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	reconciled := existing.DeepCopy()
+	if err := r.reconcile(reconciled, catalogs.Items); err != nil {
+		return ctrl.Result{}, err
+	}
+	if equality.Semantic.DeepEqual(existing.Status, reconciled.Status) {
+		return ctrl.Result{}, nil
+	}
+	if cond := meta.FindStatusCondition(reconciled.Status.Conditions, ocv1.TypeOverlapping); cond != nil && cond.Status == metav1.ConditionTrue {
+		l.Info("catalog packages overlap with other catalogs of the same priority", "priority", reconciled.Spec.Priority)
+	}
+	return ctrl.Result{}, r.Status().Update(ctx, reconciled)
+}
+
+func (r *ClusterCatalogOverlapReconciler) reconcile(catalog *ocv1.ClusterCatalog, catalogs []ocv1.ClusterCatalog) error {
+	if !overlap.IsServing(catalog) {
+		meta.RemoveStatusCondition(&catalog.Status.Conditions, ocv1.TypeOverlapping)
+		return nil
+	}
+
+	packages, err := r.Storage.GetPackageNames(catalog.Name)
+	if errors.Is(err, fs.ErrNotExist) {
+		// The content is stored once the catalog is served by this replica, which
+		// triggers another reconciliation.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting packages: %w", err)
+	}
+	overlaps, err := overlap.Find(r.Storage, catalog.Name, catalog.Spec.Priority, packages, catalogs)
+	if err != nil {
+		return err
+	}
+	updateStatusOverlapping(&catalog.Status, catalog.Spec.Priority, overlaps, catalog.GetGeneration())
+	return nil
+}
+
+func updateStatusOverlapping(status *ocv1.ClusterCatalogStatus, priority int32, overlaps []overlap.Overlap, generation int64) {
+	overlappingCond := metav1.Condition{
+		Type:               ocv1.TypeOverlapping,
+		Status:             metav1.ConditionFalse,
+		Reason:             ocv1.ReasonNoOverlap,
+		Message:            fmt.Sprintf("no packages are provided by other ClusterCatalogs with priority %d", priority),
+		ObservedGeneration: generation,
+	}
+	if len(overlaps) > 0 {
+		overlappingCond.Status = metav1.ConditionTrue
+		overlappingCond.Reason = ocv1.ReasonPackagesOverlap
+		overlappingCond.Message = overlap.Message(priority, overlaps)
+	}
+	meta.SetStatusCondition(&status.Conditions, overlappingCond)
+}
+
+// SetupWithManager sets up the controller with the Manager. As a change to any
+// ClusterCatalog can change the overlaps of every other ClusterCatalog, all of them
+// are reconciled whenever the priority, availability or served content of one changes.
+func (r *ClusterCatalogOverlapReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("catalogd-clustercatalog-overlap-controller").
+		Watches(&ocv1.ClusterCatalog{},
+			handler.EnqueueRequestsFromMapFunc(r.allCatalogs),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: overlapInputsChanged}),
+		).
+		Complete(r)
+}
+
+func (r *ClusterCatalogOverlapReconciler) allCatalogs(ctx context.Context, _ client.Object) []reconcile.Request {
+	catalogs := ocv1.ClusterCatalogList{}
+	if err := r.List(ctx, &catalogs); err != nil {
+		log.FromContext(ctx).Error(err, "error listing catalogs")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(catalogs.Items))
+	for _, catalog := range catalogs.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&catalog)})
+	}
+	return requests
+}
+
+// overlapInputsChanged filters out updates of ClusterCatalogs that do not change
+// their overlaps, including the updates of the Overlapping condition itself.
+func overlapInputsChanged(e event.UpdateEvent) bool {
+	oldCatalog, oldOK := e.ObjectOld.(*ocv1.ClusterCatalog)
+	newCatalog, newOK := e.ObjectNew.(*ocv1.ClusterCatalog)
+	if !oldOK || !newOK {
+		return true
+	}
+	return oldCatalog.Spec.Priority != newCatalog.Spec.Priority ||
+		oldCatalog.Spec.AvailabilityMode != newCatalog.Spec.AvailabilityMode ||
+		overlap.IsServing(oldCatalog) != overlap.IsServing(newCatalog) ||
+		!equality.Semantic.DeepEqual(oldCatalog.Status.ResolvedSource, newCatalog.Status.ResolvedSource) ||
+		!equality.Semantic.DeepEqual(oldCatalog.Status.LastUnpacked, newCatalog.Status.LastUnpacked) ||
+		!newCatalog.DeletionTimestamp.IsZero()
+}
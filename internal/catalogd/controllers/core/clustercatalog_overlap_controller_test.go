@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+)
+
+func packagesFS(packages ...string) fstest.MapFS {
+	var data []byte
+	for _, p := range packages {
+		data = append(data, fmt.Sprintf(`{"schema":"olm.package","name":%q}`+"\n", p)...)
+	}
+	return fstest.MapFS{"catalog.json": &fstest.MapFile{Data: data}}
+}
+
+func TestClusterCatalogOverlapReconcile(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	rootURL, err := url.Parse("http://catalogd-service.olmv1-system.svc/catalogs/")
+	require.NoError(t, err)
+
+	self := newServedCatalog("self", replicaTestRef)
+	same := newServedCatalog("same", replicaTestRef)
+	otherPriority := newServedCatalog("other-priority", replicaTestRef)
+	otherPriority.Spec.Priority = 10
+	notServing := newServedCatalog("not-serving", replicaTestRef)
+	notServing.Status.Conditions = []metav1.Condition{{Type: ocv1.TypeOverlapping, Status: metav1.ConditionTrue, Reason: ocv1.ReasonPackagesOverlap}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(self, same, otherPriority, notServing).
+		WithStatusSubresource(self, same, otherPriority, notServing).
+		Build()
+
+	store := storage.NewLocalDirV1(t.TempDir(), rootURL, storage.MetasHandlerDisabled, storage.GraphQLQueriesDisabled)
+	for name, fsys := range map[string]fstest.MapFS{
+		"self":           packagesFS("a", "b"),
+		"same":           packagesFS("b", "c"),
+		"other-priority": packagesFS("a"),
+		"not-serving":    packagesFS("a", "b"),
+	} {
+		require.NoError(t, store.Store(ctx, name, "", fsys))
+	}
+	r := &ClusterCatalogOverlapReconciler{Client: cl, Storage: store}
+
+	overlapping := func(t *testing.T, name string) *metav1.Condition {
+		t.Helper()
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		require.NoError(t, err)
+		catalog := &ocv1.ClusterCatalog{}
+		require.NoError(t, cl.Get(ctx, client.ObjectKey{Name: name}, catalog))
+		return meta.FindStatusCondition(catalog.Status.Conditions, ocv1.TypeOverlapping)
+	}
+
+	cond := overlapping(t, "self")
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Equal(t, ocv1.ReasonPackagesOverlap, cond.Reason)
+	require.Contains(t, cond.Message, `"same" (b)`)
+	require.NotContains(t, cond.Message, "not-serving")
+
+	cond = overlapping(t, "other-priority")
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+	require.Equal(t, ocv1.ReasonNoOverlap, cond.Reason)
+
+	require.Nil(t, overlapping(t, "not-serving"))
+
+	// Moving a catalog to another priority resolves the overlap.
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(same), same))
+	same.Spec.Priority = 20
+	require.NoError(t, cl.Update(ctx, same))
+	cond = overlapping(t, "self")
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+}
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.podman.io/image/v5/docker/reference"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogd/garbagecollection"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+)
+
+// ClusterCatalogReplicaReconciler keeps the local storage of catalogd replicas that
+// are not the leader in sync with the content served by the leader, so that every
+// replica can serve catalog content.
+//
+// It never modifies ClusterCatalogs. Instead, it pulls the image referenced by the
+// status.resolvedSource set by the leader, by digest, and stores its content locally.
+// Because the resolved source is pinned to a digest, every replica stores exactly the
+// same content as the leader. Once a replica is elected leader, the
+// ClusterCatalogReconciler takes over storing content and this reconciler does nothing.
+type ClusterCatalogReplicaReconciler struct {
+	client.Client
+
+	ImageCache  imageutil.Cache
+	ImagePuller imageutil.Puller
+
+	Storage storage.Instance
+
+	// Unpacks tracks the catalogs being unpacked and stored, as for the
+	// ClusterCatalogReconciler.
+	Unpacks *garbagecollection.UnpackTracker
+
+	// NamespacedCatalogs enables storing the content of namespaced Catalogs, under
+	// their key.
+	NamespacedCatalogs bool
+
+	// SecretReader and SystemNamespace locate the pull secrets of catalogs, as for
+	// the ClusterCatalogReconciler.
+	SecretReader    client.Reader
+	SystemNamespace string
+
+	// Elected is closed once this replica is elected leader.
+	Elected <-chan struct{}
+}
+
+// Reconcile stores the content of the resolved source of a ClusterCatalog if the
+// locally stored content was resolved from a different digest, and deletes it once
+// the catalog is no longer served.
+func (r *ClusterCatalogReplicaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.isLeader() {
+		return ctrl.Result{}, nil
+	}
+
+	l := log.FromContext(ctx).WithName("catalogd-replica-controller")
+	ctx = log.IntoContext(ctx, l)
+
+	catalog := ocv1.ClusterCatalog{}
+	if err := r.Get(ctx, req.NamespacedName, &catalog); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.deleteContent(ctx, req.Name)
+		}
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.storeContent(ctx, &catalog)
+}
+
+// reconcileNamespacedCatalog stores the content of the resolved source of a namespaced
+// Catalog under its key, like the content of a ClusterCatalog.
+func (r *ClusterCatalogReplicaReconciler) reconcileNamespacedCatalog(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.isLeader() {
+		return ctrl.Result{}, nil
+	}
+
+	l := log.FromContext(ctx).WithName("catalogd-catalog-replica-controller")
+	ctx = log.IntoContext(ctx, l)
+
+	catalog := ocv1.Catalog{}
+	if err := r.Get(ctx, req.NamespacedName, &catalog); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.deleteContent(ctx, catalogutil.NamespacedKey(req.Namespace, req.Name))
+		}
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.storeContent(ctx, catalogutil.AsClusterCatalog(&catalog))
+}
+
+// storeContent stores the content of the resolved source of a catalog, unless the
+// content resolved from the same digest is already stored.
+func (r *ClusterCatalogReplicaReconciler) storeContent(ctx context.Context, catalog *ocv1.ClusterCatalog) error {
+	l := log.FromContext(ctx)
+
+	resolvedRef, ok := servedRef(catalog)
+	if !ok {
+		return r.deleteContent(ctx, catalog.Name)
+	}
+
+	// Storage backends shared by all replicas make the content stored by the leader
+	// available locally first, as stored content is only ever read locally.
+	if syncer, ok := r.Storage.(storage.LocalSyncer); ok {
+		if err := syncer.SyncLocal(ctx, catalog.Name); err != nil {
+			return fmt.Errorf("error syncing stored content: %w", err)
+		}
+	}
+	if r.Storage.ContentExists(catalog.Name) {
+		storedDigest, err := r.Storage.GetCatalogDigest(catalog.Name)
+		if err != nil {
+			return fmt.Errorf("error getting digest of stored content: %w", err)
+		}
+		if storedDigest == resolvedRef.Digest() {
+			return nil
+		}
+	}
+
+	l.Info("storing content resolved by leader", "ref", resolvedRef.String())
+	pullCtx, err := pullContext(ctx, r.secretReader(), r.SystemNamespace, catalog)
+	if err != nil {
+		return err
+	}
+	defer r.Unpacks.Begin(catalog.Name)()
+	fsys, canonicalRef, _, err := r.ImagePuller.Pull(pullCtx, catalog.Name, resolvedRef.String(), r.ImageCache)
+	if err != nil {
+		return fmt.Errorf("source catalog content: %w", err)
+	}
+	if err := r.Storage.Store(ctx, catalog.Name, canonicalRef.Digest(), fsys); err != nil {
+		return fmt.Errorf("error storing fbc: %v", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Unlike the
+// ClusterCatalogReconciler, it runs on every replica regardless of leadership.
+func (r *ClusterCatalogReplicaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Elected == nil {
+		r.Elected = mgr.Elected()
+	}
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&ocv1.ClusterCatalog{}).
+		Named("catalogd-clustercatalog-replica-controller").
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r); err != nil {
+		return err
+	}
+
+	if !r.NamespacedCatalogs {
+		return nil
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ocv1.Catalog{}).
+		Named("catalogd-catalog-replica-controller").
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(reconcile.Func(r.reconcileNamespacedCatalog))
+}
+
+// ReadyzCheck returns a healthz.Checker that passes once the content of the resolved
+// source of every served ClusterCatalog, and namespaced Catalog if enabled, is stored locally, so that replicas only
+// receive traffic once they serve the same content as the leader.
+func (r *ClusterCatalogReplicaReconciler) ReadyzCheck() healthz.Checker {
+	return func(req *http.Request) error {
+		var catalogs ocv1.ClusterCatalogList
+		if err := r.List(req.Context(), &catalogs); err != nil {
+			return fmt.Errorf("error listing clustercatalogs: %w", err)
+		}
+		if r.NamespacedCatalogs {
+			var namespacedCatalogs ocv1.CatalogList
+			if err := r.List(req.Context(), &namespacedCatalogs); err != nil {
+				return fmt.Errorf("error listing catalogs: %w", err)
+			}
+			for i := range namespacedCatalogs.Items {
+				catalogs.Items = append(catalogs.Items, *catalogutil.AsClusterCatalog(&namespacedCatalogs.Items[i]))
+			}
+		}
+		for i := range catalogs.Items {
+			catalog := &catalogs.Items[i]
+			resolvedRef, ok := servedRef(catalog)
+			if !ok {
+				continue
+			}
+			storedDigest, err := r.Storage.GetCatalogDigest(catalog.Name)
+			if err != nil {
+				return fmt.Errorf("error getting digest of stored content for catalog %q: %w", catalog.Name, err)
+			}
+			if storedDigest != resolvedRef.Digest() {
+				return fmt.Errorf("content of catalog %q resolved from %q is not stored yet", catalog.Name, resolvedRef.Digest())
+			}
+		}
+		return nil
+	}
+}
+
+func (r *ClusterCatalogReplicaReconciler) isLeader() bool {
+	select {
+	case <-r.Elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// deleteContent deletes the locally stored content of a catalog. Content of storage
+// shared by all replicas is only evicted from the local cache, as deleting it is up
+// to the leader.
+func (r *ClusterCatalogReplicaReconciler) deleteContent(ctx context.Context, catalogName string) error {
+	deleteStored := r.Storage.Delete
+	if evicter, ok := r.Storage.(storage.LocalEvicter); ok {
+		deleteStored = evicter.EvictLocal
+	}
+	if err := deleteStored(catalogName); err != nil {
+		return err
+	}
+	return r.ImageCache.Delete(ctx, catalogName)
+}
+
+// servedRef returns the digest-pinned reference of the resolved source of a catalog
+// that the leader is serving content for.
+func servedRef(catalog *ocv1.ClusterCatalog) (reference.Canonical, bool) {
+	if catalog.GetDeletionTimestamp() != nil || catalog.Spec.AvailabilityMode == ocv1.AvailabilityModeUnavailable {
+		return nil, false
+	}
+	if !meta.IsStatusConditionTrue(catalog.Status.Conditions, ocv1.TypeServing) {
+		return nil, false
+	}
+	if catalog.Status.ResolvedSource == nil || catalog.Status.ResolvedSource.Image == nil {
+		return nil, false
+	}
+	named, err := reference.ParseNamed(catalog.Status.ResolvedSource.Image.Ref)
+	if err != nil {
+		return nil, false
+	}
+	canonical, ok := named.(reference.Canonical)
+	if !ok || canonical.Digest().Validate() != nil {
+		return nil, false
+	}
+	return canonical, true
+}
+
+func (r *ClusterCatalogReplicaReconciler) secretReader() client.Reader {
+	if r.SecretReader != nil {
+		return r.SecretReader
+	}
+	return r.Client
+}
@@ -0,0 +1,288 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/docker/reference"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage/s3"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage/s3/s3test"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+)
+
+const (
+	replicaTestRef    = "my.org/someimage@sha256:3e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e"
+	replicaTestNewRef = "my.org/someimage@sha256:4f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f3f"
+)
+
+func newServedCatalog(name, ref string) *ocv1.ClusterCatalog {
+	return &ocv1.ClusterCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ocv1.ClusterCatalogSpec{
+			Source: ocv1.CatalogSource{
+				Type:  ocv1.SourceTypeImage,
+				Image: &ocv1.ImageSource{Ref: "my.org/someimage:latest"},
+			},
+			AvailabilityMode: ocv1.AvailabilityModeAvailable,
+		},
+		Status: ocv1.ClusterCatalogStatus{
+			ResolvedSource: &ocv1.ResolvedCatalogSource{
+				Type:  ocv1.SourceTypeImage,
+				Image: &ocv1.ResolvedImageSource{Ref: ref},
+			},
+			Conditions: []metav1.Condition{{
+				Type:   ocv1.TypeServing,
+				Status: metav1.ConditionTrue,
+				Reason: ocv1.ReasonAvailable,
+			}},
+		},
+	}
+}
+
+func newReplicaReconciler(t *testing.T, puller imageutil.Puller, elected bool, catalogs ...*ocv1.ClusterCatalog) (*ClusterCatalogReplicaReconciler, *storage.LocalDirV1) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, catalog := range catalogs {
+		builder = builder.WithObjects(catalog).WithStatusSubresource(catalog)
+	}
+
+	rootURL, err := url.Parse("http://catalogd-service.olmv1-system.svc/catalogs/")
+	require.NoError(t, err)
+	store := storage.NewLocalDirV1(t.TempDir(), rootURL, storage.MetasHandlerDisabled, storage.GraphQLQueriesDisabled)
+
+	electedCh := make(chan struct{})
+	if elected {
+		close(electedCh)
+	}
+	return &ClusterCatalogReplicaReconciler{
+		Client:      builder.Build(),
+		ImageCache:  &imageutil.FakeCache{},
+		ImagePuller: puller,
+		Storage:     store,
+		Elected:     electedCh,
+	}, store
+}
+
+func replicaTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"my-package"}`)},
+	}
+}
+
+func mustCanonical(t *testing.T, ref string) reference.Canonical {
+	t.Helper()
+	named, err := reference.ParseNamed(ref)
+	require.NoError(t, err)
+	canonical, ok := named.(reference.Canonical)
+	require.True(t, ok)
+	return canonical
+}
+
+func TestClusterCatalogReplicaReconcile(t *testing.T) {
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-catalog"}}
+
+	t.Run("stores content of the resolved source and becomes ready", func(t *testing.T) {
+		catalog := newServedCatalog("test-catalog", replicaTestRef)
+		puller := &imageutil.FakePuller{ImageFS: replicaTestFS(), Ref: mustCanonical(t, replicaTestRef)}
+		r, store := newReplicaReconciler(t, puller, false, catalog)
+
+		readyz := r.ReadyzCheck()
+		require.Error(t, readyz(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		require.True(t, store.ContentExists("test-catalog"))
+		dgst, err := store.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Equal(t, mustCanonical(t, replicaTestRef).Digest(), dgst)
+		require.NoError(t, readyz(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+	})
+
+	t.Run("does not pull when current content is already stored", func(t *testing.T) {
+		catalog := newServedCatalog("test-catalog", replicaTestRef)
+		r, store := newReplicaReconciler(t, &imageutil.FakePuller{Error: errors.New("unexpected pull")}, false, catalog)
+		require.NoError(t, store.Store(ctx, "test-catalog", mustCanonical(t, replicaTestRef).Digest(), replicaTestFS()))
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("replaces content when the leader resolves a new digest", func(t *testing.T) {
+		catalog := newServedCatalog("test-catalog", replicaTestNewRef)
+		puller := &imageutil.FakePuller{ImageFS: replicaTestFS(), Ref: mustCanonical(t, replicaTestNewRef)}
+		r, store := newReplicaReconciler(t, puller, false, catalog)
+		require.NoError(t, store.Store(ctx, "test-catalog", mustCanonical(t, replicaTestRef).Digest(), replicaTestFS()))
+
+		readyz := r.ReadyzCheck()
+		require.Error(t, readyz(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		dgst, err := store.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Equal(t, mustCanonical(t, replicaTestNewRef).Digest(), dgst)
+		require.NoError(t, readyz(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+	})
+
+	t.Run("returns pull errors", func(t *testing.T) {
+		catalog := newServedCatalog("test-catalog", replicaTestRef)
+		r, store := newReplicaReconciler(t, &imageutil.FakePuller{Error: errors.New("pull failed")}, false, catalog)
+
+		_, err := r.Reconcile(ctx, req)
+		require.ErrorContains(t, err, "pull failed")
+		require.False(t, store.ContentExists("test-catalog"))
+	})
+
+	t.Run("does nothing once elected leader", func(t *testing.T) {
+		catalog := newServedCatalog("test-catalog", replicaTestRef)
+		r, store := newReplicaReconciler(t, &imageutil.FakePuller{Error: errors.New("unexpected pull")}, true, catalog)
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		require.False(t, store.ContentExists("test-catalog"))
+	})
+
+	t.Run("deletes content of unavailable catalogs", func(t *testing.T) {
+		catalog := newServedCatalog("test-catalog", replicaTestRef)
+		catalog.Spec.AvailabilityMode = ocv1.AvailabilityModeUnavailable
+		r, store := newReplicaReconciler(t, &imageutil.FakePuller{Error: errors.New("unexpected pull")}, false, catalog)
+		require.NoError(t, store.Store(ctx, "test-catalog", mustCanonical(t, replicaTestRef).Digest(), replicaTestFS()))
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		require.False(t, store.ContentExists("test-catalog"))
+		require.NoError(t, r.ReadyzCheck()(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+	})
+
+	t.Run("deletes content of deleted catalogs", func(t *testing.T) {
+		r, store := newReplicaReconciler(t, &imageutil.FakePuller{Error: errors.New("unexpected pull")}, false)
+		require.NoError(t, store.Store(ctx, "test-catalog", mustCanonical(t, replicaTestRef).Digest(), replicaTestFS()))
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		require.False(t, store.ContentExists("test-catalog"))
+	})
+}
+
+func TestClusterCatalogReplicaReconcileSharedStorage(t *testing.T) {
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-catalog"}}
+	srv := httptest.NewServer(s3test.NewFakeObjectStore())
+	defer srv.Close()
+	endpoint, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	newObjectStore := func() *storage.ObjectStoreV1 {
+		bucket, err := s3.NewClient(endpoint, "olmv1", "us-east-1", s3.Credentials{AccessKeyID: "test", SecretAccessKey: "test"}, srv.Client())
+		require.NoError(t, err)
+		rootURL, err := url.Parse("http://catalogd-service.olmv1-system.svc/catalogs/")
+		require.NoError(t, err)
+		local := storage.NewLocalDirV1(t.TempDir(), rootURL, storage.MetasHandlerDisabled, storage.GraphQLQueriesDisabled)
+		return storage.NewObjectStoreV1(bucket, "catalogs/", storage.ObjectContentProxy, local)
+	}
+
+	leaderStore := newObjectStore()
+	require.NoError(t, leaderStore.Store(ctx, "test-catalog", mustCanonical(t, replicaTestRef).Digest(), replicaTestFS()))
+
+	for _, tc := range []struct {
+		name     string
+		catalogs []*ocv1.ClusterCatalog
+	}{
+		{
+			name: "unavailable catalog",
+			catalogs: func() []*ocv1.ClusterCatalog {
+				catalog := newServedCatalog("test-catalog", replicaTestRef)
+				catalog.Spec.AvailabilityMode = ocv1.AvailabilityModeUnavailable
+				return []*ocv1.ClusterCatalog{catalog}
+			}(),
+		},
+		{
+			name: "deleted catalog",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, _ := newReplicaReconciler(t, &imageutil.FakePuller{Error: errors.New("unexpected pull")}, false, tc.catalogs...)
+			followerStore := newObjectStore()
+			r.Storage = followerStore
+			require.NoError(t, followerStore.SyncLocal(ctx, "test-catalog"))
+			require.True(t, followerStore.ContentExists("test-catalog"))
+			require.True(t, followerStore.Local.ContentExists("test-catalog"))
+
+			_, err := r.Reconcile(ctx, req)
+			require.NoError(t, err)
+			require.False(t, followerStore.Local.ContentExists("test-catalog"))
+
+			// The objects served by the leader are left in place.
+			require.True(t, leaderStore.ContentExists("test-catalog"))
+			otherStore := newObjectStore()
+			require.NoError(t, otherStore.SyncLocal(ctx, "test-catalog"))
+			dgst, err := otherStore.GetCatalogDigest("test-catalog")
+			require.NoError(t, err)
+			require.Equal(t, mustCanonical(t, replicaTestRef).Digest(), dgst)
+		})
+	}
+
+	t.Run("served catalog is synced without pulling its image", func(t *testing.T) {
+		r, _ := newReplicaReconciler(t, &imageutil.FakePuller{Error: errors.New("unexpected pull")}, false, newServedCatalog("test-catalog", replicaTestRef))
+		followerStore := newObjectStore()
+		r.Storage = followerStore
+		require.False(t, followerStore.ContentExists("test-catalog"))
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		require.True(t, followerStore.ContentExists("test-catalog"))
+		dgst, err := followerStore.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Equal(t, mustCanonical(t, replicaTestRef).Digest(), dgst)
+	})
+}
+
+func TestCatalogReplicaReconcileNamespaced(t *testing.T) {
+	ctx := context.Background()
+	served := newServedCatalog("test-catalog", replicaTestRef)
+	catalog := &ocv1.Catalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-catalog", Namespace: "team-a"},
+		Spec:       served.Spec,
+		Status:     served.Status,
+	}
+	key := catalogutil.NamespacedKey("team-a", "test-catalog")
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "test-catalog"}}
+
+	puller := &imageutil.FakePuller{ImageFS: replicaTestFS(), Ref: mustCanonical(t, replicaTestRef)}
+	r, store := newReplicaReconciler(t, puller, false)
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(catalog).WithStatusSubresource(catalog).Build()
+	r.NamespacedCatalogs = true
+
+	readyz := r.ReadyzCheck()
+	require.Error(t, readyz(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+
+	_, err := r.reconcileNamespacedCatalog(ctx, req)
+	require.NoError(t, err)
+	require.True(t, store.ContentExists(key))
+	require.False(t, store.ContentExists("test-catalog"))
+	require.NoError(t, readyz(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+
+	require.NoError(t, r.Delete(ctx, catalog))
+	_, err = r.reconcileNamespacedCatalog(ctx, req)
+	require.NoError(t, err)
+	require.False(t, store.ContentExists(key))
+}
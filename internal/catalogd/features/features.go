@@ -9,13 +9,17 @@ import (
 )
 
 const (
-	APIV1MetasHandler     = featuregate.Feature("APIV1MetasHandler")
-	GraphQLCatalogQueries = featuregate.Feature("GraphQLCatalogQueries")
+	APIV1MetasHandler       = featuregate.Feature("APIV1MetasHandler")
+	GraphQLCatalogQueries   = featuregate.Feature("GraphQLCatalogQueries")
+	NamespacedCatalogs      = featuregate.Feature("NamespacedCatalogs")
+	CatalogOverlapDetection = featuregate.Feature("CatalogOverlapDetection")
 )
 
 var catalogdFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	APIV1MetasHandler:     {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
-	GraphQLCatalogQueries: {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	APIV1MetasHandler:       {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	GraphQLCatalogQueries:   {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	NamespacedCatalogs:      {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	CatalogOverlapDetection: {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
 }
 
 var CatalogdFeatureGate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
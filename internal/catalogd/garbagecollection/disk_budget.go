@@ -0,0 +1,257 @@
+package garbagecollection
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+
+	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
+	fsutil "github.com/operator-framework/operator-controller/internal/shared/util/fs"
+)
+
+// DiskBudget limits the disk space used by the caches of catalogd.
+//
+// Only unpacked images in the unpack cache are evicted to enforce the budget. They are
+// only needed to avoid pulling the image of a catalog again, as the content served for
+// a catalog is stored separately. Unpacked images of digests other than the one whose
+// content is stored for their catalog are evicted first, followed by the least recently
+// unpacked images of catalogs without stored content. The unpacked image of the stored
+// digest of a catalog, the stored content itself, and the images of catalogs that are
+// being unpacked are never evicted.
+type DiskBudget struct {
+	// UnpackCachePath is the directory of the image unpack cache, which contains a
+	// directory for each ClusterCatalog, containing a directory for each unpacked
+	// image digest.
+	UnpackCachePath string
+	// StoredDigest returns the digest of the image whose content is stored for a catalog.
+	StoredDigest func(catalog string) (digest.Digest, error)
+	// MaxBytes is the maximum number of bytes used by all cache paths of the garbage
+	// collector. Zero means no limit.
+	MaxBytes int64
+	// MinFreeBytes is the minimum number of bytes that must remain free on the
+	// filesystem of the unpack cache. Zero means no minimum.
+	MinFreeBytes int64
+	// FreeSpaceCheckInterval is the interval at which free space is checked, so that
+	// garbage collection runs as soon as it drops below MinFreeBytes rather than at the
+	// next garbage collection interval.
+	FreeSpaceCheckInterval time.Duration
+	// Unpacks tracks the catalogs being unpacked, whose images are not evicted.
+	Unpacks *UnpackTracker
+}
+
+// UnpackTracker tracks the catalogs whose image is being unpacked into the unpack
+// cache and stored, so that their unpacked images are not evicted while in use.
+type UnpackTracker struct {
+	mu sync.Mutex
+	// active counts the unpacks in progress of each catalog.
+	active map[string]int
+}
+
+// NewUnpackTracker returns an UnpackTracker with no unpacks in progress.
+func NewUnpackTracker() *UnpackTracker {
+	return &UnpackTracker{active: map[string]int{}}
+}
+
+// Begin records that an image of the catalog is being unpacked, until the returned
+// function is called. Begin waits for the eviction of an image of the catalog that is
+// in progress, if any, so that unpacks never use images that are being evicted.
+func (t *UnpackTracker) Begin(catalog string) (done func()) {
+	if t == nil {
+		return func() {}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active[catalog]++
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.active[catalog]--; t.active[catalog] <= 0 {
+			delete(t.active, catalog)
+		}
+	}
+}
+
+// evict calls evict, unless an image of the catalog is being unpacked, and reports
+// whether it was called.
+func (t *UnpackTracker) evict(catalog string, evict func() error) (bool, error) {
+	if t == nil {
+		return true, evict()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.active[catalog] > 0 {
+		return false, nil
+	}
+	return true, evict()
+}
+
+// unpackedImage is an image digest unpacked in the unpack cache.
+type unpackedImage struct {
+	catalog string
+	path    string
+	size    int64
+	modTime time.Time
+	old     bool
+}
+
+// enforceDiskBudget evicts unpacked images until the caches fit the disk budget, and
+// returns the paths of the evicted images.
+func (gc *GarbageCollector) enforceDiskBudget() ([]string, error) {
+	b := gc.DiskBudget
+	usage, err := totalSize(gc.CachePaths)
+	if err != nil {
+		return nil, fmt.Errorf("error determining disk usage: %w", err)
+	}
+	defer func() { catalogdmetrics.GCDiskUsageMetric.Set(float64(usage)) }()
+
+	var toFree int64
+	if b.MaxBytes > 0 {
+		toFree = usage - b.MaxBytes
+	}
+	if b.MinFreeBytes > 0 {
+		free, err := freeSpace(b.UnpackCachePath)
+		switch {
+		case errors.Is(err, errors.ErrUnsupported):
+		case err != nil:
+			return nil, fmt.Errorf("error determining free space: %w", err)
+		default:
+			toFree = max(toFree, b.MinFreeBytes-free)
+		}
+	}
+	if toFree <= 0 {
+		return nil, nil
+	}
+
+	images, err := b.unpackedImages()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		freed   int64
+		evicted []string
+	)
+	for _, img := range images {
+		if freed >= toFree {
+			break
+		}
+		ok, err := b.Unpacks.evict(img.catalog, func() error { return fsutil.DeleteReadOnlyRecursive(img.path) })
+		if err != nil {
+			return evicted, fmt.Errorf("error evicting unpacked image %q: %w", img.path, err)
+		}
+		if !ok {
+			continue
+		}
+		freed += img.size
+		usage -= img.size
+		evicted = append(evicted, img.path)
+		reason := catalogdmetrics.GCReasonDiskBudget
+		if img.old {
+			reason = catalogdmetrics.GCReasonOldDigest
+		}
+		catalogdmetrics.GCReclaimedBytesMetric.WithLabelValues(reason).Add(float64(img.size))
+	}
+	if freed < toFree {
+		gc.Logger.Info("unable to meet disk budget by evicting unpacked images", "usage", usage, "maxBytes", b.MaxBytes, "minFreeBytes", b.MinFreeBytes, "shortfall", toFree-freed)
+	}
+	return evicted, nil
+}
+
+// unpackedImages returns the images unpacked in the unpack cache that may be evicted,
+// in the order in which they are evicted. The images of the stored digests of catalogs
+// are not returned.
+func (b *DiskBudget) unpackedImages() ([]unpackedImage, error) {
+	catalogEntries, err := os.ReadDir(b.UnpackCachePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading unpack cache directory: %w", err)
+	}
+	var images []unpackedImage
+	for _, catalogEntry := range catalogEntries {
+		if !catalogEntry.IsDir() {
+			continue
+		}
+		storedDigest, err := b.StoredDigest(catalogEntry.Name())
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("error getting stored digest of catalog %q: %w", catalogEntry.Name(), err)
+		}
+		catalogPath := filepath.Join(b.UnpackCachePath, catalogEntry.Name())
+		digestEntries, err := os.ReadDir(catalogPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading unpack cache directory of catalog %q: %w", catalogEntry.Name(), err)
+		}
+		for _, digestEntry := range digestEntries {
+			if storedDigest != "" && digestEntry.Name() == storedDigest.String() {
+				continue
+			}
+			info, err := digestEntry.Info()
+			if err != nil {
+				return nil, err
+			}
+			path := filepath.Join(catalogPath, digestEntry.Name())
+			size, err := dirSize(path)
+			if err != nil {
+				return nil, err
+			}
+			images = append(images, unpackedImage{
+				catalog: catalogEntry.Name(),
+				path:    path,
+				size:    size,
+				modTime: info.ModTime(),
+				old:     storedDigest != "" && digestEntry.Name() != storedDigest.String(),
+			})
+		}
+	}
+	slices.SortFunc(images, func(a, b unpackedImage) int {
+		if a.old != b.old {
+			if a.old {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(a.modTime.Compare(b.modTime), cmp.Compare(a.path, b.path))
+	})
+	return images, nil
+}
+
+func totalSize(paths []string) (int64, error) {
+	var total int64
+	for _, path := range paths {
+		size, err := dirSize(path)
+		if err != nil {
+			return 0, err
+		}
+		total += size
+	}
+	return total, nil
+}
+
+// dirSize returns the total size of the regular files in the directory tree at path.
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Entries may be removed concurrently.
+			return nil
+		}
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
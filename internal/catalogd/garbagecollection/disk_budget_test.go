@@ -0,0 +1,172 @@
+package garbagecollection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fsutil "github.com/operator-framework/operator-controller/internal/shared/util/fs"
+)
+
+var (
+	oldDigest     = digest.FromString("old")
+	currentDigest = digest.FromString("current")
+	pendingDigest = digest.FromString("pending")
+)
+
+// writeUnpackedImage writes an unpacked image of size bytes, made read-only like the
+// unpack cache does, that was last modified at modTime.
+func writeUnpackedImage(t *testing.T, unpackPath, catalog string, dgst digest.Digest, size int, modTime time.Time) string {
+	t.Helper()
+	path := filepath.Join(unpackPath, catalog, dgst.String())
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "configs"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "configs", "catalog.json"), make([]byte, size), 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	require.NoError(t, fsutil.SetReadOnlyRecursive(path))
+	t.Cleanup(func() { _ = fsutil.SetWritableRecursive(path) })
+	return path
+}
+
+func TestEnforceDiskBudget(t *testing.T) {
+	now := time.Now()
+	storedDigests := map[string]digest.Digest{
+		"one": currentDigest,
+		"two": currentDigest,
+	}
+
+	for _, tc := range []struct {
+		name            string
+		maxBytes        int64
+		minFreeBytes    int64
+		unpacking       []string
+		expectedEvicted []string
+	}{
+		{
+			name:     "within budget",
+			maxBytes: 1000,
+		},
+		{
+			name:            "old digests are evicted first",
+			maxBytes:        350,
+			expectedEvicted: []string{"one/" + oldDigest.String()},
+		},
+		{
+			name:     "images of catalogs without stored content are evicted next",
+			maxBytes: 250,
+			expectedEvicted: []string{
+				"one/" + oldDigest.String(),
+				"three/" + pendingDigest.String(),
+			},
+		},
+		{
+			name:     "images of stored digests are never evicted",
+			maxBytes: 1,
+			expectedEvicted: []string{
+				"one/" + oldDigest.String(),
+				"three/" + pendingDigest.String(),
+			},
+		},
+		{
+			name:            "images of catalogs being unpacked are never evicted",
+			maxBytes:        1,
+			unpacking:       []string{"three"},
+			expectedEvicted: []string{"one/" + oldDigest.String()},
+		},
+		{
+			name:         "free space below minimum",
+			minFreeBytes: 1 << 62,
+			expectedEvicted: []string{
+				"one/" + oldDigest.String(),
+				"three/" + pendingDigest.String(),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			unpackPath := t.TempDir()
+			storagePath := t.TempDir()
+			writeUnpackedImage(t, unpackPath, "one", oldDigest, 100, now.Add(-time.Minute))
+			writeUnpackedImage(t, unpackPath, "one", currentDigest, 100, now.Add(-2*time.Hour))
+			writeUnpackedImage(t, unpackPath, "two", currentDigest, 100, now.Add(-3*time.Hour))
+			// A catalog without stored content, e.g. because it is being stored.
+			writeUnpackedImage(t, unpackPath, "three", pendingDigest, 100, now.Add(-time.Hour))
+			require.NoError(t, os.MkdirAll(filepath.Join(storagePath, "one"), 0700))
+			require.NoError(t, os.WriteFile(filepath.Join(storagePath, "one", "catalog.jsonl"), make([]byte, 50), 0600))
+
+			gc := &GarbageCollector{
+				CachePaths: []string{unpackPath, storagePath},
+				Logger:     logr.Discard(),
+				DiskBudget: &DiskBudget{
+					UnpackCachePath: unpackPath,
+					StoredDigest: func(catalog string) (digest.Digest, error) {
+						return storedDigests[catalog], nil
+					},
+					MaxBytes:     tc.maxBytes,
+					MinFreeBytes: tc.minFreeBytes,
+					Unpacks:      NewUnpackTracker(),
+				},
+			}
+			for _, catalog := range tc.unpacking {
+				defer gc.DiskBudget.Unpacks.Begin(catalog)()
+			}
+			evicted, err := gc.enforceDiskBudget()
+			require.NoError(t, err)
+
+			var expectedEvicted []string
+			for _, rel := range tc.expectedEvicted {
+				path := filepath.Join(unpackPath, rel)
+				expectedEvicted = append(expectedEvicted, path)
+				assert.NoDirExists(t, path)
+			}
+			require.Equal(t, expectedEvicted, evicted)
+			assert.FileExists(t, filepath.Join(storagePath, "one", "catalog.jsonl"))
+		})
+	}
+}
+
+func TestEnforceDiskBudgetCurrentContentOnly(t *testing.T) {
+	unpackPath := t.TempDir()
+	current := []string{
+		writeUnpackedImage(t, unpackPath, "one", currentDigest, 100, time.Now().Add(-time.Hour)),
+		writeUnpackedImage(t, unpackPath, "two", currentDigest, 100, time.Now()),
+	}
+	gc := &GarbageCollector{
+		CachePaths: []string{unpackPath},
+		Logger:     logr.Discard(),
+		DiskBudget: &DiskBudget{
+			UnpackCachePath: unpackPath,
+			StoredDigest:    func(string) (digest.Digest, error) { return currentDigest, nil },
+			MaxBytes:        1,
+			Unpacks:         NewUnpackTracker(),
+		},
+	}
+
+	evicted, err := gc.enforceDiskBudget()
+	require.NoError(t, err)
+	require.Empty(t, evicted)
+	for _, path := range current {
+		assert.DirExists(t, path)
+	}
+}
+
+func TestUnpackTracker(t *testing.T) {
+	tracker := NewUnpackTracker()
+	evict := func() bool {
+		ok, err := tracker.evict("one", func() error { return nil })
+		require.NoError(t, err)
+		return ok
+	}
+
+	doneFirst := tracker.Begin("one")
+	doneSecond := tracker.Begin("one")
+	require.False(t, evict())
+	doneFirst()
+	require.False(t, evict())
+	doneSecond()
+	require.True(t, evict())
+}
@@ -0,0 +1,12 @@
+//go:build !(linux || darwin)
+
+package garbagecollection
+
+import (
+	"errors"
+)
+
+// freeSpace is not supported on this platform, so minimum free space is not enforced.
+func freeSpace(_ string) (int64, error) {
+	return 0, errors.ErrUnsupported
+}
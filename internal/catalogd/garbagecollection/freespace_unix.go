@@ -0,0 +1,17 @@
+//go:build linux || darwin
+
+package garbagecollection
+
+import (
+	"syscall"
+)
+
+// freeSpace returns the number of bytes available to unprivileged users on the
+// filesystem containing path.
+func freeSpace(path string) (int64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return int64(st.Bavail) * int64(st.Bsize), nil //nolint:gosec // block counts and sizes of real filesystems fit in an int64
+}
@@ -14,9 +14,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
 )
 
-var _ manager.Runnable = (*GarbageCollector)(nil)
+var (
+	_ manager.Runnable               = (*GarbageCollector)(nil)
+	_ manager.LeaderElectionRunnable = (*GarbageCollector)(nil)
+)
 
 // GarbageCollector is an implementation of the manager.Runnable
 // interface for running garbage collection on the Catalog content
@@ -24,24 +29,46 @@ var _ manager.Runnable = (*GarbageCollector)(nil)
 // and will ensure that no cache entries exist for Catalog resources
 // that no longer exist. This should only clean up cache entries that
 // were missed by the handling of a DELETE event on a Catalog resource.
+// When a DiskBudget is set, it also evicts unpacked images to keep the
+// caches within the budget.
 type GarbageCollector struct {
 	// CachePaths is the list of directories to garbage-collect. Each directory
-	// is expected to contain only subdirectories named after existing ClusterCatalogs;
-	// any entry whose name does not match a known ClusterCatalog — including orphaned
-	// temporary directories left by interrupted operations — is removed.
+	// is expected to contain only subdirectories named after existing ClusterCatalogs,
+	// or after the keys of existing namespaced Catalogs; any other entry — including
+	// orphaned temporary directories left by interrupted operations — is removed.
 	CachePaths     []string
 	Logger         logr.Logger
 	MetadataClient metadata.Interface
 	Interval       time.Duration
+	// NamespacedCatalogs enables keeping the cache entries of namespaced Catalogs.
+	// When it is disabled, they are removed like those of deleted ClusterCatalogs.
+	NamespacedCatalogs bool
+	// DiskBudget optionally limits the disk space used by CachePaths.
+	DiskBudget *DiskBudget
 }
 
+// NeedLeaderElection returns false, as every catalogd replica caches and stores
+// catalog content on its own disk.
+func (gc *GarbageCollector) NeedLeaderElection() bool { return false }
+
 // Start will start the garbage collector. It will always run once on startup
 // and loop until context is canceled after an initial garbage collection run.
 // Garbage collection will run again every X amount of time, where X is the
-// supplied garbage collection interval.
+// supplied garbage collection interval, and whenever free space drops below
+// the minimum free space of the disk budget, if any.
 func (gc *GarbageCollector) Start(ctx context.Context) error {
 	// Run once on startup
-	gc.runAndLog(ctx)
+	gc.run(ctx, catalogdmetrics.GCTriggerInterval)
+
+	interval := time.NewTicker(gc.Interval)
+	defer interval.Stop()
+
+	var freeSpaceCheck <-chan time.Time
+	if gc.DiskBudget != nil && gc.DiskBudget.MinFreeBytes > 0 && gc.DiskBudget.FreeSpaceCheckInterval > 0 {
+		ticker := time.NewTicker(gc.DiskBudget.FreeSpaceCheckInterval)
+		defer ticker.Stop()
+		freeSpaceCheck = ticker.C
+	}
 
 	// Loop until context is canceled, running garbage collection
 	// at the configured interval
@@ -49,15 +76,30 @@ func (gc *GarbageCollector) Start(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(gc.Interval):
-			gc.runAndLog(ctx)
+		case <-interval.C:
+			gc.run(ctx, catalogdmetrics.GCTriggerInterval)
+		case <-freeSpaceCheck:
+			free, err := freeSpace(gc.DiskBudget.UnpackCachePath)
+			if err != nil {
+				gc.Logger.Error(err, "checking free space", "path", gc.DiskBudget.UnpackCachePath)
+				continue
+			}
+			if free < gc.DiskBudget.MinFreeBytes {
+				gc.Logger.Info("free space is below minimum, running garbage collection", "free", free, "minFreeBytes", gc.DiskBudget.MinFreeBytes)
+				gc.run(ctx, catalogdmetrics.GCTriggerLowFreeSpace)
+			}
 		}
 	}
 }
 
+func (gc *GarbageCollector) run(ctx context.Context, trigger string) {
+	catalogdmetrics.GCRunsMetric.WithLabelValues(trigger).Inc()
+	gc.runAndLog(ctx)
+}
+
 func (gc *GarbageCollector) runAndLog(ctx context.Context) {
 	for _, path := range gc.CachePaths {
-		removed, err := runGarbageCollection(ctx, path, gc.MetadataClient)
+		removed, err := runGarbageCollection(ctx, path, gc.MetadataClient, gc.NamespacedCatalogs)
 		if err != nil {
 			gc.Logger.Error(err, "running garbage collection", "path", path)
 		}
@@ -65,9 +107,18 @@ func (gc *GarbageCollector) runAndLog(ctx context.Context) {
 			gc.Logger.Info("removed stale cache entries", "path", path, "removed entries", removed)
 		}
 	}
+	if gc.DiskBudget != nil {
+		evicted, err := gc.enforceDiskBudget()
+		if err != nil {
+			gc.Logger.Error(err, "enforcing disk budget")
+		}
+		if len(evicted) > 0 {
+			gc.Logger.Info("evicted unpacked images to enforce disk budget", "evicted images", evicted)
+		}
+	}
 }
 
-func runGarbageCollection(ctx context.Context, cachePath string, metaClient metadata.Interface) ([]string, error) {
+func runGarbageCollection(ctx context.Context, cachePath string, metaClient metadata.Interface, namespacedCatalogs bool) ([]string, error) {
 	getter := metaClient.Resource(ocv1.GroupVersion.WithResource("clustercatalogs"))
 	metaList, err := getter.List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -79,6 +130,16 @@ func runGarbageCollection(ctx context.Context, cachePath string, metaClient meta
 		expectedCatalogs.Insert(meta.GetName())
 	}
 
+	if namespacedCatalogs {
+		metaList, err := metaClient.Resource(ocv1.GroupVersion.WithResource("catalogs")).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error listing catalogs: %w", err)
+		}
+		for _, meta := range metaList.Items {
+			expectedCatalogs.Insert(catalogutil.NamespacedKey(meta.GetNamespace(), meta.GetName()))
+		}
+	}
+
 	cacheDirEntries, err := os.ReadDir(cachePath)
 	if err != nil {
 		return nil, fmt.Errorf("error reading cache directory: %w", err)
@@ -89,9 +150,15 @@ func runGarbageCollection(ctx context.Context, cachePath string, metaClient meta
 		if cacheDirEntry.IsDir() && expectedCatalogs.Has(cacheDirEntry.Name()) {
 			continue
 		}
-		if err := os.RemoveAll(filepath.Join(cachePath, cacheDirEntry.Name())); err != nil {
+		entryPath := filepath.Join(cachePath, cacheDirEntry.Name())
+		size, err := dirSize(entryPath)
+		if err != nil {
+			return nil, fmt.Errorf("error determining size of cache directory entry %q: %w", cacheDirEntry.Name(), err)
+		}
+		if err := os.RemoveAll(entryPath); err != nil {
 			return nil, fmt.Errorf("error removing cache directory entry %q: %w  ", cacheDirEntry.Name(), err)
 		}
+		catalogdmetrics.GCReclaimedBytesMetric.WithLabelValues(catalogdmetrics.GCReasonOrphaned).Add(float64(size))
 
 		removed = append(removed, cacheDirEntry.Name())
 	}
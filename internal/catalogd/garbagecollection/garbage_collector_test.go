@@ -60,6 +60,50 @@ func TestGarbageCollectorStoragePath(t *testing.T) {
 	assert.Equal(t, existingCatalog.Name, entries[0].Name())
 }
 
+func TestRunGarbageCollectionNamespacedCatalogs(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, metav1.AddMetaToScheme(scheme))
+
+	clusterCatalog := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterCatalog", APIVersion: ocv1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "one"},
+	}
+	namespacedCatalog := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{Kind: "Catalog", APIVersion: ocv1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "one"},
+	}
+	metaClient := fake.NewSimpleMetadataClient(scheme, clusterCatalog, namespacedCatalog)
+
+	for _, tc := range []struct {
+		name               string
+		namespacedCatalogs bool
+		expectedRemoved    []string
+	}{
+		{
+			name:               "entries of existing namespaced catalogs are kept",
+			namespacedCatalogs: true,
+			expectedRemoved:    []string{"team-b_one"},
+		},
+		{
+			name:            "entries of namespaced catalogs are removed when they are disabled",
+			expectedRemoved: []string{"team-a_one", "team-b_one"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cachePath := t.TempDir()
+			for _, entry := range []string{"one", "team-a_one", "team-b_one"} {
+				require.NoError(t, os.MkdirAll(filepath.Join(cachePath, entry, "fakedigest"), 0700))
+			}
+
+			removed, err := runGarbageCollection(ctx, cachePath, metaClient, tc.namespacedCatalogs)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.expectedRemoved, removed)
+			assert.DirExists(t, filepath.Join(cachePath, "one"))
+		})
+	}
+}
+
 func TestRunGarbageCollection(t *testing.T) {
 	for _, tt := range []struct {
 		name             string
@@ -120,7 +164,7 @@ func TestRunGarbageCollection(t *testing.T) {
 
 			metaClient := fake.NewSimpleMetadataClient(scheme, runtimeObjs...)
 
-			_, err := runGarbageCollection(ctx, cachePath, metaClient)
+			_, err := runGarbageCollection(ctx, cachePath, metaClient, false)
 			if !tt.wantErr {
 				require.NoError(t, err)
 				entries, err := os.ReadDir(cachePath)
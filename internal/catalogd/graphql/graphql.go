@@ -45,6 +45,24 @@ type CatalogSchema struct {
 	Schemas map[string]*SchemaInfo // schema name -> info
 }
 
+// FieldCount returns the number of fields of all schemas, including the fields of
+// nested objects.
+func (c *CatalogSchema) FieldCount() int {
+	n := 0
+	for _, info := range c.Schemas {
+		n += countFields(info.Fields)
+	}
+	return n
+}
+
+func countFields(fields map[string]*FieldInfo) int {
+	n := len(fields)
+	for _, f := range fields {
+		n += countFields(f.NestedFields)
+	}
+	return n
+}
+
 // DynamicSchema holds the generated GraphQL schema and metadata
 type DynamicSchema struct {
 	Schema        graphql.Schema
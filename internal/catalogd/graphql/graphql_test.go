@@ -379,3 +379,26 @@ func TestIntegerTypeDetection(t *testing.T) {
 		t.Error("floatArray not found")
 	}
 }
+
+func TestCatalogSchemaFieldCount(t *testing.T) {
+	schema := &CatalogSchema{Schemas: map[string]*SchemaInfo{
+		declcfg.SchemaPackage: {Fields: map[string]*FieldInfo{
+			"name":   {Name: "name"},
+			"schema": {Name: "schema"},
+		}},
+		declcfg.SchemaChannel: {Fields: map[string]*FieldInfo{
+			"name": {Name: "name"},
+			"entries": {Name: "entries", IsArray: true, NestedFields: map[string]*FieldInfo{
+				"name":     {Name: "name"},
+				"replaces": {Name: "replaces"},
+			}},
+		}},
+	}}
+
+	if got := schema.FieldCount(); got != 6 {
+		t.Errorf("expected 6 fields, got %d", got)
+	}
+	if got := (&CatalogSchema{}).FieldCount(); got != 0 {
+		t.Errorf("expected 0 fields for an empty schema, got %d", got)
+	}
+}
@@ -0,0 +1,245 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
+)
+
+// Endpoints of the catalog content API, used as the endpoint label of the
+// per-catalog request metrics.
+const (
+	EndpointAll     = "all"
+	EndpointMetas   = "metas"
+	EndpointGraphQL = "graphql"
+)
+
+const (
+	catalogLabel  = "catalog"
+	endpointLabel = "endpoint"
+	codeLabel     = "code"
+)
+
+// Per-catalog metrics. Unpack durations, poll timestamps and failures are only recorded
+// by the catalogd replica that is the leader, as only it resolves catalogs. Pulled bytes,
+// content and request metrics are recorded by every replica, for the images it pulls
+// and the content it stores and serves.
+//
+// Alerting on stale or broken catalogs is typically done using
+// catalogd_catalog_consecutive_unpack_failures, and the age of
+// catalogd_catalog_last_successful_poll_timestamp_seconds relative to the poll interval
+// of the catalog.
+var (
+	CatalogUnpackDurationMetric = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "catalogd_catalog_unpack_duration_seconds",
+			Help: "Histogram of the duration in seconds of successful unpacks of catalog content, from pulling the image to storing its content",
+			// Unpacks range from sub-second for cached, small catalogs to minutes for
+			// large catalogs pulled from slow registries.
+			Buckets: []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+		},
+		[]string{catalogLabel},
+	)
+	CatalogImagePullBytesMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "catalogd_catalog_image_pull_bytes_total",
+			Help: "Total number of bytes of catalog images pulled from registries",
+		},
+		[]string{catalogLabel},
+	)
+	CatalogContentSizeMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "catalogd_catalog_content_size_bytes",
+			Help: "Size in bytes of the uncompressed stored content of catalogs",
+		},
+		[]string{catalogLabel},
+	)
+	CatalogPackagesMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "catalogd_catalog_packages",
+			Help: "Number of packages in the stored content of catalogs",
+		},
+		[]string{catalogLabel},
+	)
+	CatalogChannelsMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "catalogd_catalog_channels",
+			Help: "Number of channels in the stored content of catalogs",
+		},
+		[]string{catalogLabel},
+	)
+	CatalogBundlesMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "catalogd_catalog_bundles",
+			Help: "Number of bundles in the stored content of catalogs",
+		},
+		[]string{catalogLabel},
+	)
+	CatalogIndexBuildDurationMetric = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "catalogd_catalog_index_build_duration_seconds",
+			Help:    "Histogram of the duration in seconds of building the metas index of catalogs",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{catalogLabel},
+	)
+	CatalogLastSuccessfulPollMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "catalogd_catalog_last_successful_poll_timestamp_seconds",
+			Help: "Unix timestamp in seconds of the last successful unpack of catalogs",
+		},
+		[]string{catalogLabel},
+	)
+	CatalogConsecutiveUnpackFailuresMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "catalogd_catalog_consecutive_unpack_failures",
+			Help: "Number of consecutive failed attempts to unpack catalogs since their last successful unpack",
+		},
+		[]string{catalogLabel},
+	)
+	CatalogRequestsMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "catalogd_catalog_http_requests_total",
+			Help: "Total number of requests for catalog content, by catalog, endpoint and response code",
+		},
+		[]string{catalogLabel, endpointLabel, codeLabel},
+	)
+	CatalogRequestDurationMetric = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "catalogd_catalog_http_request_duration_seconds",
+			Help: "Histogram of the duration in seconds of requests for catalog content, by catalog and endpoint",
+			// Matches the buckets of catalogd_http_request_duration_seconds, so that
+			// Apdex Scores can be calculated per catalog.
+			Buckets: []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1, 1.2, 1.6, 2, 2.4, 2.8, 3.2, 3.6, 4, 10},
+		},
+		[]string{catalogLabel, endpointLabel},
+	)
+)
+
+// CatalogCollectors returns the collectors of all per-catalog metrics.
+func CatalogCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		CatalogUnpackDurationMetric,
+		CatalogImagePullBytesMetric,
+		CatalogContentSizeMetric,
+		CatalogPackagesMetric,
+		CatalogChannelsMetric,
+		CatalogBundlesMetric,
+		CatalogIndexBuildDurationMetric,
+		CatalogLastSuccessfulPollMetric,
+		CatalogConsecutiveUnpackFailuresMetric,
+		CatalogRequestsMetric,
+		CatalogRequestDurationMetric,
+	}
+}
+
+// RecordUnpackSuccess records a successful unpack of a catalog that took duration and
+// completed at pollTime.
+func RecordUnpackSuccess(catalog string, duration time.Duration, pollTime time.Time) {
+	CatalogUnpackDurationMetric.WithLabelValues(catalog).Observe(duration.Seconds())
+	CatalogLastSuccessfulPollMetric.WithLabelValues(catalog).Set(float64(pollTime.UnixNano()) / float64(time.Second))
+	CatalogConsecutiveUnpackFailuresMetric.WithLabelValues(catalog).Set(0)
+}
+
+// RecordUnpackFailure records a failed attempt to unpack a catalog.
+func RecordUnpackFailure(catalog string) {
+	CatalogConsecutiveUnpackFailuresMetric.WithLabelValues(catalog).Inc()
+}
+
+// RecordImagePull records the number of bytes pulled for the image of a catalog.
+func RecordImagePull(catalog string, bytes int64) {
+	CatalogImagePullBytesMetric.WithLabelValues(catalog).Add(float64(bytes))
+}
+
+// CatalogContentStats describes the stored content of a catalog.
+type CatalogContentStats struct {
+	SizeBytes int64
+	Packages  int
+	Channels  int
+	Bundles   int
+}
+
+// RecordCatalogContent records the stats of the stored content of a catalog.
+func RecordCatalogContent(catalog string, stats CatalogContentStats) {
+	CatalogContentSizeMetric.WithLabelValues(catalog).Set(float64(stats.SizeBytes))
+	CatalogPackagesMetric.WithLabelValues(catalog).Set(float64(stats.Packages))
+	CatalogChannelsMetric.WithLabelValues(catalog).Set(float64(stats.Channels))
+	CatalogBundlesMetric.WithLabelValues(catalog).Set(float64(stats.Bundles))
+}
+
+// RecordIndexBuild records the duration of building the metas index of a catalog.
+func RecordIndexBuild(catalog string, duration time.Duration) {
+	CatalogIndexBuildDurationMetric.WithLabelValues(catalog).Observe(duration.Seconds())
+}
+
+// DeleteCatalog removes all per-catalog metrics of a catalog, so that deleted catalogs
+// are not reported anymore.
+func DeleteCatalog(catalog string) {
+	labels := prometheus.Labels{catalogLabel: catalog}
+	for _, c := range CatalogCollectors() {
+		switch vec := c.(type) {
+		case *prometheus.HistogramVec:
+			vec.DeletePartialMatch(labels)
+		case *prometheus.CounterVec:
+			vec.DeletePartialMatch(labels)
+		case *prometheus.GaugeVec:
+			vec.DeletePartialMatch(labels)
+		}
+	}
+}
+
+// InstrumentCatalogHandler records the per-catalog request metrics of requests to the
+// given endpoint of the catalog content API. The catalog is taken from the catalog path
+// value of the request, and combined with its namespace path value into the key of a
+// namespaced Catalog.
+//
+// Requests that fail with a client error are recorded without a catalog, as the catalog
+// they name may not exist, and recording it would allow clients to create an unbounded
+// number of series.
+func InstrumentCatalogHandler(endpoint string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		handler.ServeHTTP(rw, r)
+
+		catalog := r.PathValue(catalogLabel)
+		if namespace := r.PathValue("namespace"); namespace != "" {
+			catalog = catalogutil.NamespacedKey(namespace, catalog)
+		}
+		if rw.code >= 400 && rw.code < 500 {
+			catalog = ""
+		}
+		CatalogRequestsMetric.WithLabelValues(catalog, endpoint, strconv.Itoa(rw.code)).Inc()
+		CatalogRequestDurationMetric.WithLabelValues(catalog, endpoint).Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder records the status code written to a ResponseWriter.
+type statusRecorder struct {
+	http.ResponseWriter
+	code        int
+	wroteHeader bool
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.code = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap allows http.ResponseController to reach the wrapped ResponseWriter, e.g. to
+// flush streamed responses.
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
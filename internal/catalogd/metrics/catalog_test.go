@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+// value returns the value of a counter or gauge.
+func value(t *testing.T, m prometheus.Metric) float64 {
+	t.Helper()
+	var out dto.Metric
+	require.NoError(t, m.Write(&out))
+	if out.Counter != nil {
+		return out.Counter.GetValue()
+	}
+	return out.Gauge.GetValue()
+}
+
+// count returns the number of series collected from c.
+func count(t *testing.T, c prometheus.Collector) int {
+	t.Helper()
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	n := 0
+	for range ch {
+		n++
+	}
+	return n
+}
+
+func TestInstrumentCatalogHandler(t *testing.T) {
+	t.Cleanup(func() {
+		CatalogRequestsMetric.Reset()
+		CatalogRequestDurationMetric.Reset()
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/catalogs/{catalog}/api/v1/all", InstrumentCatalogHandler(EndpointAll, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("catalog") == "missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	})))
+
+	for _, path := range []string{
+		"/catalogs/operatorhubio/api/v1/all",
+		"/catalogs/operatorhubio/api/v1/all",
+		"/catalogs/missing/api/v1/all",
+	} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.InDelta(t, 2, value(t, CatalogRequestsMetric.WithLabelValues("operatorhubio", EndpointAll, "200")), 0)
+	require.InDelta(t, 1, value(t, CatalogRequestsMetric.WithLabelValues("", EndpointAll, "404")), 0)
+	require.Equal(t, 2, count(t, CatalogRequestsMetric))
+	require.Equal(t, 2, count(t, CatalogRequestDurationMetric))
+}
+
+func TestRecordUnpack(t *testing.T) {
+	t.Cleanup(func() {
+		DeleteCatalog("operatorhubio")
+	})
+
+	RecordUnpackFailure("operatorhubio")
+	RecordUnpackFailure("operatorhubio")
+	require.InDelta(t, 2, value(t, CatalogConsecutiveUnpackFailuresMetric.WithLabelValues("operatorhubio")), 0)
+
+	pollTime := time.Unix(1792397524, 500000000)
+	RecordUnpackSuccess("operatorhubio", 3*time.Second, pollTime)
+	require.InDelta(t, 0, value(t, CatalogConsecutiveUnpackFailuresMetric.WithLabelValues("operatorhubio")), 0)
+	require.InDelta(t, 1792397524.5, value(t, CatalogLastSuccessfulPollMetric.WithLabelValues("operatorhubio")), 0)
+}
+
+func TestDeleteCatalog(t *testing.T) {
+	t.Cleanup(func() {
+		DeleteCatalog("operatorhubio")
+		DeleteCatalog("other")
+	})
+
+	for _, catalog := range []string{"operatorhubio", "other"} {
+		RecordCatalogContent(catalog, CatalogContentStats{SizeBytes: 1024, Packages: 1, Channels: 2, Bundles: 3})
+		RecordImagePull(catalog, 2048)
+		CatalogRequestsMetric.WithLabelValues(catalog, EndpointMetas, "200").Inc()
+	}
+
+	DeleteCatalog("operatorhubio")
+
+	require.Equal(t, 1, count(t, CatalogContentSizeMetric))
+	require.Equal(t, 1, count(t, CatalogImagePullBytesMetric))
+	require.Equal(t, 1, count(t, CatalogRequestsMetric))
+	require.InDelta(t, 3, value(t, CatalogBundlesMetric.WithLabelValues("other")), 0)
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons for which the garbage collector reclaims disk space, used as the reason
+// label of GCReclaimedBytesMetric.
+const (
+	// GCReasonOrphaned is the removal of cache entries of catalogs that no longer exist.
+	GCReasonOrphaned = "orphaned"
+	// GCReasonOldDigest is the eviction of unpacked images of catalogs whose content has
+	// since been stored from another image digest.
+	GCReasonOldDigest = "old_digest"
+	// GCReasonDiskBudget is the eviction of unpacked images of the stored content of
+	// catalogs, which are only needed to avoid pulling the images again.
+	GCReasonDiskBudget = "disk_budget"
+)
+
+// Triggers of garbage collection runs, used as the trigger label of GCRunsMetric.
+const (
+	GCTriggerInterval     = "interval"
+	GCTriggerLowFreeSpace = "low_free_space"
+)
+
+var (
+	GCReclaimedBytesMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "catalogd_gc_reclaimed_bytes_total",
+			Help: "Total number of bytes reclaimed by the garbage collection of catalogd caches, by reason",
+		},
+		[]string{"reason"},
+	)
+	GCRunsMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "catalogd_gc_runs_total",
+			Help: "Total number of garbage collection runs of catalogd caches, by trigger",
+		},
+		[]string{"trigger"},
+	)
+	GCDiskUsageMetric = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "catalogd_gc_disk_usage_bytes",
+			Help: "Number of bytes used by catalogd caches after the last garbage collection run",
+		},
+	)
+)
+
+// GCCollectors returns the collectors of all garbage collection metrics.
+func GCCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		GCReclaimedBytesMetric,
+		GCRunsMetric,
+		GCDiskUsageMetric,
+	}
+}
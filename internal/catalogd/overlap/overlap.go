@@ -0,0 +1,86 @@
+// Package overlap detects packages that are provided by more than one ClusterCatalog
+// of the same priority. Resolution fails for such packages, as it cannot choose
+// between the catalogs that provide them.
+package overlap
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// maxPackagesPerCatalog is the number of overlapping packages listed for each catalog
+// in a message, to keep conditions and admission warnings readable.
+const maxPackagesPerCatalog = 10
+
+// PackageLister returns the names of the packages of the stored content of a catalog.
+type PackageLister interface {
+	GetPackageNames(catalog string) ([]string, error)
+}
+
+// Overlap lists the packages that a catalog shares with another catalog.
+type Overlap struct {
+	Catalog  string
+	Packages []string
+}
+
+// IsServing reports whether the content of a ClusterCatalog is served, and is
+// therefore considered during resolution.
+func IsServing(catalog *ocv1.ClusterCatalog) bool {
+	return catalog.Spec.AvailabilityMode != ocv1.AvailabilityModeUnavailable &&
+		meta.IsStatusConditionTrue(catalog.Status.Conditions, ocv1.TypeServing)
+}
+
+// Find returns the overlaps of the given packages of a catalog with the serving
+// catalogs among catalogs that have the given priority, sorted by catalog name. The
+// catalog itself is skipped, as are catalogs whose content is not stored.
+func Find(lister PackageLister, catalog string, priority int32, packages []string, catalogs []ocv1.ClusterCatalog) ([]Overlap, error) {
+	if len(packages) == 0 {
+		return nil, nil
+	}
+	packageSet := sets.New(packages...)
+
+	var overlaps []Overlap
+	for i := range catalogs {
+		other := &catalogs[i]
+		if other.Name == catalog || other.Spec.Priority != priority || !IsServing(other) {
+			continue
+		}
+		otherPackages, err := lister.GetPackageNames(other.Name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting packages of catalog %q: %w", other.Name, err)
+		}
+		if shared := packageSet.Intersection(sets.New(otherPackages...)); shared.Len() > 0 {
+			overlaps = append(overlaps, Overlap{Catalog: other.Name, Packages: sets.List(shared)})
+		}
+	}
+	slices.SortFunc(overlaps, func(a, b Overlap) int {
+		return strings.Compare(a.Catalog, b.Catalog)
+	})
+	return overlaps, nil
+}
+
+// Message describes overlaps with catalogs of the given priority.
+func Message(priority int32, overlaps []Overlap) string {
+	descriptions := make([]string, 0, len(overlaps))
+	for _, o := range overlaps {
+		packages := o.Packages
+		var more string
+		if len(packages) > maxPackagesPerCatalog {
+			more = fmt.Sprintf(" and %d more", len(packages)-maxPackagesPerCatalog)
+			packages = packages[:maxPackagesPerCatalog]
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%q (%s%s)", o.Catalog, strings.Join(packages, ", "), more))
+	}
+	return fmt.Sprintf("packages are also provided by ClusterCatalogs with priority %d, which makes their resolution ambiguous: %s", priority, strings.Join(descriptions, "; "))
+}
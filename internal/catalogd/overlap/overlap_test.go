@@ -0,0 +1,87 @@
+package overlap
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+type packageLister map[string][]string
+
+func (l packageLister) GetPackageNames(catalog string) ([]string, error) {
+	names, ok := l[catalog]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return names, nil
+}
+
+func servedCatalog(name string, priority int32) ocv1.ClusterCatalog {
+	return ocv1.ClusterCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ocv1.ClusterCatalogSpec{
+			Priority:         priority,
+			AvailabilityMode: ocv1.AvailabilityModeAvailable,
+		},
+		Status: ocv1.ClusterCatalogStatus{
+			Conditions: []metav1.Condition{{Type: ocv1.TypeServing, Status: metav1.ConditionTrue, Reason: ocv1.ReasonAvailable}},
+		},
+	}
+}
+
+func TestFind(t *testing.T) {
+	lister := packageLister{
+		"self":        {"a", "b", "c"},
+		"same":        {"b", "c", "d"},
+		"also-same":   {"a"},
+		"disjoint":    {"x"},
+		"other-prio":  {"a", "b"},
+		"unavailable": {"a"},
+		"not-serving": {"a"},
+	}
+	unavailable := servedCatalog("unavailable", 0)
+	unavailable.Spec.AvailabilityMode = ocv1.AvailabilityModeUnavailable
+	notServing := servedCatalog("not-serving", 0)
+	notServing.Status.Conditions = nil
+	catalogs := []ocv1.ClusterCatalog{
+		servedCatalog("self", 0),
+		servedCatalog("same", 0),
+		servedCatalog("also-same", 0),
+		servedCatalog("disjoint", 0),
+		servedCatalog("not-stored", 0),
+		servedCatalog("other-prio", 1),
+		unavailable,
+		notServing,
+	}
+
+	overlaps, err := Find(lister, "self", 0, lister["self"], catalogs)
+	require.NoError(t, err)
+	require.Equal(t, []Overlap{
+		{Catalog: "also-same", Packages: []string{"a"}},
+		{Catalog: "same", Packages: []string{"b", "c"}},
+	}, overlaps)
+
+	overlaps, err = Find(lister, "self", 1, lister["self"], catalogs)
+	require.NoError(t, err)
+	require.Equal(t, []Overlap{{Catalog: "other-prio", Packages: []string{"a", "b"}}}, overlaps)
+
+	overlaps, err = Find(lister, "self", 2, lister["self"], catalogs)
+	require.NoError(t, err)
+	require.Empty(t, overlaps)
+}
+
+func TestMessage(t *testing.T) {
+	var many []string
+	for i := range 12 {
+		many = append(many, fmt.Sprintf("p%02d", i))
+	}
+	require.Equal(t,
+		`packages are also provided by ClusterCatalogs with priority 5, which makes their resolution ambiguous: "a" (foo, bar); "b" (p00, p01, p02, p03, p04, p05, p06, p07, p08, p09 and 2 more)`,
+		Message(5, []Overlap{{Catalog: "a", Packages: []string{"foo", "bar"}}, {Catalog: "b", Packages: many}}),
+	)
+}
@@ -0,0 +1,150 @@
+package registrywebhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.podman.io/image/v5/docker/reference"
+)
+
+// A PushEvent is the push of one or more tags to a repository, as notified by a
+// registry.
+type PushEvent struct {
+	// Repository is the fully qualified name of the repository, e.g. quay.io/operatorhubio/catalog.
+	Repository string
+	// Tags are the tags that were pushed.
+	Tags []string
+}
+
+// The shapes of the notification payloads sent by supported registries, reduced to the
+// fields needed to identify pushed tags.
+type notificationPayload struct {
+	// Docker distribution (and registries based on it) send envelopes of events.
+	// See https://distribution.github.io/distribution/about/notifications/
+	Events []distributionEvent `json:"events"`
+
+	// Harbor sends a single event of a type, with type specific data.
+	// See https://goharbor.io/docs/main/working-with-projects/project-configuration/configure-webhooks/
+	Type      string           `json:"type"`
+	EventData *harborEventData `json:"event_data"`
+
+	// Quay sends repository push notifications for a single repository.
+	// See https://docs.quay.io/guides/notifications.html
+	DockerURL   string   `json:"docker_url"`
+	UpdatedTags []string `json:"updated_tags"`
+}
+
+type distributionEvent struct {
+	Action string `json:"action"`
+	Target struct {
+		Repository string `json:"repository"`
+		Tag        string `json:"tag"`
+		URL        string `json:"url"`
+	} `json:"target"`
+	Request struct {
+		Host string `json:"host"`
+	} `json:"request"`
+}
+
+type harborEventData struct {
+	Resources []struct {
+		Tag         string `json:"tag"`
+		ResourceURL string `json:"resource_url"`
+	} `json:"resources"`
+}
+
+const harborPushArtifact = "PUSH_ARTIFACT"
+
+// ParsePushEvents parses a notification payload sent by a Docker distribution, Harbor
+// or Quay registry, and returns the pushes of tags it notifies. Notifications of other
+// events, such as pulls, deletions or pushes by digest only, yield no push events.
+func ParsePushEvents(data []byte) ([]PushEvent, error) {
+	var payload notificationPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("error decoding notification payload: %w", err)
+	}
+
+	switch {
+	case payload.Events != nil:
+		return distributionPushEvents(payload.Events)
+	case payload.EventData != nil:
+		if payload.Type != harborPushArtifact {
+			return nil, nil
+		}
+		return harborPushEvents(payload.EventData)
+	case payload.DockerURL != "":
+		if len(payload.UpdatedTags) == 0 {
+			return nil, nil
+		}
+		return []PushEvent{{Repository: payload.DockerURL, Tags: payload.UpdatedTags}}, nil
+	}
+	return nil, errors.New("unrecognized notification payload: expected a Docker distribution, Harbor or Quay notification")
+}
+
+func distributionPushEvents(events []distributionEvent) ([]PushEvent, error) {
+	var pushes []PushEvent
+	for _, ev := range events {
+		// Manifests pushed by digest only, and the blobs they reference, have no tag.
+		if ev.Action != "push" || ev.Target.Tag == "" {
+			continue
+		}
+		// The host of the target URL reflects the externally configured address of the
+		// registry, and is preferred over the host requested by the client that pushed.
+		host := ev.Request.Host
+		if u, err := url.Parse(ev.Target.URL); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		if host == "" {
+			return nil, fmt.Errorf("push event for repository %q does not identify the registry host", ev.Target.Repository)
+		}
+		pushes = append(pushes, PushEvent{Repository: host + "/" + ev.Target.Repository, Tags: []string{ev.Target.Tag}})
+	}
+	return pushes, nil
+}
+
+func harborPushEvents(data *harborEventData) ([]PushEvent, error) {
+	var pushes []PushEvent
+	for _, res := range data.Resources {
+		if res.Tag == "" {
+			continue
+		}
+		// The resource URL is the reference of the pushed artifact, e.g.
+		// harbor.example.com/library/catalog:latest.
+		named, err := reference.ParseNormalizedNamed(res.ResourceURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource URL %q: %w", res.ResourceURL, err)
+		}
+		pushes = append(pushes, PushEvent{Repository: named.Name(), Tags: []string{res.Tag}})
+	}
+	return pushes, nil
+}
+
+// Matches reports whether the image reference ref is one of the tags pushed by ev.
+// References that are pinned to a digest never match, as pushes cannot change the
+// image they refer to.
+func (ev PushEvent) Matches(ref string) bool {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return false
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return false
+	}
+	tagged, ok := reference.TagNameOnly(named).(reference.Tagged)
+	if !ok {
+		return false
+	}
+	repo, err := reference.ParseNormalizedNamed(strings.TrimPrefix(strings.TrimPrefix(ev.Repository, "https://"), "http://"))
+	if err != nil || repo.Name() != named.Name() {
+		return false
+	}
+	for _, tag := range ev.Tags {
+		if tag == tagged.Tag() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package registrywebhook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePushEvents(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		file           string
+		payload        string
+		expectedEvents []PushEvent
+		expectedErr    string
+	}{
+		{
+			name: "docker distribution push",
+			file: "distribution-push.json",
+			expectedEvents: []PushEvent{
+				{Repository: "registry.example.com:5000/olm/catalog", Tags: []string{"v4.18"}},
+			},
+		},
+		{
+			name: "harbor push",
+			file: "harbor-push.json",
+			expectedEvents: []PushEvent{
+				{Repository: "harbor.example.com/olm/catalog", Tags: []string{"latest"}},
+			},
+		},
+		{
+			name: "harbor pull",
+			file: "harbor-pull.json",
+		},
+		{
+			name: "quay push",
+			file: "quay-push.json",
+			expectedEvents: []PushEvent{
+				{Repository: "quay.io/operatorhubio/catalog", Tags: []string{"latest", "v2"}},
+			},
+		},
+		{
+			name:        "docker distribution push without registry host",
+			payload:     `{"events":[{"action":"push","target":{"repository":"olm/catalog","tag":"latest"}}]}`,
+			expectedErr: `push event for repository "olm/catalog" does not identify the registry host`,
+		},
+		{
+			name:        "unrecognized payload",
+			payload:     `{"hello":"world"}`,
+			expectedErr: "unrecognized notification payload",
+		},
+		{
+			name:        "invalid JSON",
+			payload:     `{"events":`,
+			expectedErr: "error decoding notification payload",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := []byte(tc.payload)
+			if tc.file != "" {
+				var err error
+				data, err = os.ReadFile(filepath.Join("testdata", tc.file))
+				require.NoError(t, err)
+			}
+			events, err := ParsePushEvents(data)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
+
+func TestPushEventMatches(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		event    PushEvent
+		ref      string
+		expected bool
+	}{
+		{
+			name:     "matching tag",
+			event:    PushEvent{Repository: "quay.io/operatorhubio/catalog", Tags: []string{"latest", "v2"}},
+			ref:      "quay.io/operatorhubio/catalog:v2",
+			expected: true,
+		},
+		{
+			name:     "untagged reference defaults to latest",
+			event:    PushEvent{Repository: "quay.io/operatorhubio/catalog", Tags: []string{"latest"}},
+			ref:      "quay.io/operatorhubio/catalog",
+			expected: true,
+		},
+		{
+			name:     "docker hub names are normalized",
+			event:    PushEvent{Repository: "docker.io/library/catalog", Tags: []string{"latest"}},
+			ref:      "catalog:latest",
+			expected: true,
+		},
+		{
+			name:     "registry port is significant",
+			event:    PushEvent{Repository: "registry.example.com:5000/olm/catalog", Tags: []string{"latest"}},
+			ref:      "registry.example.com/olm/catalog:latest",
+			expected: false,
+		},
+		{
+			name:     "different tag",
+			event:    PushEvent{Repository: "quay.io/operatorhubio/catalog", Tags: []string{"v2"}},
+			ref:      "quay.io/operatorhubio/catalog:latest",
+			expected: false,
+		},
+		{
+			name:     "different repository",
+			event:    PushEvent{Repository: "quay.io/operatorhubio/other", Tags: []string{"latest"}},
+			ref:      "quay.io/operatorhubio/catalog:latest",
+			expected: false,
+		},
+		{
+			name:     "digest pinned reference",
+			event:    PushEvent{Repository: "quay.io/operatorhubio/catalog", Tags: []string{"latest"}},
+			ref:      "quay.io/operatorhubio/catalog:latest@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expected: false,
+		},
+		{
+			name:     "invalid reference",
+			event:    PushEvent{Repository: "quay.io/operatorhubio/catalog", Tags: []string{"latest"}},
+			ref:      "quay.io/operatorhubio/Catalog:latest",
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.event.Matches(tc.ref))
+		})
+	}
+}
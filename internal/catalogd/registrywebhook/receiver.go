@@ -0,0 +1,161 @@
+// Package registrywebhook implements an HTTP endpoint that receives push notifications
+// from container registries and requests a refresh of the ClusterCatalogs sourced from
+// the pushed images, so that new catalog content is picked up without waiting for the
+// next poll.
+package registrywebhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// Path is the path the receiver is served at by the catalog server.
+const Path = "/registry-webhook"
+
+// maxPayloadSize limits the size of notification payloads. Registries batch events,
+// but even large batches are far smaller than this.
+const maxPayloadSize = 1 << 20
+
+// Receiver is an http.Handler that receives push notifications from container registries.
+// A refresh is requested for every ClusterCatalog whose image source references a pushed
+// repository and tag, by setting its refresh-requested annotation, which makes the
+// catalog be unpacked again by whichever catalogd replica is the leader.
+//
+// Notifications must present a shared token in the Authorization header, with or without
+// a Bearer scheme. Tokens are never accepted in the query string, as request URIs are logged.
+type Receiver struct {
+	client    client.Client
+	tokenFile string
+	log       logr.Logger
+	now       func() time.Time
+}
+
+// NewReceiver returns a Receiver that updates ClusterCatalogs using c, and authenticates
+// notifications with the token stored in tokenFile. The token file is read for every
+// notification, so that the token can be rotated without restarting catalogd.
+func NewReceiver(c client.Client, tokenFile string, l logr.Logger) *Receiver {
+	return &Receiver{client: c, tokenFile: tokenFile, log: l, now: time.Now}
+}
+
+// receiverResponse is the body of successful responses, which registries typically
+// record in their notification logs.
+type receiverResponse struct {
+	RefreshedCatalogs []string `json:"refreshedCatalogs"`
+}
+
+func (rcv *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if err := rcv.authenticate(r); err != nil {
+		rcv.log.Info("rejected registry notification", "reason", err.Error())
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading notification: %v", err), http.StatusBadRequest)
+		return
+	}
+	events, err := ParsePushEvents(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	refreshed, err := rcv.refreshCatalogs(r, events)
+	if err != nil {
+		rcv.log.Error(err, "error requesting refresh of catalogs", "refreshedCatalogs", refreshed)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(refreshed) > 0 {
+		rcv.log.Info("requested refresh of catalogs", "refreshedCatalogs", refreshed)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(receiverResponse{RefreshedCatalogs: refreshed})
+}
+
+func (rcv *Receiver) authenticate(r *http.Request) error {
+	data, err := os.ReadFile(rcv.tokenFile)
+	if err != nil {
+		return fmt.Errorf("error reading token file: %w", err)
+	}
+	expected := strings.TrimSpace(string(data))
+	if expected == "" {
+		return errors.New("token file is empty")
+	}
+
+	if r.URL.Query().Has("token") {
+		return errors.New("token query parameter is not supported, present the token in the Authorization header")
+	}
+	presented := strings.TrimSpace(r.Header.Get("Authorization"))
+	if scheme, token, ok := strings.Cut(presented, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		presented = strings.TrimSpace(token)
+	}
+	if presented == "" {
+		return errors.New("missing token")
+	}
+	if subtle.ConstantTimeCompare([]byte(presented), []byte(expected)) != 1 {
+		return errors.New("invalid token")
+	}
+	return nil
+}
+
+// refreshCatalogs requests a refresh of the catalogs matching any of events, and returns
+// the names of the catalogs it requested a refresh of.
+func (rcv *Receiver) refreshCatalogs(r *http.Request, events []PushEvent) ([]string, error) {
+	if len(events) == 0 {
+		return []string{}, nil
+	}
+	var catalogs ocv1.ClusterCatalogList
+	if err := rcv.client.List(r.Context(), &catalogs); err != nil {
+		return nil, fmt.Errorf("error listing catalogs: %w", err)
+	}
+
+	refreshed := []string{}
+	requestedAt := rcv.now().UTC().Format(time.RFC3339Nano)
+	var errs []error
+	for _, catalog := range catalogs.Items {
+		if !matchesAny(&catalog, events) {
+			continue
+		}
+		patch := fmt.Appendf(nil, `{"metadata":{"annotations":{%q:%q}}}`, ocv1.RefreshRequestedAnnotation, requestedAt)
+		if err := rcv.client.Patch(r.Context(), &catalog, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			errs = append(errs, fmt.Errorf("error requesting refresh of catalog %q: %w", catalog.Name, err))
+			continue
+		}
+		refreshed = append(refreshed, catalog.Name)
+	}
+	return refreshed, errors.Join(errs...)
+}
+
+func matchesAny(catalog *ocv1.ClusterCatalog, events []PushEvent) bool {
+	if catalog.Spec.Source.Type != ocv1.SourceTypeImage || catalog.Spec.Source.Image == nil {
+		return false
+	}
+	for _, ev := range events {
+		if ev.Matches(catalog.Spec.Source.Image.Ref) {
+			return true
+		}
+	}
+	return false
+}
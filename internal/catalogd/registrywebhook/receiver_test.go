@@ -0,0 +1,196 @@
+package registrywebhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+const testToken = "s3cr3t"
+
+var testNow = time.Date(2026, 10, 19, 8, 12, 4, 0, time.UTC)
+
+func newImageCatalog(name, ref string) *ocv1.ClusterCatalog {
+	return &ocv1.ClusterCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ocv1.ClusterCatalogSpec{
+			Source: ocv1.CatalogSource{
+				Type:  ocv1.SourceTypeImage,
+				Image: &ocv1.ImageSource{Ref: ref},
+			},
+		},
+	}
+}
+
+func newTestReceiver(t *testing.T, catalogs ...client.Object) (*Receiver, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(catalogs...).Build()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(testToken+"\n"), 0600))
+
+	rcv := NewReceiver(cl, tokenFile, logr.Discard())
+	rcv.now = func() time.Time { return testNow }
+	return rcv, cl
+}
+
+func readTestdata(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestReceiver(t *testing.T) {
+	catalogs := []client.Object{
+		newImageCatalog("quay-latest", "quay.io/operatorhubio/catalog:latest"),
+		newImageCatalog("quay-v2", "quay.io/operatorhubio/catalog:v2"),
+		newImageCatalog("quay-v3", "quay.io/operatorhubio/catalog:v3"),
+		newImageCatalog("quay-pinned", "quay.io/operatorhubio/catalog@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+		newImageCatalog("distribution", "registry.example.com:5000/olm/catalog:v4.18"),
+		newImageCatalog("harbor", "harbor.example.com/olm/catalog"),
+	}
+
+	for _, tc := range []struct {
+		name              string
+		method            string
+		target            string
+		authorization     string
+		payload           string
+		expectedStatus    int
+		expectedRefreshed []string
+	}{
+		{
+			name:              "quay push with bearer token",
+			method:            http.MethodPost,
+			target:            Path,
+			authorization:     "Bearer " + testToken,
+			payload:           readTestdata(t, "quay-push.json"),
+			expectedStatus:    http.StatusAccepted,
+			expectedRefreshed: []string{"quay-latest", "quay-v2"},
+		},
+		{
+			name:              "docker distribution push with bearer token",
+			method:            http.MethodPost,
+			target:            Path,
+			authorization:     "Bearer " + testToken,
+			payload:           readTestdata(t, "distribution-push.json"),
+			expectedStatus:    http.StatusAccepted,
+			expectedRefreshed: []string{"distribution"},
+		},
+		{
+			name:              "harbor push with raw token",
+			method:            http.MethodPost,
+			target:            Path,
+			authorization:     testToken,
+			payload:           readTestdata(t, "harbor-push.json"),
+			expectedStatus:    http.StatusAccepted,
+			expectedRefreshed: []string{"harbor"},
+		},
+		{
+			name:              "harbor pull",
+			method:            http.MethodPost,
+			target:            Path,
+			authorization:     testToken,
+			payload:           readTestdata(t, "harbor-pull.json"),
+			expectedStatus:    http.StatusAccepted,
+			expectedRefreshed: []string{},
+		},
+		{
+			name:           "invalid token",
+			method:         http.MethodPost,
+			target:         Path,
+			authorization:  "Bearer wrong",
+			payload:        readTestdata(t, "quay-push.json"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing token",
+			method:         http.MethodPost,
+			target:         Path,
+			payload:        readTestdata(t, "quay-push.json"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "token query parameter",
+			method:         http.MethodPost,
+			target:         Path + "?token=" + testToken,
+			payload:        readTestdata(t, "quay-push.json"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "unsupported method",
+			method:         http.MethodGet,
+			target:         Path,
+			authorization:  testToken,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "unrecognized payload",
+			method:         http.MethodPost,
+			target:         Path,
+			authorization:  testToken,
+			payload:        `{"hello":"world"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rcv, cl := newTestReceiver(t, catalogs...)
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.payload))
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			w := httptest.NewRecorder()
+			rcv.ServeHTTP(w, req)
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+
+			if tc.expectedStatus == http.StatusAccepted {
+				var resp receiverResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, tc.expectedRefreshed, resp.RefreshedCatalogs)
+			}
+
+			// Only the refreshed catalogs are annotated.
+			for _, obj := range catalogs {
+				var catalog ocv1.ClusterCatalog
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(obj), &catalog))
+				requestedAt, ok := catalog.Annotations[ocv1.RefreshRequestedAnnotation]
+				if !slices.Contains(tc.expectedRefreshed, catalog.Name) {
+					require.False(t, ok, "catalog %q should not be refreshed", catalog.Name)
+					continue
+				}
+				require.Equal(t, testNow.Format(time.RFC3339Nano), requestedAt)
+			}
+		})
+	}
+}
+
+func TestReceiverMissingTokenFile(t *testing.T) {
+	rcv, _ := newTestReceiver(t, newImageCatalog("quay-latest", "quay.io/operatorhubio/catalog:latest"))
+	rcv.tokenFile = filepath.Join(t.TempDir(), "missing")
+
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(readTestdata(t, "quay-push.json")))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	w := httptest.NewRecorder()
+	rcv.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
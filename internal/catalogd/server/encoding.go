@@ -0,0 +1,126 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/gzhttp"
+	"github.com/opencontainers/go-digest"
+)
+
+// Content codings that catalog content may be pre-compressed with.
+const (
+	EncodingGzip = "gzip"
+	EncodingZstd = "zstd"
+)
+
+// preferredEncodings lists the supported content codings in order of server
+// preference, used to break ties between codings with an equal q-value.
+var preferredEncodings = []string{EncodingZstd, EncodingGzip}
+
+// minCompressedSize is the minimum size of uncompressed catalog content for which a
+// pre-compressed representation is served. It matches the threshold used for
+// responses that are compressed on the fly, so that small catalogs are served
+// the same way regardless of which path produced the response.
+const minCompressedSize = gzhttp.DefaultMinSize
+
+// negotiateEncoding returns the content coding to use for a response based on the
+// request's Accept-Encoding header, or an empty string if the response should not
+// be encoded.
+func negotiateEncoding(r *http.Request) string {
+	accept := r.Header.Values("Accept-Encoding")
+	if len(accept) == 0 {
+		return ""
+	}
+
+	qvalues := map[string]float64{}
+	wildcard := -1.0
+	for _, header := range accept {
+		for _, part := range strings.Split(header, ",") {
+			coding, q, ok := parseCoding(part)
+			if !ok {
+				continue
+			}
+			if coding == "*" {
+				wildcard = q
+				continue
+			}
+			qvalues[coding] = q
+		}
+	}
+
+	best, bestQ := "", 0.0
+	for _, enc := range preferredEncodings {
+		q, ok := qvalues[enc]
+		if !ok && wildcard >= 0 {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// onTheFlyEncoding returns the content coding that the compression handler wrapping
+// the catalog handlers compresses a response to r with, if the response is large
+// enough to be compressed, or an empty string if it does not compress it. Unlike
+// negotiateEncoding, it ignores wildcards and HEAD requests, as the handler does.
+func onTheFlyEncoding(r *http.Request) string {
+	if r.Method == http.MethodHead {
+		return ""
+	}
+	qvalues := map[string]float64{}
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if coding, q, ok := parseCoding(part); ok {
+			qvalues[coding] = q
+		}
+	}
+	best, bestQ := "", 0.0
+	for _, enc := range preferredEncodings {
+		if q := qvalues[enc]; q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// parseCoding parses a single element of an Accept-Encoding header, such as
+// "gzip;q=0.8", into its lowercased content coding and q-value.
+func parseCoding(s string) (string, float64, bool) {
+	coding, params, _ := strings.Cut(s, ";")
+	coding = strings.ToLower(strings.TrimSpace(coding))
+	if coding == "" {
+		return "", 0, false
+	}
+	q := 1.0
+	for _, param := range strings.Split(params, ";") {
+		k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(k), "q") {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			return "", 0, false
+		}
+		q = parsed
+	}
+	return coding, q, true
+}
+
+// etagFor returns a strong ETag for catalog content resolved from the image with
+// the given digest and served with the given content coding. Each encoding gets a
+// distinct ETag, as required for strong validators of different representations,
+// using the same "-<coding>" suffix convention as the on-the-fly compression
+// handler. An empty string is returned if the digest is unknown.
+func etagFor(dgst digest.Digest, encoding string) string {
+	if dgst == "" {
+		return ""
+	}
+	if encoding == "" {
+		return fmt.Sprintf(`"%s"`, dgst)
+	}
+	return fmt.Sprintf(`"%s-%s"`, dgst, encoding)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,12 +11,16 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/opencontainers/go-digest"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 
+	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
 	"github.com/operator-framework/operator-controller/internal/catalogd/service"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
 )
 
 var (
@@ -39,9 +44,11 @@ const (
 	GraphQLQueriesEnabled  GraphQLQueriesMode = true
 )
 
-// routeConfig defines allowed HTTP methods for a specific route
+// routeConfig defines allowed HTTP methods for a specific route, and the
+// endpoint it is recorded as in per-catalog request metrics
 type routeConfig struct {
 	path           string
+	endpoint       string
 	handler        http.HandlerFunc
 	allowedMethods []string
 }
@@ -55,9 +62,11 @@ type CatalogHandlers struct {
 	enableGraphQL GraphQLQueriesMode
 }
 
-// Index provides methods for looking up catalog content by schema/package/name
+// Index provides methods for looking up catalog content by schema/package/name.
+// Indexes returned by a CatalogStore must be closed once they are no longer used.
 type Index interface {
 	Get(catalogFile io.ReaderAt, schema, pkg, name string) io.Reader
+	Close() error
 }
 
 // CatalogStore defines the storage interface needed by handlers
@@ -65,6 +74,15 @@ type CatalogStore interface {
 	// GetCatalogData returns the catalog file and its metadata
 	GetCatalogData(catalog string) (*os.File, os.FileInfo, error)
 
+	// GetEncodedCatalogData returns the catalog file pre-compressed with the given
+	// content coding and its metadata. An error wrapping fs.ErrNotExist is returned
+	// if no such representation is stored.
+	GetEncodedCatalogData(catalog string, encoding string) (*os.File, os.FileInfo, error)
+
+	// GetCatalogDigest returns the digest of the image the stored catalog content
+	// was resolved from, or an empty digest if it is not known.
+	GetCatalogDigest(catalog string) (digest.Digest, error)
+
 	// GetCatalogFS returns a filesystem interface for the catalog
 	GetCatalogFS(catalog string) (fs.FS, error)
 
@@ -72,6 +90,15 @@ type CatalogStore interface {
 	GetIndex(catalog string) (Index, error)
 }
 
+// CatalogContentRedirector is optionally implemented by a CatalogStore whose content
+// can be served by another server, such as an object store, in which case requests
+// for the full content of a catalog are redirected there.
+type CatalogContentRedirector interface {
+	// CatalogContentURL returns the URL of the full content of the catalog in the
+	// given content coding, or nil if the content should not be redirected.
+	CatalogContentURL(ctx context.Context, catalog string, encoding string) (*url.URL, error)
+}
+
 // NewCatalogHandlers creates a new HTTP handlers instance
 func NewCatalogHandlers(store CatalogStore, graphqlSvc service.GraphQLService, rootURL *url.URL, enableMetas MetasHandlerMode, enableGraphQL GraphQLQueriesMode) *CatalogHandlers {
 	return &CatalogHandlers{
@@ -83,12 +110,27 @@ func NewCatalogHandlers(store CatalogStore, graphqlSvc service.GraphQLService, r
 	}
 }
 
-// Handler returns an HTTP handler with all routes configured
+// Handler returns an HTTP handler with all routes configured. The content of
+// ClusterCatalogs is served under <root>/<catalog>, and the content of namespaced
+// Catalogs under <root>/namespaces/<namespace>/<catalog>.
 func (h *CatalogHandlers) Handler() http.Handler {
+	var routes []routeConfig
+	for _, catalogURL := range []*url.URL{
+		h.rootURL.JoinPath("{catalog}"),
+		h.rootURL.JoinPath("namespaces", "{namespace}", "{catalog}"),
+	} {
+		routes = append(routes, h.catalogRoutes(catalogURL)...)
+	}
+	return h.buildRoutedHandler(routes)
+}
+
+// catalogRoutes returns the routes of the endpoints of a catalog served under catalogURL.
+func (h *CatalogHandlers) catalogRoutes(catalogURL *url.URL) []routeConfig {
 	// Build route configurations - each service contributes its routes and allowed methods
 	routes := []routeConfig{
 		{
-			path:           h.rootURL.JoinPath("{catalog}", "api", "v1", "all").Path,
+			path:           catalogURL.JoinPath("api", "v1", "all").Path,
+			endpoint:       catalogdmetrics.EndpointAll,
 			handler:        h.handleV1All,
 			allowedMethods: []string{http.MethodGet, http.MethodHead},
 		},
@@ -96,7 +138,8 @@ func (h *CatalogHandlers) Handler() http.Handler {
 
 	if h.enableMetas {
 		routes = append(routes, routeConfig{
-			path:           h.rootURL.JoinPath("{catalog}", "api", "v1", "metas").Path,
+			path:           catalogURL.JoinPath("api", "v1", "metas").Path,
+			endpoint:       catalogdmetrics.EndpointMetas,
 			handler:        h.handleV1Metas,
 			allowedMethods: []string{http.MethodGet, http.MethodHead},
 		})
@@ -104,22 +147,26 @@ func (h *CatalogHandlers) Handler() http.Handler {
 
 	if h.enableGraphQL {
 		routes = append(routes, routeConfig{
-			path:           h.rootURL.JoinPath("{catalog}", "api", "v1", "graphql").Path,
+			path:           catalogURL.JoinPath("api", "v1", "graphql").Path,
+			endpoint:       catalogdmetrics.EndpointGraphQL,
 			handler:        h.handleV1GraphQL,
 			allowedMethods: []string{http.MethodPost},
 		})
 	}
 
-	return h.buildRoutedHandler(routes)
+	return routes
 }
 
 // handleV1All serves the complete catalog content
 func (h *CatalogHandlers) handleV1All(w http.ResponseWriter, r *http.Request) {
-	catalog := r.PathValue("catalog")
-	if err := isValidCatalogName(catalog); err != nil {
+	catalog, err := catalogKey(r)
+	if err != nil {
 		httpError(w, err)
 		return
 	}
+	if done := h.redirectContent(w, r, catalog); done {
+		return
+	}
 	catalogFile, catalogStat, err := h.store.GetCatalogData(catalog)
 	if err != nil {
 		httpError(w, err)
@@ -127,14 +174,23 @@ func (h *CatalogHandlers) handleV1All(w http.ResponseWriter, r *http.Request) {
 	}
 	defer catalogFile.Close()
 
+	content, err := h.negotiateContent(w, r, catalog, catalogFile, catalogStat)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	if content != catalogFile {
+		defer content.Close()
+	}
+
 	w.Header().Add("Content-Type", "application/jsonl")
-	http.ServeContent(w, r, "", catalogStat.ModTime(), catalogFile)
+	http.ServeContent(w, r, "", catalogStat.ModTime(), content)
 }
 
 // handleV1Metas serves filtered catalog content based on query parameters
 func (h *CatalogHandlers) handleV1Metas(w http.ResponseWriter, r *http.Request) {
-	catalog := r.PathValue("catalog")
-	if err := isValidCatalogName(catalog); err != nil {
+	catalog, err := catalogKey(r)
+	if err != nil {
 		httpError(w, err)
 		return
 	}
@@ -159,19 +215,43 @@ func (h *CatalogHandlers) handleV1Metas(w http.ResponseWriter, r *http.Request)
 	}
 	defer catalogFile.Close()
 
-	w.Header().Set("Last-Modified", catalogStat.ModTime().UTC().Format(timeFormat))
-	done := checkPreconditions(w, r, catalogStat.ModTime())
-	if done {
-		return
-	}
-
 	schema := r.URL.Query().Get("schema")
 	pkg := r.URL.Query().Get("package")
 	name := r.URL.Query().Get("name")
+	filtered := schema != "" || pkg != "" || name != ""
+
+	if !filtered {
+		// If no parameters are provided, the entire catalog is returned, which
+		// may be served from a pre-compressed representation.
+		content, err := h.negotiateContent(w, r, catalog, catalogFile, catalogStat)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		if content != catalogFile {
+			defer content.Close()
+		}
+		// Content that is not pre-compressed may be compressed on the fly instead.
+		var onTheFly string
+		if content == catalogFile {
+			onTheFly = onTheFlyEncoding(r)
+		}
+		w.Header().Set("Last-Modified", catalogStat.ModTime().UTC().Format(timeFormat))
+		if done := checkPreconditions(w, r, catalogStat.ModTime(), onTheFly); done {
+			return
+		}
+		serveJSONLines(w, r, content)
+		return
+	}
 
-	if schema == "" && pkg == "" && name == "" {
-		// If no parameters are provided, return the entire catalog
-		serveJSONLines(w, r, catalogFile)
+	// Filtered responses are compressed on the fly, if at all, so they are
+	// validated against the identity ETag.
+	if err := h.setETag(w, catalog, ""); err != nil {
+		httpError(w, err)
+		return
+	}
+	w.Header().Set("Last-Modified", catalogStat.ModTime().UTC().Format(timeFormat))
+	if done := checkPreconditions(w, r, catalogStat.ModTime(), onTheFlyEncoding(r)); done {
 		return
 	}
 
@@ -180,6 +260,7 @@ func (h *CatalogHandlers) handleV1Metas(w http.ResponseWriter, r *http.Request)
 		httpError(w, err)
 		return
 	}
+	defer idx.Close()
 	indexReader := idx.Get(catalogFile, schema, pkg, name)
 	serveJSONLines(w, r, indexReader)
 }
@@ -195,8 +276,8 @@ func (h *CatalogHandlers) handleV1GraphQL(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	catalog := r.PathValue("catalog")
-	if err := isValidCatalogName(catalog); err != nil {
+	catalog, err := catalogKey(r)
+	if err != nil {
 		httpError(w, err)
 		return
 	}
@@ -244,6 +325,79 @@ func (h *CatalogHandlers) handleV1GraphQL(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// negotiateContent selects the representation of the full catalog content to serve
+// based on the request's Accept-Encoding header. If a pre-compressed representation
+// acceptable to the client is stored, it is opened and returned, and the
+// Content-Encoding header is set accordingly. Otherwise, catalogFile is returned.
+// In both cases the ETag and Vary headers for the selected representation are set.
+func (h *CatalogHandlers) negotiateContent(w http.ResponseWriter, r *http.Request, catalog string, catalogFile *os.File, catalogStat os.FileInfo) (*os.File, error) {
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	encoding := negotiateEncoding(r)
+	if encoding == "" || catalogStat.Size() < minCompressedSize {
+		return catalogFile, h.setETag(w, catalog, "")
+	}
+
+	encodedFile, _, err := h.store.GetEncodedCatalogData(catalog, encoding)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Content stored before pre-compression was introduced has no encoded
+		// representations, so fall back to serving it uncompressed.
+		return catalogFile, h.setETag(w, catalog, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := h.setETag(w, catalog, encoding); err != nil {
+		encodedFile.Close()
+		return nil, err
+	}
+	w.Header().Set("Content-Encoding", encoding)
+	return encodedFile, nil
+}
+
+// redirectContent redirects the request for the full content of a catalog to the
+// URL provided by the store, if it implements CatalogContentRedirector, and reports
+// whether a response was written.
+func (h *CatalogHandlers) redirectContent(w http.ResponseWriter, r *http.Request, catalog string) bool {
+	redirector, ok := h.store.(CatalogContentRedirector)
+	if !ok {
+		return false
+	}
+	encoding := negotiateEncoding(r)
+	target, err := redirector.CatalogContentURL(r.Context(), catalog, encoding)
+	if err != nil {
+		httpError(w, err)
+		return true
+	}
+	if target == nil {
+		return false
+	}
+
+	w.Header().Add("Vary", "Accept-Encoding")
+	if err := h.setETag(w, catalog, encoding); err != nil {
+		httpError(w, err)
+		return true
+	}
+	if done := checkPreconditions(w, r, time.Time{}, ""); done {
+		return true
+	}
+	http.Redirect(w, r, target.String(), http.StatusTemporaryRedirect)
+	return true
+}
+
+// setETag sets the ETag header for the given representation of a catalog's content,
+// if the digest of the image it was resolved from is known.
+func (h *CatalogHandlers) setETag(w http.ResponseWriter, catalog string, encoding string) error {
+	dgst, err := h.store.GetCatalogDigest(catalog)
+	if err != nil {
+		return err
+	}
+	if etag := etagFor(dgst, encoding); etag != "" {
+		w.Header().Set("Etag", etag)
+	}
+	return nil
+}
+
 // httpError writes an HTTP error response based on the error type
 func httpError(w http.ResponseWriter, err error) {
 	var code int
@@ -296,7 +450,7 @@ func (h *CatalogHandlers) buildRoutedHandler(routes []routeConfig) http.Handler
 
 	for _, route := range routes {
 		// Wrap each handler with method checking specific to that route
-		mux.HandleFunc(route.path, methodRestrictedHandler(route.handler, route.allowedMethods...))
+		mux.Handle(route.path, catalogdmetrics.InstrumentCatalogHandler(route.endpoint, methodRestrictedHandler(route.handler, route.allowedMethods...)))
 	}
 
 	return mux
@@ -314,6 +468,24 @@ func methodRestrictedHandler(handler http.HandlerFunc, allowedMethods ...string)
 	}
 }
 
+// catalogKey returns the key of the catalog that a request is for, which is the name
+// of a ClusterCatalog, or the key of a namespaced Catalog for requests with a
+// namespace path value.
+func catalogKey(r *http.Request) (string, error) {
+	catalog := r.PathValue("catalog")
+	if err := isValidCatalogName(catalog); err != nil {
+		return "", err
+	}
+	namespace := r.PathValue("namespace")
+	if namespace == "" {
+		return catalog, nil
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", fmt.Errorf("%w: invalid namespace: %s", errInvalidCatalogName, strings.Join(errs, "; "))
+	}
+	return catalogutil.NamespacedKey(namespace, catalog), nil
+}
+
 // isValidCatalogName validates that a catalog name is safe for filesystem operations
 // and suitable for Kubernetes metadata.name by using DNS1123 subdomain validation.
 // Prevents path traversal attacks by requiring alphanumeric start/end characters.
@@ -23,10 +23,12 @@ const (
 )
 
 // checkPreconditions evaluates request preconditions and reports whether a precondition
-// resulted in sending StatusNotModified or StatusPreconditionFailed.
-func checkPreconditions(w http.ResponseWriter, r *http.Request, modtime time.Time) bool {
+// resulted in sending StatusNotModified or StatusPreconditionFailed. onTheFlyEncoding is
+// the content coding that the response will be compressed with on the fly, whose suffix
+// is added to the ETag after the preconditions are evaluated, or an empty string.
+func checkPreconditions(w http.ResponseWriter, r *http.Request, modtime time.Time, onTheFlyEncoding string) bool {
 	// This function carefully follows RFC 7232 section 6.
-	ch := checkIfMatch(r)
+	ch := checkIfMatch(w, r)
 	if ch == condNone {
 		ch = checkIfUnmodifiedSince(r, modtime)
 	}
@@ -34,7 +36,7 @@ func checkPreconditions(w http.ResponseWriter, r *http.Request, modtime time.Tim
 		w.WriteHeader(http.StatusPreconditionFailed)
 		return true
 	}
-	switch checkIfNoneMatch(r) {
+	switch checkIfNoneMatch(w, r, onTheFlyEncoding) {
 	case condFalse:
 		if r.Method == "GET" || r.Method == "HEAD" {
 			writeNotModified(w)
@@ -128,7 +130,7 @@ func writeNotModified(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNotModified)
 }
 
-func checkIfNoneMatch(r *http.Request) condResult {
+func checkIfNoneMatch(w http.ResponseWriter, r *http.Request, onTheFlyEncoding string) condResult {
 	inm := r.Header.Get("If-None-Match")
 	if inm == "" {
 		return condNone
@@ -150,6 +152,10 @@ func checkIfNoneMatch(r *http.Request) condResult {
 		if etag == "" {
 			break
 		}
+		current := w.Header().Get("Etag")
+		if etagWeakMatch(etag, current) || etagWeakMatch(trimEncodingSuffix(etag, onTheFlyEncoding), current) {
+			return condFalse
+		}
 		buf = remain
 	}
 	return condTrue
@@ -169,7 +175,7 @@ func parseTime(text string) (t time.Time, err error) {
 	return
 }
 
-func checkIfMatch(r *http.Request) condResult {
+func checkIfMatch(w http.ResponseWriter, r *http.Request) condResult {
 	im := r.Header.Get("If-Match")
 	if im == "" {
 		return condNone
@@ -190,6 +196,9 @@ func checkIfMatch(r *http.Request) condResult {
 		if etag == "" {
 			break
 		}
+		if etagStrongMatch(etag, w.Header().Get("Etag")) {
+			return condTrue
+		}
 		im = remain
 	}
 
@@ -224,3 +233,32 @@ func scanETag(s string) (etag string, remain string) {
 	}
 	return "", ""
 }
+
+// etagStrongMatch reports whether a and b match using strong ETag comparison.
+// Assumes a and b are valid ETags.
+func etagStrongMatch(a, b string) bool {
+	return a == b && a != "" && a[0] == '"'
+}
+
+// etagWeakMatch reports whether a and b match using weak ETag comparison.
+// Assumes a and b are valid ETags.
+func etagWeakMatch(a, b string) bool {
+	return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+}
+
+// trimEncodingSuffix removes the suffix of a content coding (e.g. "-gzip") from an
+// ETag. Responses that are compressed on the fly have their ETag suffixed after the
+// preconditions are evaluated, so clients revalidating such a response send the
+// suffixed ETag back. Only the suffix of the coding that the response will be
+// compressed with is removed, so that an ETag of another representation never
+// matches.
+func trimEncodingSuffix(etag, encoding string) string {
+	if encoding == "" {
+		return etag
+	}
+	suffix := "-" + encoding + `"`
+	if !strings.HasSuffix(etag, suffix) {
+		return etag
+	}
+	return strings.TrimSuffix(etag, suffix) + `"`
+}
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
+	"github.com/operator-framework/operator-controller/internal/catalogd/registrywebhook"
 	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
 )
 
@@ -26,6 +27,12 @@ type CatalogServerConfig struct {
 	CertFile     string
 	KeyFile      string
 	LocalStorage storage.Instance
+	// ContentAuthFilter optionally wraps the handler serving catalog content in order to
+	// authenticate and authorize requests. When nil, catalog content is served to any client.
+	ContentAuthFilter func(http.Handler) http.Handler
+	// RegistryWebhook optionally receives push notifications from container registries at
+	// registrywebhook.Path. It authenticates requests itself, independently of ContentAuthFilter.
+	RegistryWebhook http.Handler
 	// TLSOpts are optional functions applied to the TLS configuration when serving over HTTPS.
 	// Use these to configure cipher suites, minimum TLS version, curve preferences, and
 	// certificate retrieval (e.g. via a certwatcher).
@@ -35,8 +42,8 @@ type CatalogServerConfig struct {
 // AddCatalogServerToManager adds the catalog HTTP server to the manager and registers
 // a readiness check that passes once the server has started serving.  Because
 // NeedLeaderElection returns false, Start() is called on every pod immediately, so all
-// replicas bind the catalog port and become ready.  Non-leader pods serve requests but
-// return 404 (empty local cache); callers are expected to retry.
+// replicas bind the catalog port.  Non-leader pods serve the content they replicated
+// from the resolved sources set by the leader.
 func AddCatalogServerToManager(mgr ctrl.Manager, cfg CatalogServerConfig) error {
 	shutdownTimeout := 30 * time.Second
 	r := &catalogServerRunnable{
@@ -57,8 +64,7 @@ func AddCatalogServerToManager(mgr ctrl.Manager, cfg CatalogServerConfig) error
 
 	// Register a readiness check that passes once Start() has been called and the
 	// server is actively serving.  All pods reach Start() (NeedLeaderElection=false),
-	// so all replicas become ready and receive traffic; non-leaders return 404 until
-	// they win the leader lease and populate their local cache.
+	// so all replicas can become ready and receive traffic without holding the leader lease.
 	if err := mgr.AddReadyzCheck("catalog-server", r.readyzCheck()); err != nil {
 		return fmt.Errorf("error adding catalog server readiness check: %w", err)
 	}
@@ -67,8 +73,7 @@ func AddCatalogServerToManager(mgr ctrl.Manager, cfg CatalogServerConfig) error
 }
 
 // catalogServerRunnable is a Runnable that binds the catalog HTTP port on every pod.
-// Because NeedLeaderElection returns false, Start() is called on all replicas immediately;
-// non-leader pods serve the catalog port but return 404 (empty local cache).
+// Because NeedLeaderElection returns false, Start() is called on all replicas immediately.
 type catalogServerRunnable struct {
 	cfg             CatalogServerConfig
 	server          *http.Server
@@ -83,9 +88,8 @@ type catalogServerRunnable struct {
 // (held by the still-running old pod) and therefore could never pass the
 // catalog-server readiness check, deadlocking the rollout.
 //
-// Non-leader pods serve the catalog HTTP port but have an empty local cache
-// (only the leader's reconciler downloads catalog content), so requests to a
-// non-leader return 404.  Callers are expected to retry.
+// Non-leader pods serve the content stored by the ClusterCatalogReplicaReconciler,
+// which pulls the resolved sources set by the leader.
 func (r *catalogServerRunnable) NeedLeaderElection() bool { return false }
 
 func (r *catalogServerRunnable) Start(ctx context.Context) error {
@@ -169,9 +173,36 @@ func logrLoggingHandler(l logr.Logger, handler http.Handler) http.Handler {
 	})
 }
 
+// compressHandler compresses responses on the fly for clients that accept gzip or zstd,
+// preferring zstd when both are accepted with the same quality, like the storage handler
+// does for the pre-compressed catalog representations. Compressed responses get an
+// encoding-specific ETag suffix ("-gzip" or "-zstd"), matching the ETags of those
+// representations, which already set Content-Encoding and are passed through unmodified.
+var compressHandler = func() func(http.Handler) http.HandlerFunc {
+	wrapper, err := gzhttp.NewWrapper(
+		gzhttp.EnableGzip(true),
+		gzhttp.EnableZstd(true),
+		gzhttp.PreferZstd(true),
+		gzhttp.SuffixETag("-gzip"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("invalid compression handler configuration: %v", err))
+	}
+	return wrapper
+}()
+
 func storageServerHandlerWrapped(l logr.Logger, cfg CatalogServerConfig) http.Handler {
 	handler := cfg.LocalStorage.StorageServerHandler()
-	handler = gzhttp.GzipHandler(handler)
+	if cfg.ContentAuthFilter != nil {
+		handler = cfg.ContentAuthFilter(handler)
+	}
+	if cfg.RegistryWebhook != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/", handler)
+		mux.Handle(registrywebhook.Path, cfg.RegistryWebhook)
+		handler = mux
+	}
+	handler = compressHandler(handler)
 	handler = catalogdmetrics.AddMetricsToHandler(handler)
 
 	handler = logrLoggingHandler(l, handler)
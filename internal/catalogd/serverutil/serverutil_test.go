@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
@@ -110,6 +111,70 @@ func TestStorageServerHandlerWrapped_Gzip(t *testing.T) {
 	}
 }
 
+func TestStorageServerHandlerWrapped_Zstd(t *testing.T) {
+	content := "{\"data\":\"" + strings.Repeat("test data ", 1000) + "\"}"
+	handler := storageServerHandlerWrapped(logr.Logger{}, CatalogServerConfig{LocalStorage: newMockStorageInstance(gomock.NewController(t), content)})
+
+	for _, tc := range []struct {
+		acceptEncoding   string
+		expectedEncoding string
+	}{
+		{acceptEncoding: "zstd", expectedEncoding: "zstd"},
+		{acceptEncoding: "gzip, zstd", expectedEncoding: "zstd"},
+		{acceptEncoding: "gzip;q=1.0, zstd;q=0.5", expectedEncoding: "gzip"},
+	} {
+		t.Run(tc.acceptEncoding, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, tc.expectedEncoding, rec.Header().Get("Content-Encoding"))
+			if tc.expectedEncoding != "zstd" {
+				return
+			}
+			zr, err := zstd.NewReader(rec.Body)
+			require.NoError(t, err)
+			defer zr.Close()
+			body, err := io.ReadAll(zr)
+			require.NoError(t, err)
+			require.Equal(t, content, string(body))
+		})
+	}
+}
+
+func TestStorageServerHandlerWrapped_ETagSuffix(t *testing.T) {
+	content := "{\"data\":\"" + strings.Repeat("test data ", 1000) + "\"}"
+	mockStorage := mockstorage.NewMockInstance(gomock.NewController(t))
+	mockStorage.EXPECT().StorageServerHandler().Return(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Etag", `"sha256:abc"`)
+		_, _ = w.Write([]byte(content))
+	})).AnyTimes()
+	handler := storageServerHandlerWrapped(logr.Logger{}, CatalogServerConfig{LocalStorage: mockStorage})
+
+	for _, tc := range []struct {
+		acceptEncoding string
+		expectedETag   string
+	}{
+		{acceptEncoding: "", expectedETag: `"sha256:abc"`},
+		{acceptEncoding: "gzip", expectedETag: `"sha256:abc-gzip"`},
+		{acceptEncoding: "zstd", expectedETag: `"sha256:abc-zstd"`},
+	} {
+		t.Run(tc.acceptEncoding, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, tc.expectedETag, rec.Header().Get("Etag"))
+		})
+	}
+}
+
 func newMockStorageInstance(ctrl *gomock.Controller, content string) *mockstorage.MockInstance {
 	m := mockstorage.NewMockInstance(ctrl)
 	m.EXPECT().StorageServerHandler().Return(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -118,7 +183,7 @@ func newMockStorageInstance(ctrl *gomock.Controller, content string) *mockstorag
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
 	})).AnyTimes()
-	m.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	m.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	m.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
 	m.EXPECT().ContentExists(gomock.Any()).Return(true).AnyTimes()
 	m.EXPECT().BaseURL(gomock.Any()).Return("").AnyTimes()
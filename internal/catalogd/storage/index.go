@@ -2,8 +2,6 @@ package storage
 
 import (
 	"cmp"
-	"encoding/json"
-	"fmt"
 	"io"
 	"slices"
 
@@ -24,10 +22,13 @@ import (
 // that the actual content returned by the index remains identical, as users of the index
 // may be sensitive to differences introduced by index algorithm changes (e.g. if the
 // order of the returned sections changes).
+//
+// The index is built in memory while storing a catalog, and stored in its binary
+// representation (see writeBinaryIndex), which is queried in place when serving.
 type index struct {
-	BySchema  map[string][]section `json:"by_schema"`
-	ByPackage map[string][]section `json:"by_package"`
-	ByName    map[string][]section `json:"by_name"`
+	BySchema  map[string][]section
+	ByPackage map[string][]section
+	ByName    map[string][]section
 }
 
 // A section is the byte offset and length of an FBC blob within the file.
@@ -36,20 +37,6 @@ type section struct {
 	length int64
 }
 
-func (s *section) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprintf(`[%d,%d]`, s.offset, s.length)), nil
-}
-
-func (s *section) UnmarshalJSON(b []byte) error {
-	vals := [2]int64{}
-	if err := json.Unmarshal(b, &vals); err != nil {
-		return err
-	}
-	s.offset = vals[0]
-	s.length = vals[1]
-	return nil
-}
-
 func (i index) Get(r io.ReaderAt, schema, packageName, name string) io.Reader {
 	sectionSet := i.getSectionSet(schema, packageName, name)
 
@@ -0,0 +1,345 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"sync/atomic"
+)
+
+// The binary index is the on-disk representation of an index. It is designed to be
+// memory-mapped and queried in place, without decoding it: keys are sorted so that
+// they can be binary searched, and the sections matching each key are stored as a
+// sorted posting list of section ordinals, so that posting lists can be intersected
+// by merging them.
+//
+// All integers are little-endian. The layout of version 1 is:
+//
+//	header:
+//	  magic         [8]byte   "FBCINDEX"
+//	  version       uint32
+//	  reserved      uint32
+//	  sectionCount  uint64
+//	  tableOffsets  [3]uint64 (schema, package and name tables)
+//	sections:       sectionCount x {offset int64, length int64}, sorted by offset
+//	table:
+//	  keyCount      uint64
+//	  entries       keyCount x {keyOffset uint64, keyLength uint32, postingCount uint32, postingsOffset uint64}, sorted by key
+//	  keys          concatenated key bytes
+//	  postings      concatenated posting lists of uint32 section ordinals, each sorted
+//
+// Offsets within the index are relative to the start of the index. Because section
+// ordinals are assigned in offset order, sorted posting lists yield sections in the
+// same order as the in-memory index.
+const (
+	binaryIndexMagic   = "FBCINDEX"
+	binaryIndexVersion = uint32(1)
+
+	binaryIndexHeaderSize  = 48
+	binaryIndexSectionSize = 16
+	binaryIndexEntrySize   = 24
+	binaryIndexPostingSize = 4
+)
+
+const (
+	tableSchema = iota
+	tablePackage
+	tableName
+	tableCount
+)
+
+var errInvalidBinaryIndex = errors.New("invalid binary index")
+
+// writeBinaryIndex writes the binary representation of idx to w.
+func writeBinaryIndex(w io.Writer, idx *index) error {
+	// Assign ordinals to all distinct sections in offset order.
+	offsets := map[int64]section{}
+	for _, byKey := range []map[string][]section{idx.BySchema, idx.ByPackage, idx.ByName} {
+		for _, sections := range byKey {
+			for _, s := range sections {
+				offsets[s.offset] = s
+			}
+		}
+	}
+	sections := slices.Collect(maps.Values(offsets))
+	slices.SortFunc(sections, func(a, b section) int { return cmp.Compare(a.offset, b.offset) })
+	ordinals := make(map[int64]uint32, len(sections))
+	for i, s := range sections {
+		ordinals[s.offset] = uint32(i)
+	}
+
+	bw := bufio.NewWriter(w)
+	tables := []map[string][]section{idx.BySchema, idx.ByPackage, idx.ByName}
+	tableOffsets := [tableCount]uint64{}
+	offset := uint64(binaryIndexHeaderSize + len(sections)*binaryIndexSectionSize)
+	for i, byKey := range tables {
+		tableOffsets[i] = offset
+		offset += binaryTableSize(byKey)
+	}
+
+	header := make([]byte, 0, binaryIndexHeaderSize)
+	header = append(header, binaryIndexMagic...)
+	header = binary.LittleEndian.AppendUint32(header, binaryIndexVersion)
+	header = binary.LittleEndian.AppendUint32(header, 0)
+	header = binary.LittleEndian.AppendUint64(header, uint64(len(sections)))
+	for _, tableOffset := range tableOffsets {
+		header = binary.LittleEndian.AppendUint64(header, tableOffset)
+	}
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 0, binaryIndexEntrySize)
+	for _, s := range sections {
+		buf = binary.LittleEndian.AppendUint64(buf[:0], uint64(s.offset))
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(s.length))
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	for i, byKey := range tables {
+		if err := writeBinaryTable(bw, tableOffsets[i], byKey, ordinals); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func binaryTableSize(byKey map[string][]section) uint64 {
+	size := uint64(8 + len(byKey)*binaryIndexEntrySize)
+	for key, sections := range byKey {
+		size += uint64(len(key) + len(sections)*binaryIndexPostingSize)
+	}
+	return size
+}
+
+func writeBinaryTable(w io.Writer, tableOffset uint64, byKey map[string][]section, ordinals map[int64]uint32) error {
+	keys := slices.Sorted(maps.Keys(byKey))
+
+	keysOffset := tableOffset + 8 + uint64(len(keys)*binaryIndexEntrySize)
+	postingsOffset := keysOffset
+	for _, key := range keys {
+		postingsOffset += uint64(len(key))
+	}
+
+	buf := binary.LittleEndian.AppendUint64(make([]byte, 0, binaryIndexEntrySize), uint64(len(keys)))
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	postingLists := make([][]uint32, 0, len(keys))
+	for _, key := range keys {
+		postings := make([]uint32, 0, len(byKey[key]))
+		for _, s := range byKey[key] {
+			postings = append(postings, ordinals[s.offset])
+		}
+		slices.Sort(postings)
+		postingLists = append(postingLists, postings)
+
+		buf = binary.LittleEndian.AppendUint64(buf[:0], keysOffset)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(key)))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(postings)))
+		buf = binary.LittleEndian.AppendUint64(buf, postingsOffset)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		keysOffset += uint64(len(key))
+		postingsOffset += uint64(len(postings) * binaryIndexPostingSize)
+	}
+	for _, key := range keys {
+		if _, err := io.WriteString(w, key); err != nil {
+			return err
+		}
+	}
+	for _, postings := range postingLists {
+		buf = buf[:0]
+		for _, p := range postings {
+			buf = binary.LittleEndian.AppendUint32(buf, p)
+		}
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// binaryIndex is an index backed by the binary representation of an index, which is
+// typically memory-mapped. It is queried in place.
+//
+// A binaryIndex is reference counted: it is opened with a single reference, every
+// retain adds one, and every Close drops one. Its data is released by the release
+// function, e.g. unmapped, once the last reference is dropped.
+type binaryIndex struct {
+	data         []byte
+	sectionCount uint64
+	tables       [tableCount]binaryTable
+
+	refs    atomic.Int64
+	release func()
+}
+
+type binaryTable struct {
+	keyCount uint64
+	entries  []byte
+}
+
+// openBinaryIndex validates the header and table directories of the binary index in
+// data, and returns an index that queries it in place.
+func openBinaryIndex(data []byte) (*binaryIndex, error) {
+	if len(data) < binaryIndexHeaderSize || string(data[:len(binaryIndexMagic)]) != binaryIndexMagic {
+		return nil, fmt.Errorf("%w: missing header", errInvalidBinaryIndex)
+	}
+	if version := binary.LittleEndian.Uint32(data[8:]); version != binaryIndexVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", errInvalidBinaryIndex, version)
+	}
+	idx := &binaryIndex{
+		data:         data,
+		sectionCount: binary.LittleEndian.Uint64(data[16:]),
+	}
+	idx.refs.Store(1)
+	if idx.sectionCount > uint64(len(data)-binaryIndexHeaderSize)/binaryIndexSectionSize {
+		return nil, fmt.Errorf("%w: sections exceed index size", errInvalidBinaryIndex)
+	}
+	for i := range idx.tables {
+		tableOffset := binary.LittleEndian.Uint64(data[24+8*i:])
+		if tableOffset > uint64(len(data))-8 {
+			return nil, fmt.Errorf("%w: table %d exceeds index size", errInvalidBinaryIndex, i)
+		}
+		keyCount := binary.LittleEndian.Uint64(data[tableOffset:])
+		entriesStart := tableOffset + 8
+		if keyCount > (uint64(len(data))-entriesStart)/binaryIndexEntrySize {
+			return nil, fmt.Errorf("%w: table %d entries exceed index size", errInvalidBinaryIndex, i)
+		}
+		entries := data[entriesStart : entriesStart+keyCount*binaryIndexEntrySize]
+		for e := uint64(0); e < keyCount; e++ {
+			entry := entries[e*binaryIndexEntrySize:]
+			keyOffset, keyLength := binary.LittleEndian.Uint64(entry), uint64(binary.LittleEndian.Uint32(entry[8:]))
+			postingCount, postingsOffset := uint64(binary.LittleEndian.Uint32(entry[12:])), binary.LittleEndian.Uint64(entry[16:])
+			if keyOffset > uint64(len(data)) || keyLength > uint64(len(data))-keyOffset ||
+				postingsOffset > uint64(len(data)) || postingCount > (uint64(len(data))-postingsOffset)/binaryIndexPostingSize {
+				return nil, fmt.Errorf("%w: table %d entry %d exceeds index size", errInvalidBinaryIndex, i, e)
+			}
+		}
+		idx.tables[i] = binaryTable{keyCount: keyCount, entries: entries}
+	}
+	return idx, nil
+}
+
+// retain adds a reference to the index, unless its data was already released.
+func (i *binaryIndex) retain() bool {
+	for {
+		refs := i.refs.Load()
+		if refs <= 0 {
+			return false
+		}
+		if i.refs.CompareAndSwap(refs, refs+1) {
+			return true
+		}
+	}
+}
+
+// Close drops a reference to the index, and releases its data once no reference is left.
+func (i *binaryIndex) Close() error {
+	if i.refs.Add(-1) == 0 && i.release != nil {
+		i.release()
+	}
+	return nil
+}
+
+func (i *binaryIndex) Get(r io.ReaderAt, schema, packageName, name string) io.Reader {
+	var ordinals []uint32
+	if schema == "" {
+		ordinals = i.allPostings(tableSchema)
+	} else {
+		ordinals = i.postings(tableSchema, schema)
+	}
+	if packageName != "" {
+		ordinals = intersectPostings(ordinals, i.postings(tablePackage, packageName))
+	}
+	if name != "" {
+		ordinals = intersectPostings(ordinals, i.postings(tableName, name))
+	}
+
+	srs := make([]io.Reader, 0, len(ordinals))
+	for _, ordinal := range ordinals {
+		if uint64(ordinal) >= i.sectionCount {
+			return io.MultiReader(append(srs, errReader{fmt.Errorf("%w: section %d out of range", errInvalidBinaryIndex, ordinal)})...)
+		}
+		s := i.data[binaryIndexHeaderSize+uint64(ordinal)*binaryIndexSectionSize:]
+		offset, length := int64(binary.LittleEndian.Uint64(s)), int64(binary.LittleEndian.Uint64(s[8:]))
+		srs = append(srs, io.NewSectionReader(r, offset, length))
+	}
+	return io.MultiReader(srs...)
+}
+
+// postings returns the posting list of key in the given table.
+func (i *binaryIndex) postings(table int, key string) []uint32 {
+	t := i.tables[table]
+	e := sort.Search(int(t.keyCount), func(e int) bool {
+		return bytes.Compare(i.entryKey(t, uint64(e)), []byte(key)) >= 0
+	})
+	if uint64(e) == t.keyCount || !bytes.Equal(i.entryKey(t, uint64(e)), []byte(key)) {
+		return nil
+	}
+	return i.entryPostings(t, uint64(e))
+}
+
+// allPostings returns the union of all posting lists of the given table.
+func (i *binaryIndex) allPostings(table int) []uint32 {
+	t := i.tables[table]
+	var all []uint32
+	for e := uint64(0); e < t.keyCount; e++ {
+		all = append(all, i.entryPostings(t, e)...)
+	}
+	slices.Sort(all)
+	return slices.Compact(all)
+}
+
+func (i *binaryIndex) entryKey(t binaryTable, e uint64) []byte {
+	entry := t.entries[e*binaryIndexEntrySize:]
+	keyOffset := binary.LittleEndian.Uint64(entry)
+	return i.data[keyOffset : keyOffset+uint64(binary.LittleEndian.Uint32(entry[8:]))]
+}
+
+func (i *binaryIndex) entryPostings(t binaryTable, e uint64) []uint32 {
+	entry := t.entries[e*binaryIndexEntrySize:]
+	count := uint64(binary.LittleEndian.Uint32(entry[12:]))
+	postingsOffset := binary.LittleEndian.Uint64(entry[16:])
+	postings := make([]uint32, count)
+	for p := range postings {
+		postings[p] = binary.LittleEndian.Uint32(i.data[postingsOffset+uint64(p)*binaryIndexPostingSize:])
+	}
+	return postings
+}
+
+// intersectPostings returns the ordinals present in both of the sorted posting lists.
+func intersectPostings(a, b []uint32) []uint32 {
+	var out []uint32
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0] < b[0]:
+			a = a[1:]
+		case a[0] > b[0]:
+			b = b[1:]
+		default:
+			out = append(out, a[0])
+			a, b = a[1:], b[1:]
+		}
+	}
+	return out
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+// newTestIndex returns the index and content of a catalog of several packages, with
+// metas of the same schema, package and name interleaved throughout the content.
+func newTestIndex(t *testing.T) (*index, []byte) {
+	var metas []*declcfg.Meta
+	for _, pkg := range []string{"pkg-b", "pkg-a", "pkg-c"} {
+		metas = append(metas, &declcfg.Meta{Schema: "olm.package", Name: pkg})
+		for _, ch := range []string{"stable", "fast"} {
+			metas = append(metas, &declcfg.Meta{Schema: "olm.channel", Package: pkg, Name: ch})
+		}
+		for v := range 3 {
+			metas = append(metas, &declcfg.Meta{Schema: "olm.bundle", Package: pkg, Name: fmt.Sprintf("%s.v1.0.%d", pkg, v)})
+		}
+		metas = append(metas, &declcfg.Meta{Schema: "olm.deprecations", Package: pkg})
+	}
+	metas = append(metas, &declcfg.Meta{Schema: "custom.schema"})
+
+	var content bytes.Buffer
+	metasChan := make(chan *declcfg.Meta, len(metas))
+	for _, meta := range metas {
+		meta.Blob = createBlob(t, map[string]interface{}{"schema": meta.Schema, "package": meta.Package, "name": meta.Name})
+		content.Write(meta.Blob)
+		metasChan <- meta
+	}
+	close(metasChan)
+	return newIndex(metasChan), content.Bytes()
+}
+
+func TestBinaryIndexGet(t *testing.T) {
+	idx, content := newTestIndex(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeBinaryIndex(&buf, idx))
+	binIdx, err := openBinaryIndex(buf.Bytes())
+	require.NoError(t, err)
+
+	r := bytes.NewReader(content)
+	for _, schema := range []string{"", "olm.package", "olm.channel", "olm.bundle", "olm.deprecations", "custom.schema", "missing"} {
+		for _, pkg := range []string{"", "pkg-a", "pkg-b", "pkg-c", "missing"} {
+			for _, name := range []string{"", "stable", "fast", "pkg-a", "pkg-c.v1.0.1", "missing"} {
+				t.Run(fmt.Sprintf("schema=%q,package=%q,name=%q", schema, pkg, name), func(t *testing.T) {
+					expected, err := io.ReadAll(idx.Get(r, schema, pkg, name))
+					require.NoError(t, err)
+					actual, err := io.ReadAll(binIdx.Get(r, schema, pkg, name))
+					require.NoError(t, err)
+					require.Equal(t, string(expected), string(actual))
+				})
+			}
+		}
+	}
+}
+
+func TestBinaryIndexEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeBinaryIndex(&buf, newIndex(closedMetasChan())))
+	binIdx, err := openBinaryIndex(buf.Bytes())
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(binIdx.Get(bytes.NewReader(nil), "", "", ""))
+	require.NoError(t, err)
+	require.Empty(t, content)
+}
+
+func TestOpenBinaryIndexInvalid(t *testing.T) {
+	idx, _ := newTestIndex(t)
+	var buf bytes.Buffer
+	require.NoError(t, writeBinaryIndex(&buf, idx))
+	valid := buf.Bytes()
+
+	withUint64 := func(offset int, v uint64) []byte {
+		data := bytes.Clone(valid)
+		binary.LittleEndian.PutUint64(data[offset:], v)
+		return data
+	}
+	unsupportedVersion := bytes.Clone(valid)
+	binary.LittleEndian.PutUint32(unsupportedVersion[8:], binaryIndexVersion+1)
+
+	for _, tc := range []struct {
+		name        string
+		data        []byte
+		expectedErr string
+	}{
+		{name: "empty", data: nil, expectedErr: "missing header"},
+		{name: "JSON index", data: []byte(`{"BySchema":{},"ByPackage":{},"ByName":{}}` + "\n"), expectedErr: "missing header"},
+		{name: "unsupported version", data: unsupportedVersion, expectedErr: "unsupported version"},
+		{name: "too many sections", data: withUint64(16, uint64(len(valid))), expectedErr: "sections exceed index size"},
+		{name: "table out of range", data: withUint64(24, uint64(len(valid))), expectedErr: "table 0 exceeds index size"},
+		{name: "truncated", data: valid[:len(valid)-1], expectedErr: "exceeds index size"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := openBinaryIndex(tc.data)
+			require.ErrorIs(t, err, errInvalidBinaryIndex)
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMapBinaryIndex(t *testing.T) {
+	idx, content := newTestIndex(t)
+	path := filepath.Join(t.TempDir(), "index.bin")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, writeBinaryIndex(f, idx))
+	require.NoError(t, f.Close())
+
+	binIdx, err := mapBinaryIndex(path)
+	require.NoError(t, err)
+
+	r := bytes.NewReader(content)
+	expected, err := io.ReadAll(idx.Get(r, "olm.bundle", "pkg-b", ""))
+	require.NoError(t, err)
+	actual, err := io.ReadAll(binIdx.Get(r, "olm.bundle", "pkg-b", ""))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual))
+
+	_, err = mapBinaryIndex(filepath.Join(t.TempDir(), "missing.bin"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestBinaryIndexReferences(t *testing.T) {
+	idx, _ := newTestIndex(t)
+	var buf bytes.Buffer
+	require.NoError(t, writeBinaryIndex(&buf, idx))
+	binIdx, err := openBinaryIndex(buf.Bytes())
+	require.NoError(t, err)
+	released := 0
+	binIdx.release = func() { released++ }
+
+	require.True(t, binIdx.retain())
+	require.NoError(t, binIdx.Close())
+	require.Equal(t, 0, released, "index released while referenced")
+	require.NoError(t, binIdx.Close())
+	require.Equal(t, 1, released)
+	require.False(t, binIdx.retain(), "released index retained")
+}
+
+func closedMetasChan() <-chan *declcfg.Meta {
+	metasChan := make(chan *declcfg.Meta)
+	close(metasChan)
+	return metasChan
+}
@@ -0,0 +1,37 @@
+//go:build unix
+
+package storage
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// mapBinaryIndex memory-maps the binary index file at path and opens it. The mapping
+// is released once the last reference to the returned index is closed.
+func mapBinaryIndex(path string) (*binaryIndex, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if stat.Size() == 0 {
+		return openBinaryIndex(nil)
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(stat.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("error mapping index %q: %w", path, err)
+	}
+	idx, err := openBinaryIndex(data)
+	if err != nil {
+		_ = syscall.Munmap(data)
+		return nil, err
+	}
+	idx.release = func() { _ = syscall.Munmap(data) }
+	return idx, nil
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package storage
+
+import (
+	"os"
+)
+
+// mapBinaryIndex reads the binary index file at path into memory and opens it, on
+// platforms that do not support memory-mapping files.
+func mapBinaryIndex(path string) (*binaryIndex, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return openBinaryIndex(data)
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	gql "github.com/operator-framework/operator-controller/internal/catalogd/graphql"
+	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
+)
+
+// ContentLimits limit the size and shape of the content of a catalog that is stored,
+// so that a malformed or malicious catalog cannot make catalogd write arbitrarily
+// large files or build arbitrarily large GraphQL schemas. Storing content that
+// exceeds a limit fails with a terminal error with reason LimitExceeded, and leaves
+// the previously stored content in place. A zero limit is not enforced.
+type ContentLimits struct {
+	// MaxMetas is the maximum number of FBC objects of any schema.
+	MaxMetas int
+	// MaxBlobBytes is the maximum size of a single FBC object.
+	MaxBlobBytes int64
+	// MaxPackages is the maximum number of olm.package objects.
+	MaxPackages int
+	// MaxGraphQLFields is the maximum number of fields of the GraphQL schema of the
+	// content, including nested fields. It is only enforced when GraphQL queries are
+	// enabled.
+	MaxGraphQLFields int
+}
+
+// checkMeta checks a meta, and the stats of the content walked up to and including it,
+// against the limits.
+func (l ContentLimits) checkMeta(meta *declcfg.Meta, metas int, stats catalogdmetrics.CatalogContentStats) error {
+	switch {
+	case l.MaxBlobBytes > 0 && int64(len(meta.Blob)) > l.MaxBlobBytes:
+		return limitExceeded("%s object %q of %d bytes exceeds the limit of %d bytes", meta.Schema, meta.Name, len(meta.Blob), l.MaxBlobBytes)
+	case l.MaxMetas > 0 && metas > l.MaxMetas:
+		return limitExceeded("number of objects exceeds the limit of %d", l.MaxMetas)
+	case l.MaxPackages > 0 && stats.Packages > l.MaxPackages:
+		return limitExceeded("number of packages exceeds the limit of %d", l.MaxPackages)
+	}
+	return nil
+}
+
+// checkGraphQLSchema checks the GraphQL schema built for the content against the limits.
+func (l ContentLimits) checkGraphQLSchema(schema *gql.DynamicSchema) error {
+	if l.MaxGraphQLFields <= 0 {
+		return nil
+	}
+	if n := schema.CatalogSchema.FieldCount(); n > l.MaxGraphQLFields {
+		return limitExceeded("GraphQL schema with %d fields exceeds the limit of %d fields", n, l.MaxGraphQLFields)
+	}
+	return nil
+}
+
+func limitExceeded(format string, args ...any) error {
+	return errorutil.NewTerminalError(ocv1.ReasonLimitExceeded, fmt.Errorf("catalog content exceeds limits: "+format, args...))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"net/http"
 	"net/url"
@@ -12,15 +13,22 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 
+	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
 	"github.com/operator-framework/operator-controller/internal/catalogd/server"
 	"github.com/operator-framework/operator-controller/internal/catalogd/service"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
 )
 
 // Re-export enum types and constants from server package for convenience
@@ -40,23 +48,30 @@ const (
 // fs.FS, the content is first written to a temporary file, after which
 // it is copied to its final destination in RootDir/<catalogName>.jsonl. This is
 // done so that clients accessing the content stored in RootDir/<catalogName>.json1
-// have an atomic view of the content for a catalog.
+// have an atomic view of the content for a catalog. Gzip and zstd compressed
+// copies of the content are written alongside it, so that they can be served to
+// clients that accept them without compressing the content on every request.
 type LocalDirV1 struct {
 	RootDir              string
 	RootURL              *url.URL
 	EnableMetasHandler   MetasHandlerMode
 	EnableGraphQLQueries GraphQLQueriesMode
+	// Limits limit the size and shape of the content that is stored.
+	Limits ContentLimits
 
 	m sync.RWMutex
 	// this singleflight Group is used in `GetIndex()` to handle concurrent HTTP requests
-	// optimally. With the use of this singleflight group, the index is loaded from disk
-	// once per concurrent group of HTTP requests being handled by the metas handler.
-	// The single flight instance gives us a way to load the index from disk exactly once
-	// per concurrent group of callers, and then let every concurrent caller have access to
-	// the loaded index. This avoids lots of unnecessary open/decode/close cycles when concurrent
-	// requests are being handled, which improves overall performance and decreases response latency.
+	// optimally. With the use of this singleflight group, the index is mapped from disk
+	// once by the first of a concurrent group of HTTP requests being handled by the metas
+	// handler, after which every caller uses the cached index.
 	sf singleflight.Group
 
+	indexesMu sync.Mutex
+	// indexes caches the memory-mapped binary index of each catalog, so that it is
+	// opened once per stored content rather than once per request. Entries are removed
+	// when the content of a catalog is replaced or deleted.
+	indexes map[string]*binaryIndex
+
 	// GraphQL service for handling schema generation and caching
 	graphqlSvc service.GraphQLService
 }
@@ -79,29 +94,126 @@ func NewLocalDirV1(rootDir string, rootURL *url.URL, enableMetasHandler MetasHan
 	return s
 }
 
-func (s *LocalDirV1) Store(ctx context.Context, catalog string, fsys fs.FS) error {
-	s.m.Lock()
-	defer s.m.Unlock()
+func (s *LocalDirV1) Store(ctx context.Context, catalog string, dgst digest.Digest, fsys fs.FS) error {
+	// Catalog images are often rebuilt without any change to their FBC, which yields a
+	// new image digest for identical content. Rewriting the content in that case would
+	// needlessly rebuild the index and GraphQL schema, and change the modification time
+	// the content is served with, so only the digest is updated.
+	contentDigest, stats, err := fbcContentDigest(ctx, fsys, s.Limits)
+	if err != nil {
+		return fmt.Errorf("error walking FBC root: %w", err)
+	}
+	unchanged, err := s.updateDigestIfContentUnchanged(catalog, contentDigest, dgst)
+	if err != nil {
+		return err
+	}
+	if !unchanged {
+		if err := s.replaceCatalogDir(catalog, func(tmpCatalogDir string) error {
+			if err := s.storeCatalogDir(ctx, catalog, tmpCatalogDir, dgst, fsys); err != nil {
+				return err
+			}
+			return os.WriteFile(catalogContentDigestFilePath(tmpCatalogDir), []byte(contentDigest.String()), 0600)
+		}); err != nil {
+			return err
+		}
+	}
+	catalogdmetrics.RecordCatalogContent(catalog, stats)
+	return nil
+}
 
-	if err := os.MkdirAll(s.RootDir, 0700); err != nil {
+// fbcContentDigest returns the digest of the normalized FBC in fsys, which is the
+// digest of the catalog.jsonl file it is stored as, along with the stats of the FBC.
+// FBC that only differs in its file layout, formatting or YAML/JSON representation has
+// the same content digest. As it walks the FBC before any of it is written, it also
+// checks the FBC against the limits.
+func fbcContentDigest(ctx context.Context, fsys fs.FS, limits ContentLimits) (digest.Digest, catalogdmetrics.CatalogContentStats, error) {
+	var (
+		stats    catalogdmetrics.CatalogContentStats
+		metas    int
+		limitErr error
+	)
+	digester := digest.Canonical.Digester()
+	err := declcfg.WalkMetasFS(ctx, fsys, func(path string, meta *declcfg.Meta, err error) error {
+		if err != nil {
+			return err
+		}
+		switch meta.Schema {
+		case declcfg.SchemaPackage:
+			stats.Packages++
+		case declcfg.SchemaChannel:
+			stats.Channels++
+		case declcfg.SchemaBundle:
+			stats.Bundles++
+		}
+		stats.SizeBytes += int64(len(meta.Blob))
+		metas++
+		if limitErr = limits.checkMeta(meta, metas, stats); limitErr != nil {
+			return limitErr
+		}
+		_, err = digester.Hash().Write(meta.Blob)
 		return err
+	}, declcfg.WithConcurrency(1))
+	if limitErr != nil {
+		return "", stats, limitErr
+	}
+	if err != nil {
+		return "", stats, err
 	}
+	return digester.Digest(), stats, nil
+}
 
-	// Remove any orphaned temporary directories left by previously interrupted Store
-	// operations (e.g. after a process crash where deferred cleanup did not run).
-	if err := s.removeOrphanedTempDirs(catalog); err != nil {
-		return fmt.Errorf("error removing orphaned temp directories: %w", err)
+// updateDigestIfContentUnchanged replaces the stored digest of a catalog with dgst if
+// the complete content stored for it has the given content digest, and reports
+// whether it did.
+func (s *LocalDirV1) updateDigestIfContentUnchanged(catalog string, contentDigest, dgst digest.Digest) (bool, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	catalogDir := s.catalogDir(catalog)
+	storedContentDigest, err := os.ReadFile(catalogContentDigestFilePath(catalogDir))
+	if err != nil || string(storedContentDigest) != contentDigest.String() || !s.contentExists(catalog) {
+		return false, nil
 	}
 
-	tmpCatalogDir, err := os.MkdirTemp(s.RootDir, fmt.Sprintf(".%s-*", catalog))
+	if dgst == "" {
+		if err := os.Remove(catalogDigestFilePath(catalogDir)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+		return true, nil
+	}
+	// The digest is replaced atomically, so that an interrupted update cannot leave
+	// a truncated digest behind.
+	tmpFile, err := os.CreateTemp(catalogDir, ".digest-*")
 	if err != nil {
-		return err
+		return false, err
 	}
-	defer os.RemoveAll(tmpCatalogDir)
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.WriteString(dgst.String()); err != nil {
+		_ = tmpFile.Close()
+		return false, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmpFile.Name(), catalogDigestFilePath(catalogDir)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	storeMetaFuncs := []storeMetasFunc{storeCatalogData}
+// storeCatalogDir writes the catalog content in fsys and all artifacts derived from
+// it to tmpCatalogDir.
+func (s *LocalDirV1) storeCatalogDir(ctx context.Context, catalog string, tmpCatalogDir string, dgst digest.Digest, fsys fs.FS) error {
+	storeMetaFuncs := []storeMetasFunc{storeCatalogData, storeGzipCatalogData, storeZstdCatalogData, storePackageNames}
 	if s.EnableMetasHandler {
-		storeMetaFuncs = append(storeMetaFuncs, storeIndexData)
+		storeMetaFuncs = append(storeMetaFuncs, func(catalogDir string, metas <-chan *declcfg.Meta) error {
+			start := time.Now()
+			if err := storeIndexData(catalogDir, metas); err != nil {
+				return err
+			}
+			catalogdmetrics.RecordIndexBuild(catalog, time.Since(start))
+			return nil
+		})
 	}
 
 	eg, egCtx := errgroup.WithContext(ctx)
@@ -116,7 +228,7 @@ func (s *LocalDirV1) Store(ctx context.Context, catalog string, fsys fs.FS) erro
 			return f(tmpCatalogDir, metaChans[i])
 		})
 	}
-	err = declcfg.WalkMetasFS(egCtx, fsys, func(path string, meta *declcfg.Meta, err error) error {
+	err := declcfg.WalkMetasFS(egCtx, fsys, func(path string, meta *declcfg.Meta, err error) error {
 		if err != nil {
 			return err
 		}
@@ -140,33 +252,69 @@ func (s *LocalDirV1) Store(ctx context.Context, catalog string, fsys fs.FS) erro
 		return err
 	}
 
-	catalogDir := s.catalogDir(catalog)
-	err = errors.Join(
-		os.RemoveAll(catalogDir),
-		os.Rename(tmpCatalogDir, catalogDir),
-	)
+	if err := os.WriteFile(catalogIgnoreFilePath(tmpCatalogDir), []byte(catalogIgnoreData), 0600); err != nil {
+		return err
+	}
+	if dgst != "" {
+		if err := os.WriteFile(catalogDigestFilePath(tmpCatalogDir), []byte(dgst.String()), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceCatalogDir atomically replaces the stored content of a catalog with the
+// content written to a temporary directory by populate.
+func (s *LocalDirV1) replaceCatalogDir(catalog string, populate func(tmpCatalogDir string) error) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if err := os.MkdirAll(s.RootDir, 0700); err != nil {
+		return err
+	}
+
+	// Remove any orphaned temporary directories left by previously interrupted Store
+	// operations (e.g. after a process crash where deferred cleanup did not run).
+	if err := s.removeOrphanedTempDirs(catalog); err != nil {
+		return fmt.Errorf("error removing orphaned temp directories: %w", err)
+	}
+
+	tmpCatalogDir, err := os.MkdirTemp(s.RootDir, fmt.Sprintf(".%s-*", catalog))
 	if err != nil {
 		return err
 	}
+	defer os.RemoveAll(tmpCatalogDir)
+
+	if err := populate(tmpCatalogDir); err != nil {
+		return err
+	}
 
-	// Invalidate and pre-warm GraphQL schema cache if GraphQL service is enabled
+	// Build the GraphQL schema from the new content before replacing the stored content,
+	// so that content the schema cannot be built for, or whose schema exceeds the limits,
+	// is never served and the previously stored content is retained.
 	if s.graphqlSvc != nil {
 		s.graphqlSvc.InvalidateCache(catalog)
-
-		// Pre-warm the GraphQL schema cache using the newly created catalog directory
-		// Use the actual catalog directory filesystem, not the input fsys
-		catalogFS := os.DirFS(catalogDir)
-		if _, err := s.graphqlSvc.GetSchema(catalog, catalogFS); err != nil {
-			// Schema build failed - rollback by removing the catalog directory
-			// to maintain consistency (don't persist catalog without valid schema)
-			if removeErr := os.RemoveAll(catalogDir); removeErr != nil {
-				return fmt.Errorf("failed to pre-build GraphQL schema for catalog %q: %w (rollback also failed: %v)", catalog, err, removeErr)
-			}
+		schema, err := s.graphqlSvc.GetSchema(catalog, os.DirFS(tmpCatalogDir))
+		if err != nil {
+			s.graphqlSvc.InvalidateCache(catalog)
 			return fmt.Errorf("failed to pre-build GraphQL schema for catalog %q: %w", catalog, err)
 		}
+		if err := s.Limits.checkGraphQLSchema(schema); err != nil {
+			s.graphqlSvc.InvalidateCache(catalog)
+			return err
+		}
 	}
 
-	return nil
+	catalogDir := s.catalogDir(catalog)
+	s.forgetIndex(catalog)
+	err = errors.Join(
+		os.RemoveAll(catalogDir),
+		os.Rename(tmpCatalogDir, catalogDir),
+	)
+	if err != nil && s.graphqlSvc != nil {
+		s.graphqlSvc.InvalidateCache(catalog)
+	}
+	return err
 }
 
 // removeOrphanedTempDirs removes temporary staging directories that were created by a
@@ -201,6 +349,8 @@ func (s *LocalDirV1) Delete(catalog string) error {
 	if s.graphqlSvc != nil {
 		s.graphqlSvc.InvalidateCache(catalog)
 	}
+	s.forgetIndex(catalog)
+	catalogdmetrics.DeleteCatalog(catalog)
 
 	return os.RemoveAll(s.catalogDir(catalog))
 }
@@ -208,7 +358,12 @@ func (s *LocalDirV1) Delete(catalog string) error {
 func (s *LocalDirV1) ContentExists(catalog string) bool {
 	s.m.RLock()
 	defer s.m.RUnlock()
+	return s.contentExists(catalog)
+}
 
+// contentExists reports whether complete content is stored for a catalog. It must be
+// called while holding the lock.
+func (s *LocalDirV1) contentExists(catalog string) bool {
 	catalogFileStat, err := os.Stat(catalogFilePath(s.catalogDir(catalog)))
 	if err != nil {
 		return false
@@ -239,7 +394,42 @@ func catalogFilePath(catalogDir string) string {
 }
 
 func catalogIndexFilePath(catalogDir string) string {
-	return filepath.Join(catalogDir, "index.json")
+	return filepath.Join(catalogDir, "index.bin")
+}
+
+func catalogPackagesFilePath(catalogDir string) string {
+	return filepath.Join(catalogDir, "packages.json")
+}
+
+// catalogIgnoreData is written to the .indexignore file of a catalog directory so that
+// consumers walking the directory as FBC (e.g. the GraphQL schema builder) only read
+// catalog.jsonl, and skip the index, digest, package names and compressed copies stored
+// next to it.
+const catalogIgnoreData = `*
+!catalog.jsonl
+`
+
+func catalogIgnoreFilePath(catalogDir string) string {
+	return filepath.Join(catalogDir, ".indexignore")
+}
+
+func catalogDigestFilePath(catalogDir string) string {
+	return filepath.Join(catalogDir, "digest")
+}
+
+func catalogContentDigestFilePath(catalogDir string) string {
+	return filepath.Join(catalogDir, "content-digest")
+}
+
+func encodedCatalogFilePath(catalogDir string, encoding string) (string, error) {
+	switch encoding {
+	case server.EncodingGzip:
+		return catalogFilePath(catalogDir) + ".gz", nil
+	case server.EncodingZstd:
+		return catalogFilePath(catalogDir) + ".zst", nil
+	default:
+		return "", fmt.Errorf("unsupported content encoding %q: %w", encoding, fs.ErrNotExist)
+	}
 }
 
 type storeMetasFunc func(catalogDir string, metaChan <-chan *declcfg.Meta) error
@@ -259,6 +449,57 @@ func storeCatalogData(catalogDir string, metas <-chan *declcfg.Meta) error {
 	return nil
 }
 
+func storeGzipCatalogData(catalogDir string, metas <-chan *declcfg.Meta) error {
+	return storeEncodedCatalogData(catalogDir, server.EncodingGzip, metas, func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriterLevel(w, gzip.BestCompression)
+	})
+}
+
+func storeZstdCatalogData(catalogDir string, metas <-chan *declcfg.Meta) error {
+	return storeEncodedCatalogData(catalogDir, server.EncodingZstd, metas, func(w io.Writer) (io.WriteCloser, error) {
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	})
+}
+
+func storeEncodedCatalogData(catalogDir string, encoding string, metas <-chan *declcfg.Meta, newWriter func(io.Writer) (io.WriteCloser, error)) error {
+	path, err := encodedCatalogFilePath(catalogDir, encoding)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cw, err := newWriter(f)
+	if err != nil {
+		return err
+	}
+	for m := range metas {
+		if _, err := cw.Write(m.Blob); err != nil {
+			return err
+		}
+	}
+	return cw.Close()
+}
+
+// storePackageNames writes the sorted names of the packages of a catalog, so that they
+// can be read without walking its content.
+func storePackageNames(catalogDir string, metas <-chan *declcfg.Meta) error {
+	names := sets.New[string]()
+	for m := range metas {
+		if m.Schema == declcfg.SchemaPackage {
+			names.Insert(m.Name)
+		}
+	}
+	data, err := json.Marshal(sets.List(names))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(catalogPackagesFilePath(catalogDir), data, 0600)
+}
+
 func storeIndexData(catalogDir string, metas <-chan *declcfg.Meta) error {
 	idx := newIndex(metas)
 
@@ -268,12 +509,19 @@ func storeIndexData(catalogDir string, metas <-chan *declcfg.Meta) error {
 	}
 	defer f.Close()
 
-	enc := json.NewEncoder(f)
-	enc.SetEscapeHTML(false)
-	return enc.Encode(idx)
+	if err := writeBinaryIndex(f, idx); err != nil {
+		return err
+	}
+	return f.Close()
 }
 
+// BaseURL returns the URL under which the content of a catalog is served, which is
+// <root>/<catalog> for ClusterCatalogs and <root>/namespaces/<namespace>/<catalog> for
+// namespaced Catalogs, whose key is passed as catalog.
 func (s *LocalDirV1) BaseURL(catalog string) string {
+	if namespace, name, ok := catalogutil.SplitNamespacedKey(catalog); ok {
+		return s.RootURL.JoinPath("namespaces", namespace, name).String()
+	}
 	return s.RootURL.JoinPath(catalog).String()
 }
 
@@ -304,6 +552,95 @@ func (s *LocalDirV1) GetCatalogData(catalog string) (*os.File, os.FileInfo, erro
 	return catalogFile, catalogFileStat, nil
 }
 
+// GetEncodedCatalogData returns the catalog file compressed with the given content
+// encoding and its metadata
+// Implements server.CatalogStore interface
+func (s *LocalDirV1) GetEncodedCatalogData(catalog string, encoding string) (*os.File, os.FileInfo, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	path, err := encodedCatalogFilePath(s.catalogDir(catalog), encoding)
+	if err != nil {
+		return nil, nil, err
+	}
+	encodedFile, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	encodedFileStat, err := encodedFile.Stat()
+	if err != nil {
+		if closeErr := encodedFile.Close(); closeErr != nil {
+			klog.ErrorS(closeErr, "failed to close encoded catalog file after stat error")
+		}
+		return nil, nil, err
+	}
+	return encodedFile, encodedFileStat, nil
+}
+
+// GetCatalogDigest returns the digest of the image the catalog was resolved from
+// Implements server.CatalogStore interface
+func (s *LocalDirV1) GetCatalogDigest(catalog string) (digest.Digest, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	data, err := os.ReadFile(catalogDigestFilePath(s.catalogDir(catalog)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	dgst, err := digest.Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return "", fmt.Errorf("invalid digest stored for catalog %q: %w", catalog, err)
+	}
+	return dgst, nil
+}
+
+// GetPackageNames returns the sorted names of the packages of the stored content of a
+// catalog. The names are read from the content itself if it was stored without them.
+func (s *LocalDirV1) GetPackageNames(catalog string) ([]string, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	catalogDir := s.catalogDir(catalog)
+	data, err := os.ReadFile(catalogPackagesFilePath(catalogDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return readPackageNames(catalogFilePath(catalogDir))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("invalid package names stored for catalog %q: %w", catalog, err)
+	}
+	return names, nil
+}
+
+// readPackageNames returns the sorted names of the packages of a catalog file.
+func readPackageNames(catalogFile string) ([]string, error) {
+	f, err := os.Open(catalogFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	names := sets.New[string]()
+	if err := declcfg.WalkMetasReader(f, func(m *declcfg.Meta, err error) error {
+		if err != nil {
+			return err
+		}
+		if m.Schema == declcfg.SchemaPackage {
+			names.Insert(m.Name)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return sets.List(names), nil
+}
+
 // GetCatalogFS returns a filesystem interface for the catalog
 // Implements server.CatalogStore interface
 func (s *LocalDirV1) GetCatalogFS(catalog string) (fs.FS, error) {
@@ -324,26 +661,62 @@ func (s *LocalDirV1) GetCatalogFS(catalog string) (fs.FS, error) {
 	return os.DirFS(catalogDir), nil
 }
 
-// GetIndex returns the index for a catalog
+// GetIndex returns the index for a catalog, which the caller must close once it is
+// no longer used.
 // Implements server.CatalogStore interface
 func (s *LocalDirV1) GetIndex(catalog string) (server.Index, error) {
 	s.m.RLock()
 	defer s.m.RUnlock()
 
-	idx, err, _ := s.sf.Do(catalog, func() (interface{}, error) {
-		indexFile, err := os.Open(catalogIndexFilePath(s.catalogDir(catalog)))
+	if idx := s.retainIndex(catalog); idx != nil {
+		return idx, nil
+	}
+
+	_, err, _ := s.sf.Do(catalog, func() (interface{}, error) {
+		idx, err := mapBinaryIndex(catalogIndexFilePath(s.catalogDir(catalog)))
 		if err != nil {
 			return nil, err
 		}
-		defer indexFile.Close()
-		var idx index
-		if err := json.NewDecoder(indexFile).Decode(&idx); err != nil {
-			return nil, err
+		s.indexesMu.Lock()
+		defer s.indexesMu.Unlock()
+		if s.indexes == nil {
+			s.indexes = map[string]*binaryIndex{}
 		}
-		return &idx, nil
+		if previous, ok := s.indexes[catalog]; ok {
+			_ = previous.Close()
+		}
+		s.indexes[catalog] = idx
+		return nil, nil
 	})
 	if err != nil {
 		return nil, err
 	}
-	return idx.(*index), nil
+	if idx := s.retainIndex(catalog); idx != nil {
+		return idx, nil
+	}
+	return nil, fmt.Errorf("index of catalog %q was replaced while loading it", catalog)
+}
+
+// retainIndex returns the cached index of a catalog with a reference added for the
+// caller, or nil if no index is cached.
+func (s *LocalDirV1) retainIndex(catalog string) *binaryIndex {
+	s.indexesMu.Lock()
+	defer s.indexesMu.Unlock()
+	idx, ok := s.indexes[catalog]
+	if !ok || !idx.retain() {
+		return nil
+	}
+	return idx
+}
+
+// forgetIndex removes the cached index of a catalog and drops the reference of the
+// cache to it. The index remains valid for callers still using it, and is released
+// once they close it.
+func (s *LocalDirV1) forgetIndex(catalog string) {
+	s.indexesMu.Lock()
+	defer s.indexesMu.Unlock()
+	if idx, ok := s.indexes[catalog]; ok {
+		_ = idx.Close()
+		delete(s.indexes, catalog)
+	}
 }
@@ -16,15 +16,25 @@ import (
 	"sync"
 	"testing"
 	"testing/fstest"
+	"time"
 
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 )
 
 const urlPrefix = "/catalogs/"
 
+var testDigest = digest.FromString("test-catalog-image")
+
 func TestLocalDirStorage(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -52,7 +62,7 @@ func TestLocalDirStorage(t *testing.T) {
 				}
 
 				// Store the content
-				if err := s.Store(context.Background(), catalog, fsys); err != nil {
+				if err := s.Store(context.Background(), catalog, testDigest, fsys); err != nil {
 					t.Fatal(err)
 				}
 
@@ -84,7 +94,7 @@ func TestLocalDirStorage(t *testing.T) {
 				return s, createTestFS(t)
 			},
 			test: func(t *testing.T, s *LocalDirV1, fsys fs.FS) {
-				err := s.Store(context.Background(), "test-catalog", fsys)
+				err := s.Store(context.Background(), "test-catalog", testDigest, fsys)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -123,7 +133,7 @@ func TestLocalDirStorage(t *testing.T) {
 				}
 
 				// Write while readers are active
-				err := s.Store(context.Background(), catalog, fsys)
+				err := s.Store(context.Background(), catalog, testDigest, fsys)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -167,7 +177,7 @@ func TestLocalDirStorage(t *testing.T) {
 			test: func(t *testing.T, s *LocalDirV1, fsys fs.FS) {
 				const catalog = "test-catalog"
 
-				if err := s.Store(context.Background(), catalog, fsys); err != nil {
+				if err := s.Store(context.Background(), catalog, testDigest, fsys); err != nil {
 					t.Fatalf("Store failed: %v", err)
 				}
 
@@ -219,7 +229,7 @@ func TestLocalDirStorage(t *testing.T) {
 				return NewLocalDirV1(dir, nil, MetasHandlerDisabled, GraphQLQueriesDisabled), createTestFS(t)
 			},
 			test: func(t *testing.T, s *LocalDirV1, fsys fs.FS) {
-				err := s.Store(context.Background(), "test-catalog", fsys)
+				err := s.Store(context.Background(), "test-catalog", testDigest, fsys)
 				if !errors.Is(err, fs.ErrPermission) {
 					t.Errorf("expected permission error, got: %v", err)
 				}
@@ -244,7 +254,7 @@ func TestLocalDirStorage(t *testing.T) {
 
 func TestLocalDirServerHandler(t *testing.T) {
 	store := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerDisabled, GraphQLQueriesDisabled)
-	if store.Store(context.Background(), "test-catalog", createTestFS(t)) != nil {
+	if store.Store(context.Background(), "test-catalog", testDigest, createTestFS(t)) != nil {
 		t.Fatal("failed to store test catalog and start server")
 	}
 
@@ -341,6 +351,58 @@ func TestLocalDirServerHandler(t *testing.T) {
 	}
 }
 
+func TestLocalDirNamespacedCatalog(t *testing.T) {
+	store := NewLocalDirV1(t.TempDir(), &url.URL{Scheme: "https", Host: "catalogd-service.olmv1-system.svc", Path: urlPrefix}, MetasHandlerDisabled, GraphQLQueriesDisabled)
+	key := catalogutil.NamespacedKey("team-a", "test-catalog")
+	require.NoError(t, store.Store(context.Background(), key, testDigest, createTestFS(t)))
+	require.True(t, store.ContentExists(key))
+	require.False(t, store.ContentExists("test-catalog"))
+	require.Equal(t, "https://catalogd-service.olmv1-system.svc/catalogs/namespaces/team-a/test-catalog", store.BaseURL(key))
+	require.Equal(t, "https://catalogd-service.olmv1-system.svc/catalogs/test-catalog", store.BaseURL("test-catalog"))
+
+	testServer := httptest.NewServer(store.StorageServerHandler())
+	defer testServer.Close()
+
+	for _, tc := range []struct {
+		name               string
+		URLPath            string
+		expectedStatusCode int
+	}{
+		{
+			name:               "content of a namespaced catalog is served under its namespace",
+			URLPath:            "/catalogs/namespaces/team-a/test-catalog/api/v1/all",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "catalog with the same name in another namespace is not found",
+			URLPath:            "/catalogs/namespaces/team-b/test-catalog/api/v1/all",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "ClusterCatalog with the same name is not found",
+			URLPath:            "/catalogs/test-catalog/api/v1/all",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "namespaced catalog cannot be requested by its key",
+			URLPath:            "/catalogs/" + key + "/api/v1/all",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "invalid namespace is rejected",
+			URLPath:            "/catalogs/namespaces/team.a/test-catalog/api/v1/all",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(testServer.URL + tc.URLPath)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
 // Tests to verify the behavior of the metas endpoint, as described in
 // https://docs.google.com/document/d/1s6_9IFEKGQLNh3ueH7SF4Yrx4PW9NSiNFqFIJx0pU-8/
 func TestMetasEndpoint(t *testing.T) {
@@ -350,7 +412,7 @@ func TestMetasEndpoint(t *testing.T) {
 		MetasHandlerEnabled,
 		GraphQLQueriesDisabled,
 	)
-	if store.Store(context.Background(), "test-catalog", createTestFS(t)) != nil {
+	if store.Store(context.Background(), "test-catalog", testDigest, createTestFS(t)) != nil {
 		t.Fatal("failed to store test catalog")
 	}
 	testServer := httptest.NewServer(store.StorageServerHandler())
@@ -488,6 +550,335 @@ func TestMetasEndpoint(t *testing.T) {
 	}
 }
 
+func TestContentEncodingAndETags(t *testing.T) {
+	store := NewLocalDirV1(
+		t.TempDir(),
+		&url.URL{Path: urlPrefix},
+		MetasHandlerEnabled,
+		GraphQLQueriesEnabled,
+	)
+
+	// The catalog must be large enough for its pre-compressed representations to be served
+	largeFS := fstest.MapFS{}
+	for i := 0; i < 100; i++ {
+		largeFS[fmt.Sprintf("meta_%d.json", i)] = &fstest.MapFile{
+			Data: []byte(fmt.Sprintf(`{"schema":"olm.bundle","package":"test-op-%d","name":"test-op.v%d.0"}`, i, i)),
+		}
+	}
+	require.NoError(t, store.Store(context.Background(), "test-catalog", testDigest, largeFS))
+
+	// GraphQL schemas are built from the catalog directory, which must only expose the FBC itself
+	catalogFS, err := store.GetCatalogFS("test-catalog")
+	require.NoError(t, err)
+	cfg, err := declcfg.LoadFS(context.Background(), catalogFS)
+	require.NoError(t, err)
+	require.Len(t, cfg.Bundles, 100)
+
+	expectedContent, err := os.ReadFile(catalogFilePath(store.catalogDir("test-catalog")))
+	require.NoError(t, err)
+
+	testServer := httptest.NewServer(store.StorageServerHandler())
+	defer testServer.Close()
+	// Disable transparent decompression so that responses are observed as sent
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+
+	decode := func(t *testing.T, encoding string, body io.Reader) []byte {
+		t.Helper()
+		var r io.Reader = body
+		switch encoding {
+		case "gzip":
+			gr, err := gzip.NewReader(body)
+			require.NoError(t, err)
+			defer gr.Close()
+			r = gr
+		case "zstd":
+			zr, err := zstd.NewReader(body)
+			require.NoError(t, err)
+			defer zr.Close()
+			r = zr
+		}
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return data
+	}
+
+	for _, tc := range []struct {
+		name             string
+		path             string
+		acceptEncoding   string
+		expectedEncoding string
+	}{
+		{name: "all without Accept-Encoding", path: "all", expectedEncoding: ""},
+		{name: "all with gzip", path: "all", acceptEncoding: "gzip", expectedEncoding: "gzip"},
+		{name: "all with zstd", path: "all", acceptEncoding: "zstd", expectedEncoding: "zstd"},
+		{name: "all prefers zstd", path: "all", acceptEncoding: "gzip, zstd", expectedEncoding: "zstd"},
+		{name: "all honours q-values", path: "all", acceptEncoding: "gzip;q=0.5, zstd;q=0", expectedEncoding: "gzip"},
+		{name: "all with unsupported encoding", path: "all", acceptEncoding: "br", expectedEncoding: ""},
+		{name: "all with wildcard", path: "all", acceptEncoding: "*", expectedEncoding: "zstd"},
+		{name: "unfiltered metas with gzip", path: "metas", acceptEncoding: "gzip", expectedEncoding: "gzip"},
+		{name: "unfiltered metas with zstd", path: "metas", acceptEncoding: "zstd", expectedEncoding: "zstd"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reqURL := fmt.Sprintf("%s/catalogs/test-catalog/api/v1/%s", testServer.URL, tc.path)
+			req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, tc.expectedEncoding, resp.Header.Get("Content-Encoding"))
+			require.Contains(t, resp.Header.Values("Vary"), "Accept-Encoding")
+			expectedETag := fmt.Sprintf(`"%s"`, testDigest)
+			if tc.expectedEncoding != "" {
+				expectedETag = fmt.Sprintf(`"%s-%s"`, testDigest, tc.expectedEncoding)
+			}
+			etag := resp.Header.Get("Etag")
+			require.Equal(t, expectedETag, etag)
+			require.Equal(t, string(expectedContent), string(decode(t, tc.expectedEncoding, resp.Body)))
+
+			// Revalidating with the returned ETag must not transfer the content again
+			req.Header.Set("If-None-Match", etag)
+			resp, err = client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+			// A stale ETag must result in the full content being returned
+			req.Header.Set("If-None-Match", `"sha256:stale"`)
+			resp, err = client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+
+	t.Run("range request for pre-compressed content", func(t *testing.T) {
+		gzipContent, err := os.ReadFile(catalogFilePath(store.catalogDir("test-catalog")) + ".gz")
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/catalogs/test-catalog/api/v1/all", testServer.URL), nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept-Encoding", "gzip")
+		req.Header.Set("Range", "bytes=10-19")
+		req.Header.Set("If-Range", fmt.Sprintf(`"%s-gzip"`, testDigest))
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		require.Equal(t, fmt.Sprintf("bytes 10-19/%d", len(gzipContent)), resp.Header.Get("Content-Range"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, gzipContent[10:20], body)
+	})
+
+	for _, tc := range []struct {
+		name           string
+		acceptEncoding string
+		ifNoneMatch    string
+		expectedStatus int
+	}{
+		{name: "gzip ETag with gzip", acceptEncoding: "gzip", ifNoneMatch: "gzip", expectedStatus: http.StatusNotModified},
+		{name: "zstd ETag with zstd", acceptEncoding: "zstd", ifNoneMatch: "zstd", expectedStatus: http.StatusNotModified},
+		{name: "identity ETag without Accept-Encoding", ifNoneMatch: "", expectedStatus: http.StatusNotModified},
+		{name: "gzip ETag without Accept-Encoding", ifNoneMatch: "gzip", expectedStatus: http.StatusOK},
+		{name: "gzip ETag with zstd", acceptEncoding: "zstd", ifNoneMatch: "gzip", expectedStatus: http.StatusOK},
+		{name: "zstd ETag with gzip", acceptEncoding: "gzip", ifNoneMatch: "zstd", expectedStatus: http.StatusOK},
+	} {
+		t.Run("filtered metas revalidated with "+tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/catalogs/test-catalog/api/v1/metas?package=test-op-1", testServer.URL), nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			etag := fmt.Sprintf(`"%s"`, testDigest)
+			if tc.ifNoneMatch != "" {
+				etag = fmt.Sprintf(`"%s-%s"`, testDigest, tc.ifNoneMatch)
+			}
+			req.Header.Set("If-None-Match", etag)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tc.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestLocalDirIndexOutlivesDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerEnabled, GraphQLQueriesDisabled)
+	content := `{"schema":"olm.package","name":"foo"}` + "\n" + `{"schema":"olm.bundle","package":"foo","name":"foo.v1.0.0"}` + "\n"
+	require.NoError(t, store.Store(ctx, "test-catalog", testDigest, fstest.MapFS{
+		"catalog.json": &fstest.MapFile{Data: []byte(content)},
+	}))
+
+	idx, err := store.GetIndex("test-catalog")
+	require.NoError(t, err)
+	defer idx.Close()
+
+	// The index remains usable by queries that started before the catalog was deleted.
+	require.NoError(t, store.Delete("test-catalog"))
+	bundles, err := io.ReadAll(idx.Get(strings.NewReader(content), "olm.bundle", "foo", ""))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"schema":"olm.bundle","package":"foo","name":"foo.v1.0.0"}`, string(bundles))
+}
+
+func TestLocalDirStoreUnchangedContent(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerEnabled, GraphQLQueriesDisabled)
+	catalogDir := store.catalogDir("test-catalog")
+
+	original := fstest.MapFS{
+		"catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"foo"}` + "\n" + `{"schema":"olm.bundle","package":"foo","name":"foo.v1.0.0"}`)},
+	}
+	require.NoError(t, store.Store(ctx, "test-catalog", testDigest, original))
+
+	// Backdate the stored content, so that rewriting it is detected regardless of the
+	// resolution of file modification times.
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(catalogFilePath(catalogDir), modTime, modTime))
+	storedModTime := func(t *testing.T) time.Time {
+		stat, err := os.Stat(catalogFilePath(catalogDir))
+		require.NoError(t, err)
+		return stat.ModTime()
+	}
+
+	t.Run("identical content in a different layout only updates the digest", func(t *testing.T) {
+		relaid := fstest.MapFS{
+			"foo/a-package.json": &fstest.MapFile{Data: []byte("{\n  \"schema\": \"olm.package\",\n  \"name\": \"foo\"\n}\n")},
+			"foo/b-bundle.yaml":  &fstest.MapFile{Data: []byte("schema: olm.bundle\npackage: foo\nname: foo.v1.0.0\n")},
+		}
+		newDigest := digest.FromString("rebuilt-test-catalog-image")
+		require.NoError(t, store.Store(ctx, "test-catalog", newDigest, relaid))
+
+		dgst, err := store.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Equal(t, newDigest, dgst)
+		require.Equal(t, modTime, storedModTime(t))
+		require.True(t, store.ContentExists("test-catalog"))
+	})
+
+	t.Run("changed content is stored", func(t *testing.T) {
+		changed := fstest.MapFS{
+			"catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"bar"}`)},
+		}
+		require.NoError(t, store.Store(ctx, "test-catalog", testDigest, changed))
+
+		dgst, err := store.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Equal(t, testDigest, dgst)
+		require.NotEqual(t, modTime, storedModTime(t))
+		content, err := os.ReadFile(catalogFilePath(catalogDir))
+		require.NoError(t, err)
+		require.Contains(t, string(content), `"bar"`)
+	})
+
+	t.Run("incomplete content is stored again", func(t *testing.T) {
+		require.NoError(t, os.Remove(catalogIndexFilePath(catalogDir)))
+		changed := fstest.MapFS{
+			"catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"bar"}`)},
+		}
+		require.NoError(t, store.Store(ctx, "test-catalog", testDigest, changed))
+		require.True(t, store.ContentExists("test-catalog"))
+	})
+}
+
+func TestLocalDirPackageNames(t *testing.T) {
+	store := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerDisabled, GraphQLQueriesDisabled)
+	fsys := fstest.MapFS{
+		"catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"foo"}` + "\n" +
+			`{"schema":"olm.bundle","package":"foo","name":"foo.v1.0.0"}` + "\n" +
+			`{"schema":"olm.package","name":"bar"}`)},
+	}
+	require.NoError(t, store.Store(context.Background(), "test-catalog", testDigest, fsys))
+
+	names, err := store.GetPackageNames("test-catalog")
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar", "foo"}, names)
+
+	// Content stored without package names is read instead.
+	require.NoError(t, os.Remove(catalogPackagesFilePath(store.catalogDir("test-catalog"))))
+	names, err = store.GetPackageNames("test-catalog")
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar", "foo"}, names)
+
+	_, err = store.GetPackageNames("missing-catalog")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestFBCContentStats(t *testing.T) {
+	fsys := fstest.MapFS{
+		"foo/catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"foo"}
+{"schema":"olm.channel","package":"foo","name":"stable","entries":[{"name":"foo.v1.0.0"}]}
+{"schema":"olm.channel","package":"foo","name":"fast","entries":[{"name":"foo.v1.0.0"}]}
+{"schema":"olm.bundle","package":"foo","name":"foo.v1.0.0"}
+{"schema":"olm.deprecations","package":"foo"}
+`)},
+		"bar/catalog.yaml": &fstest.MapFile{Data: []byte("schema: olm.package\nname: bar\n")},
+	}
+	store := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerDisabled, GraphQLQueriesDisabled)
+	require.NoError(t, store.Store(context.Background(), "test-catalog", testDigest, fsys))
+
+	_, stats, err := fbcContentDigest(context.Background(), fsys, ContentLimits{})
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Packages)
+	require.Equal(t, 2, stats.Channels)
+	require.Equal(t, 1, stats.Bundles)
+
+	stat, err := os.Stat(catalogFilePath(store.catalogDir("test-catalog")))
+	require.NoError(t, err)
+	require.Equal(t, stat.Size(), stats.SizeBytes)
+}
+
+func TestLocalDirContentLimits(t *testing.T) {
+	stored := fstest.MapFS{
+		"catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"foo"}`)},
+	}
+	exceeding := fstest.MapFS{
+		"catalog.json": &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"foo"}
+{"schema":"olm.package","name":"bar"}
+{"schema":"olm.bundle","package":"bar","name":"bar.v1.0.0","properties":[{"type":"olm.package","value":{"packageName":"bar","version":"1.0.0"}}]}
+`)},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		limits  ContentLimits
+		graphql GraphQLQueriesMode
+		wantErr string
+	}{
+		{name: "metas", limits: ContentLimits{MaxMetas: 2}, wantErr: "number of objects exceeds the limit of 2"},
+		{name: "blob size", limits: ContentLimits{MaxBlobBytes: 64}, wantErr: `olm.bundle object "bar.v1.0.0" of 146 bytes exceeds the limit of 64 bytes`},
+		{name: "packages", limits: ContentLimits{MaxPackages: 1}, wantErr: "number of packages exceeds the limit of 1"},
+		{name: "GraphQL fields", limits: ContentLimits{MaxGraphQLFields: 4}, graphql: GraphQLQueriesEnabled, wantErr: "exceeds the limit of 4 fields"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerDisabled, tc.graphql)
+			store.Limits = tc.limits
+			require.NoError(t, store.Store(context.Background(), "test-catalog", testDigest, stored))
+
+			err := store.Store(context.Background(), "test-catalog", digest.FromString("exceeding"), exceeding)
+			require.ErrorContains(t, err, tc.wantErr)
+			reason, ok := errorutil.ExtractTerminalReason(err)
+			require.True(t, ok)
+			require.Equal(t, ocv1.ReasonLimitExceeded, reason)
+
+			// The previously stored content is still served.
+			require.True(t, store.ContentExists("test-catalog"))
+			names, err := store.GetPackageNames("test-catalog")
+			require.NoError(t, err)
+			require.Equal(t, []string{"foo"}, names)
+			dgst, err := os.ReadFile(catalogDigestFilePath(store.catalogDir("test-catalog")))
+			require.NoError(t, err)
+			require.Equal(t, testDigest.String(), string(dgst))
+
+			// Content is stored once the limits are lifted.
+			store.Limits = ContentLimits{}
+			require.NoError(t, store.Store(context.Background(), "test-catalog", testDigest, exceeding))
+		})
+	}
+}
+
 func TestServerLoadHandling(t *testing.T) {
 	store := NewLocalDirV1(
 		t.TempDir(),
@@ -504,7 +895,7 @@ func TestServerLoadHandling(t *testing.T) {
 		}
 	}
 
-	if err := store.Store(context.Background(), "test-catalog", largeFS); err != nil {
+	if err := store.Store(context.Background(), "test-catalog", testDigest, largeFS); err != nil {
 		t.Fatal("failed to store test catalog")
 	}
 
@@ -0,0 +1,424 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/operator-framework/operator-controller/internal/catalogd/server"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage/s3"
+)
+
+// ObjectBucket is a bucket of an object store that catalog content is stored in.
+type ObjectBucket interface {
+	PutObject(ctx context.Context, key string, body io.Reader, size int64, info s3.ObjectInfo) error
+	// GetObject returns an error wrapping fs.ErrNotExist if the object does not exist.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	DeleteObject(ctx context.Context, key string) error
+	PresignGetObject(key string, expires time.Duration) (*url.URL, error)
+}
+
+var _ ObjectBucket = (*s3.Client)(nil)
+
+// ObjectContentMode controls how requests for the full content of a catalog are
+// served by an ObjectStoreV1.
+type ObjectContentMode string
+
+const (
+	// ObjectContentProxy serves content from the local copy of the object store content.
+	ObjectContentProxy ObjectContentMode = "proxy"
+	// ObjectContentRedirect redirects clients to a presigned URL of the content in
+	// the object store, so that the content is not transferred through catalogd.
+	ObjectContentRedirect ObjectContentMode = "redirect"
+)
+
+const (
+	// objectCurrentDigestKey is the key, relative to a catalog's prefix, of the object
+	// containing the digest of the catalog's current content. It is written after all
+	// of the content's objects, and so acts as the commit marker of a Store.
+	objectCurrentDigestKey = "current"
+	// objectPreviousDigestKey is the key, relative to a catalog's prefix, of the object
+	// containing the digest of the content that was current before the current content.
+	// That content is kept until the next Store, so that replicas still downloading it
+	// when it is replaced can finish doing so.
+	objectPreviousDigestKey = "previous"
+	// presignedURLExpiry is how long the presigned URLs clients are redirected to are valid.
+	presignedURLExpiry = 5 * time.Minute
+)
+
+// ObjectStoreV1 is a storage Instance that stores catalog content in a bucket of an
+// S3-compatible object store, so that content survives restarts of catalogd and is
+// shared by all replicas without each of them unpacking the catalog image.
+//
+// The catalog JSONL, its index and all derived artifacts are produced by a LocalDirV1
+// and uploaded under <Prefix><catalog>/<digest>/. The local directory doubles as a
+// cache of the object store content: content stored by another replica, or before a
+// restart, is downloaded into it by SyncLocal, and content is only ever served from
+// local disk like LocalDirV1 content, so that serving it never waits on the object store.
+type ObjectStoreV1 struct {
+	Bucket      ObjectBucket
+	Prefix      string
+	ContentMode ObjectContentMode
+	Local       *LocalDirV1
+
+	// sf deduplicates concurrent downloads of the content of a catalog.
+	sf singleflight.Group
+}
+
+var (
+	_ Instance                        = (*ObjectStoreV1)(nil)
+	_ LocalEvicter                    = (*ObjectStoreV1)(nil)
+	_ LocalSyncer                     = (*ObjectStoreV1)(nil)
+	_ server.CatalogStore             = (*ObjectStoreV1)(nil)
+	_ server.CatalogContentRedirector = (*ObjectStoreV1)(nil)
+)
+
+// NewObjectStoreV1 creates a new ObjectStoreV1 storage instance that stores content
+// under prefix in bucket, and keeps a local copy of it in local.
+func NewObjectStoreV1(bucket ObjectBucket, prefix string, contentMode ObjectContentMode, local *LocalDirV1) *ObjectStoreV1 {
+	return &ObjectStoreV1{
+		Bucket:      bucket,
+		Prefix:      prefix,
+		ContentMode: contentMode,
+		Local:       local,
+	}
+}
+
+// Store stores the content locally and then uploads it to the object store. The
+// content it replaces is kept as the previous content of the catalog, and the
+// content that was previous before is deleted.
+func (s *ObjectStoreV1) Store(ctx context.Context, catalog string, dgst digest.Digest, fsys fs.FS) error {
+	if err := dgst.Validate(); err != nil {
+		return fmt.Errorf("invalid digest for catalog %q: %w", catalog, err)
+	}
+	current, err := s.currentDigest(ctx, catalog)
+	if err != nil {
+		return err
+	}
+	previous, err := s.readDigest(ctx, catalog, objectPreviousDigestKey)
+	if err != nil {
+		return err
+	}
+	if err := s.Local.Store(ctx, catalog, dgst, fsys); err != nil {
+		return err
+	}
+	if current != "" && current != dgst {
+		if err := s.putDigest(ctx, catalog, objectPreviousDigestKey, current); err != nil {
+			return err
+		}
+	}
+	if err := s.upload(ctx, catalog, dgst); err != nil {
+		return err
+	}
+	if previous != "" && previous != dgst && previous != current && current != dgst {
+		return s.deleteObjects(ctx, catalog, previous)
+	}
+	return nil
+}
+
+// upload uploads all files of the locally stored content of a catalog, followed by
+// the object marking it as the catalog's current content.
+func (s *ObjectStoreV1) upload(ctx context.Context, catalog string, dgst digest.Digest) error {
+	s.Local.m.RLock()
+	defer s.Local.m.RUnlock()
+
+	catalogDir := s.Local.catalogDir(catalog)
+	for _, name := range objectFileNames() {
+		if err := s.uploadFile(ctx, filepath.Join(catalogDir, name), s.contentKey(catalog, dgst, name)); err != nil {
+			return err
+		}
+	}
+	return s.putDigest(ctx, catalog, objectCurrentDigestKey, dgst)
+}
+
+func (s *ObjectStoreV1) uploadFile(ctx context.Context, filePath, key string) error {
+	f, err := os.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Optional artifacts, such as the index, are not stored for every catalog.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return s.Bucket.PutObject(ctx, key, f, stat.Size(), objectInfo(path.Base(key)))
+}
+
+// Delete deletes the current and previous content of a catalog from the object store
+// and the local directory.
+func (s *ObjectStoreV1) Delete(catalog string) error {
+	ctx := context.Background()
+	current, err := s.currentDigest(ctx, catalog)
+	if err != nil {
+		return err
+	}
+	previous, err := s.readDigest(ctx, catalog, objectPreviousDigestKey)
+	if err != nil {
+		return err
+	}
+	if current != "" {
+		// Delete the commit marker first, so that the content is never partially visible.
+		if err := s.Bucket.DeleteObject(ctx, s.catalogKey(catalog, objectCurrentDigestKey)); err != nil {
+			return err
+		}
+		if err := s.deleteObjects(ctx, catalog, current); err != nil {
+			return err
+		}
+	}
+	if previous != "" {
+		if err := s.Bucket.DeleteObject(ctx, s.catalogKey(catalog, objectPreviousDigestKey)); err != nil {
+			return err
+		}
+		if previous != current {
+			if err := s.deleteObjects(ctx, catalog, previous); err != nil {
+				return err
+			}
+		}
+	}
+	return s.Local.Delete(catalog)
+}
+
+// EvictLocal deletes the local copy of the content of a catalog, leaving its content
+// in the object store in place. The content is downloaded again by the next SyncLocal.
+func (s *ObjectStoreV1) EvictLocal(catalog string) error {
+	return s.Local.Delete(catalog)
+}
+
+func (s *ObjectStoreV1) deleteObjects(ctx context.Context, catalog string, dgst digest.Digest) error {
+	var errs []error
+	for _, name := range objectFileNames() {
+		errs = append(errs, s.Bucket.DeleteObject(ctx, s.contentKey(catalog, dgst, name)))
+	}
+	return errors.Join(errs...)
+}
+
+// ContentExists returns true if content for the catalog is stored locally. It does
+// not check the object store, which is up to SyncLocal.
+func (s *ObjectStoreV1) ContentExists(catalog string) bool {
+	return s.Local.ContentExists(catalog)
+}
+
+// GetCatalogDigest returns the digest of the locally stored content of the catalog,
+// as of the last Store or SyncLocal.
+// Implements server.CatalogStore interface
+func (s *ObjectStoreV1) GetCatalogDigest(catalog string) (digest.Digest, error) {
+	return s.Local.GetCatalogDigest(catalog)
+}
+
+func (s *ObjectStoreV1) BaseURL(catalog string) string {
+	return s.Local.BaseURL(catalog)
+}
+
+// StorageServerHandler returns an HTTP handler for serving catalog content
+func (s *ObjectStoreV1) StorageServerHandler() http.Handler {
+	handlers := server.NewCatalogHandlers(s, s.Local.graphqlSvc, s.Local.RootURL, s.Local.EnableMetasHandler, s.Local.EnableGraphQLQueries)
+	return handlers.Handler()
+}
+
+// GetCatalogData returns the catalog file and its metadata
+// Implements server.CatalogStore interface
+func (s *ObjectStoreV1) GetCatalogData(catalog string) (*os.File, os.FileInfo, error) {
+	return s.Local.GetCatalogData(catalog)
+}
+
+// GetEncodedCatalogData returns the catalog file compressed with the given content
+// encoding and its metadata
+// Implements server.CatalogStore interface
+func (s *ObjectStoreV1) GetEncodedCatalogData(catalog string, encoding string) (*os.File, os.FileInfo, error) {
+	return s.Local.GetEncodedCatalogData(catalog, encoding)
+}
+
+// GetPackageNames returns the sorted names of the packages of the stored content of a catalog
+func (s *ObjectStoreV1) GetPackageNames(catalog string) ([]string, error) {
+	return s.Local.GetPackageNames(catalog)
+}
+
+// GetCatalogFS returns a filesystem interface for the catalog
+// Implements server.CatalogStore interface
+func (s *ObjectStoreV1) GetCatalogFS(catalog string) (fs.FS, error) {
+	return s.Local.GetCatalogFS(catalog)
+}
+
+// GetIndex returns the index for a catalog
+// Implements server.CatalogStore interface
+func (s *ObjectStoreV1) GetIndex(catalog string) (server.Index, error) {
+	return s.Local.GetIndex(catalog)
+}
+
+// CatalogContentURL returns a presigned URL of the full content of the catalog in
+// the given content encoding, if clients are redirected to the object store.
+// Implements server.CatalogContentRedirector interface
+func (s *ObjectStoreV1) CatalogContentURL(ctx context.Context, catalog string, encoding string) (*url.URL, error) {
+	if s.ContentMode != ObjectContentRedirect {
+		return nil, nil
+	}
+	// The URL points to the content that is stored locally, and so matches the ETag
+	// returned for the catalog.
+	current, err := s.Local.GetCatalogDigest(catalog)
+	if err != nil {
+		return nil, err
+	}
+	if current == "" {
+		return nil, fmt.Errorf("no content stored for catalog %q: %w", catalog, fs.ErrNotExist)
+	}
+	name := filepath.Base(catalogFilePath(""))
+	if encoding != "" {
+		encodedPath, err := encodedCatalogFilePath("", encoding)
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.Base(encodedPath)
+	}
+	return s.Bucket.PresignGetObject(s.contentKey(catalog, current, name), presignedURLExpiry)
+}
+
+// SyncLocal makes sure that the locally stored content of a catalog is the current
+// content in the object store, downloading it if necessary. Local content of a
+// catalog that has no content in the object store is deleted.
+// Implements LocalSyncer interface
+func (s *ObjectStoreV1) SyncLocal(ctx context.Context, catalog string) error {
+	_, err, _ := s.sf.Do(catalog, func() (interface{}, error) {
+		current, err := s.currentDigest(ctx, catalog)
+		if err != nil {
+			return nil, err
+		}
+		if current == "" {
+			return nil, s.Local.Delete(catalog)
+		}
+		local, err := s.Local.GetCatalogDigest(catalog)
+		if err != nil {
+			return nil, err
+		}
+		if local == current && s.Local.ContentExists(catalog) {
+			return nil, nil
+		}
+		return nil, s.Local.replaceCatalogDir(catalog, func(tmpCatalogDir string) error {
+			return s.download(ctx, catalog, current, tmpCatalogDir)
+		})
+	})
+	return err
+}
+
+func (s *ObjectStoreV1) download(ctx context.Context, catalog string, dgst digest.Digest, catalogDir string) error {
+	for _, name := range objectFileNames() {
+		err := s.downloadFile(ctx, s.contentKey(catalog, dgst, name), filepath.Join(catalogDir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := os.Stat(catalogFilePath(catalogDir)); err != nil {
+		return fmt.Errorf("error downloading content of catalog %q: %w", catalog, err)
+	}
+	return nil
+}
+
+func (s *ObjectStoreV1) downloadFile(ctx context.Context, key, filePath string) error {
+	body, err := s.Bucket.GetObject(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, body); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// currentDigest returns the digest of the current content of a catalog in the object
+// store, or an empty digest if it has none.
+func (s *ObjectStoreV1) currentDigest(ctx context.Context, catalog string) (digest.Digest, error) {
+	return s.readDigest(ctx, catalog, objectCurrentDigestKey)
+}
+
+// readDigest returns the digest stored in the object with the given name under the
+// prefix of a catalog, or an empty digest if there is no such object.
+func (s *ObjectStoreV1) readDigest(ctx context.Context, catalog string, name string) (digest.Digest, error) {
+	body, err := s.Bucket.GetObject(ctx, s.catalogKey(catalog, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, 1024))
+	if err != nil {
+		return "", err
+	}
+	dgst, err := digest.Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return "", fmt.Errorf("invalid digest stored for catalog %q: %w", catalog, err)
+	}
+	return dgst, nil
+}
+
+func (s *ObjectStoreV1) putDigest(ctx context.Context, catalog string, name string, dgst digest.Digest) error {
+	data := dgst.String()
+	return s.Bucket.PutObject(ctx, s.catalogKey(catalog, name), strings.NewReader(data), int64(len(data)), s3.ObjectInfo{ContentType: "text/plain"})
+}
+
+func (s *ObjectStoreV1) catalogKey(catalog string, name string) string {
+	return s.Prefix + path.Join(catalog, name)
+}
+
+func (s *ObjectStoreV1) contentKey(catalog string, dgst digest.Digest, name string) string {
+	return s.Prefix + path.Join(catalog, dgst.Algorithm().String(), dgst.Encoded(), name)
+}
+
+// objectFileNames returns the names of the files of a locally stored catalog that
+// are stored in the object store.
+func objectFileNames() []string {
+	names := []string{
+		catalogFilePath(""),
+		catalogIndexFilePath(""),
+		catalogIgnoreFilePath(""),
+		catalogDigestFilePath(""),
+		catalogPackagesFilePath(""),
+	}
+	for _, encoding := range []string{server.EncodingGzip, server.EncodingZstd} {
+		encodedPath, _ := encodedCatalogFilePath("", encoding)
+		names = append(names, encodedPath)
+	}
+	for i := range names {
+		names[i] = filepath.Base(names[i])
+	}
+	return names
+}
+
+// objectInfo returns how the object storing the file with the given name is served.
+func objectInfo(name string) s3.ObjectInfo {
+	switch name {
+	case filepath.Base(catalogFilePath("")):
+		return s3.ObjectInfo{ContentType: "application/jsonl"}
+	}
+	for _, encoding := range []string{server.EncodingGzip, server.EncodingZstd} {
+		if encodedPath, _ := encodedCatalogFilePath("", encoding); name == filepath.Base(encodedPath) {
+			return s3.ObjectInfo{ContentType: "application/jsonl", ContentEncoding: encoding}
+		}
+	}
+	return s3.ObjectInfo{ContentType: "application/octet-stream"}
+}
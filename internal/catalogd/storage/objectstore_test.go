@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage/s3"
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage/s3/s3test"
+)
+
+// newTestObjectStore returns an ObjectStoreV1 with its own local directory that
+// stores content in the bucket of the given fake object store server.
+func newTestObjectStore(t *testing.T, srv *httptest.Server, mode ObjectContentMode) *ObjectStoreV1 {
+	t.Helper()
+	endpoint, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	bucket, err := s3.NewClient(endpoint, "olmv1", "us-east-1", s3.Credentials{AccessKeyID: "test", SecretAccessKey: "test"}, srv.Client())
+	require.NoError(t, err)
+	rootURL := &url.URL{Path: urlPrefix}
+	local := NewLocalDirV1(t.TempDir(), rootURL, MetasHandlerEnabled, GraphQLQueriesDisabled)
+	return NewObjectStoreV1(bucket, "catalogs/", mode, local)
+}
+
+func TestObjectStoreV1(t *testing.T) {
+	ctx := context.Background()
+	fake := s3test.NewFakeObjectStore()
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fake.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	leader := newTestObjectStore(t, srv, ObjectContentProxy)
+	replica := newTestObjectStore(t, srv, ObjectContentProxy)
+	fsys := createTestFS(t)
+
+	require.False(t, replica.ContentExists("test-catalog"))
+
+	require.NoError(t, leader.Store(ctx, "test-catalog", testDigest, fsys))
+	require.Contains(t, fake.Keys(), "olmv1/catalogs/test-catalog/current")
+	require.Contains(t, fake.Keys(), "olmv1/catalogs/test-catalog/sha256/"+testDigest.Encoded()+"/catalog.jsonl")
+	require.Contains(t, fake.Keys(), "olmv1/catalogs/test-catalog/sha256/"+testDigest.Encoded()+"/index.bin")
+
+	t.Run("replica only reads the object store when it is synced", func(t *testing.T) {
+		before := requests.Load()
+		require.False(t, replica.ContentExists("test-catalog"))
+		dgst, err := replica.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Empty(t, dgst)
+		require.Equal(t, before, requests.Load())
+
+		require.NoError(t, replica.SyncLocal(ctx, "test-catalog"))
+		require.Greater(t, requests.Load(), before)
+	})
+
+	t.Run("replica serves content stored by the leader without unpacking it", func(t *testing.T) {
+		before := requests.Load()
+		require.True(t, replica.ContentExists("test-catalog"))
+		dgst, err := replica.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Equal(t, testDigest, dgst)
+
+		expected, err := os.ReadFile(filepath.Join(leader.Local.RootDir, "test-catalog", "catalog.jsonl"))
+		require.NoError(t, err)
+
+		testServer := httptest.NewServer(replica.StorageServerHandler())
+		defer testServer.Close()
+		resp, err := testServer.Client().Get(testServer.URL + urlPrefix + "test-catalog/api/v1/all")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, `"`+testDigest.String()+`"`, resp.Header.Get("Etag"))
+		actual, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, string(expected), string(actual))
+		require.Equal(t, before, requests.Load())
+	})
+
+	newDigest := digest.FromString("new-test-catalog-image")
+	t.Run("new content replaces the previous content", func(t *testing.T) {
+		require.NoError(t, leader.Store(ctx, "test-catalog", newDigest, fsys))
+		// The previous content is kept for replicas that are still downloading it.
+		require.Contains(t, fake.Keys(), "olmv1/catalogs/test-catalog/previous")
+		require.Contains(t, fake.Keys(), "olmv1/catalogs/test-catalog/sha256/"+testDigest.Encoded()+"/catalog.jsonl")
+
+		dgst, err := replica.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Equal(t, testDigest, dgst)
+
+		require.NoError(t, replica.SyncLocal(ctx, "test-catalog"))
+		require.True(t, replica.ContentExists("test-catalog"))
+		dgst, err = replica.GetCatalogDigest("test-catalog")
+		require.NoError(t, err)
+		require.Equal(t, newDigest, dgst)
+	})
+
+	t.Run("content stored before the previous content is deleted", func(t *testing.T) {
+		newerDigest := digest.FromString("newer-test-catalog-image")
+		require.NoError(t, leader.Store(ctx, "test-catalog", newerDigest, fsys))
+		require.Contains(t, fake.Keys(), "olmv1/catalogs/test-catalog/sha256/"+newDigest.Encoded()+"/catalog.jsonl")
+		for _, key := range fake.Keys() {
+			require.NotContains(t, key, testDigest.Encoded())
+		}
+
+		// Storing the current content again keeps the previous content.
+		require.NoError(t, leader.Store(ctx, "test-catalog", newerDigest, fsys))
+		require.Contains(t, fake.Keys(), "olmv1/catalogs/test-catalog/sha256/"+newDigest.Encoded()+"/catalog.jsonl")
+	})
+
+	t.Run("delete removes content from the object store and all replicas", func(t *testing.T) {
+		require.NoError(t, leader.Delete("test-catalog"))
+		require.Empty(t, fake.Keys())
+		require.False(t, leader.Local.ContentExists("test-catalog"))
+
+		require.NoError(t, replica.SyncLocal(ctx, "test-catalog"))
+		require.False(t, replica.ContentExists("test-catalog"))
+		require.False(t, replica.Local.ContentExists("test-catalog"))
+	})
+}
+
+func TestObjectStoreV1Redirect(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(s3test.NewFakeObjectStore())
+	defer srv.Close()
+
+	store := newTestObjectStore(t, srv, ObjectContentRedirect)
+	require.NoError(t, store.Store(ctx, "test-catalog", testDigest, createTestFS(t)))
+
+	testServer := httptest.NewServer(store.StorageServerHandler())
+	defer testServer.Close()
+	noRedirectClient := &http.Client{
+		Transport: &http.Transport{DisableCompression: true},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	for _, tc := range []struct {
+		name           string
+		acceptEncoding string
+		expectedObject string
+		expectedETag   string
+	}{
+		{
+			name:           "uncompressed",
+			expectedObject: "catalog.jsonl",
+			expectedETag:   `"` + testDigest.String() + `"`,
+		},
+		{
+			name:           "gzip",
+			acceptEncoding: "gzip",
+			expectedObject: "catalog.jsonl.gz",
+			expectedETag:   `"` + testDigest.String() + `-gzip"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testServer.URL+urlPrefix+"test-catalog/api/v1/all", nil)
+			require.NoError(t, err)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			resp, err := noRedirectClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			require.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+			require.Equal(t, tc.expectedETag, resp.Header.Get("Etag"))
+			location, err := url.Parse(resp.Header.Get("Location"))
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(location.String(), srv.URL))
+			require.Equal(t, "/olmv1/catalogs/test-catalog/sha256/"+testDigest.Encoded()+"/"+tc.expectedObject, location.Path)
+			require.NotEmpty(t, location.Query().Get("X-Amz-Signature"))
+
+			req.Header.Set("If-None-Match", tc.expectedETag)
+			resp, err = noRedirectClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusNotModified, resp.StatusCode)
+		})
+	}
+
+	t.Run("unknown catalog", func(t *testing.T) {
+		resp, err := noRedirectClient.Get(testServer.URL + urlPrefix + "missing-catalog/api/v1/all")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,148 @@
+// Package s3 implements a minimal client for S3-compatible object stores, such as
+// AWS S3 and MinIO, sufficient for storing and serving catalog content.
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client reads and writes objects in a single bucket of an S3-compatible object
+// store. Buckets are addressed path-style (<endpoint>/<bucket>/<key>), which all
+// S3-compatible object stores support.
+type Client struct {
+	endpoint   *url.URL
+	bucket     string
+	signer     *signer
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewClient returns a Client for the given bucket of the object store at endpoint,
+// which signs requests with the given region and credentials.
+func NewClient(endpoint *url.URL, bucket, region string, credentials Credentials, httpClient *http.Client) (*Client, error) {
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("object store endpoint %q must use http or https", endpoint)
+	}
+	if bucket == "" {
+		return nil, errors.New("object store bucket must not be empty")
+	}
+	if region == "" {
+		return nil, errors.New("object store region must not be empty")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		endpoint:   endpoint,
+		bucket:     bucket,
+		signer:     &signer{credentials: credentials, region: region},
+		httpClient: httpClient,
+		now:        time.Now,
+	}, nil
+}
+
+// ObjectInfo describes how an object is served.
+type ObjectInfo struct {
+	ContentType     string
+	ContentEncoding string
+}
+
+// PutObject writes size bytes read from body to the object with the given key.
+func (c *Client) PutObject(ctx context.Context, key string, body io.Reader, size int64, info ObjectInfo) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(key).String(), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if info.ContentType != "" {
+		req.Header.Set("Content-Type", info.ContentType)
+	}
+	if info.ContentEncoding != "" {
+		req.Header.Set("Content-Encoding", info.ContentEncoding)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("error putting object %q: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+// GetObject returns the content of the object with the given key. An error wrapping
+// fs.ErrNotExist is returned if the object does not exist.
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	// Objects stored with a Content-Encoding must be returned as stored, rather than
+	// being transparently decoded by the transport.
+	req.Header.Set("Accept-Encoding", "identity")
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting object %q: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+// DeleteObject deletes the object with the given key. Deleting an object that does
+// not exist is not an error.
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting object %q: %w", key, err)
+	}
+	return resp.Body.Close()
+}
+
+// PresignGetObject returns a URL that can be used to get the object with the given
+// key without credentials until it expires.
+func (c *Client) PresignGetObject(key string, expires time.Duration) (*url.URL, error) {
+	return c.signer.presignURL(c.objectURL(key), c.now(), expires), nil
+}
+
+func (c *Client) objectURL(key string) *url.URL {
+	segments := append([]string{c.bucket}, strings.Split(key, "/")...)
+	u := c.endpoint.JoinPath(segments...)
+	if !strings.HasPrefix(u.Path, "/") {
+		// The path of an endpoint without a path is relative after joining.
+		u.Path = "/" + u.Path
+		u.RawPath = ""
+	}
+	return u
+}
+
+// do signs and sends req, and returns an error for unsuccessful responses.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	c.signer.signRequest(req, c.now())
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	// Error responses carry an XML document describing the error, which is limited
+	// in size and included in the error to aid debugging.
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %w", fs.ErrNotExist, err)
+	}
+	return nil, err
+}
@@ -0,0 +1,91 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-controller/internal/catalogd/storage/s3/s3test"
+)
+
+func TestClient(t *testing.T) {
+	store := s3test.NewFakeObjectStore()
+	srv := httptest.NewServer(store)
+	defer srv.Close()
+
+	endpoint, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	c, err := NewClient(endpoint, "catalogs", "us-east-1", exampleCredentials, srv.Client())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("get missing object", func(t *testing.T) {
+		_, err := c.GetObject(ctx, "test-catalog/catalog.jsonl")
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("put and get object", func(t *testing.T) {
+		data := `{"schema":"olm.package","name":"my-package"}`
+		require.NoError(t, c.PutObject(ctx, "test-catalog/catalog.jsonl", strings.NewReader(data), int64(len(data)), ObjectInfo{ContentType: "application/jsonl"}))
+		require.ElementsMatch(t, []string{"catalogs/test-catalog/catalog.jsonl"}, store.Keys())
+
+		body, err := c.GetObject(ctx, "test-catalog/catalog.jsonl")
+		require.NoError(t, err)
+		defer body.Close()
+		got, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, data, string(got))
+	})
+
+	t.Run("presigned URL can be used without credentials", func(t *testing.T) {
+		u, err := c.PresignGetObject("test-catalog/catalog.jsonl", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "/catalogs/test-catalog/catalog.jsonl", u.Path)
+
+		resp, err := srv.Client().Get(u.String())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/jsonl", resp.Header.Get("Content-Type"))
+	})
+
+	t.Run("delete object", func(t *testing.T) {
+		require.NoError(t, c.DeleteObject(ctx, "test-catalog/catalog.jsonl"))
+		require.Empty(t, store.Keys())
+		require.NoError(t, c.DeleteObject(ctx, "test-catalog/catalog.jsonl"))
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		endpoint    string
+		bucket      string
+		region      string
+		expectedErr string
+	}{
+		{name: "valid", endpoint: "https://s3.example.com", bucket: "catalogs", region: "us-east-1"},
+		{name: "unsupported scheme", endpoint: "s3://catalogs", bucket: "catalogs", region: "us-east-1", expectedErr: "must use http or https"},
+		{name: "missing bucket", endpoint: "https://s3.example.com", region: "us-east-1", expectedErr: "bucket must not be empty"},
+		{name: "missing region", endpoint: "https://s3.example.com", bucket: "catalogs", expectedErr: "region must not be empty"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint, err := url.Parse(tc.endpoint)
+			require.NoError(t, err)
+			_, err = NewClient(endpoint, tc.bucket, tc.region, Credentials{}, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package s3test provides a fake S3-compatible object store for tests.
+package s3test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// FakeObjectStore is a test fake of an S3-compatible object store, which serves
+// objects of any bucket from memory. It only checks that requests are signed, not
+// that their signatures are valid. Presigned URLs are served like any other request.
+type FakeObjectStore struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data            []byte
+	contentType     string
+	contentEncoding string
+}
+
+// NewFakeObjectStore returns an empty FakeObjectStore.
+func NewFakeObjectStore() *FakeObjectStore {
+	return &FakeObjectStore{objects: map[string]fakeObject{}}
+}
+
+// Keys returns the paths (<bucket>/<key>) of all stored objects.
+func (f *FakeObjectStore) Keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.objects))
+	for k := range f.objects {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (f *FakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256") && r.URL.Query().Get("X-Amz-Signature") == "" {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = fakeObject{
+			data:            data,
+			contentType:     r.Header.Get("Content-Type"),
+			contentEncoding: r.Header.Get("Content-Encoding"),
+		}
+	case http.MethodGet:
+		obj, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		if obj.contentType != "" {
+			w.Header().Set("Content-Type", obj.contentType)
+		}
+		if obj.contentEncoding != "" {
+			w.Header().Set("Content-Encoding", obj.contentEncoding)
+		}
+		_, _ = w.Write(obj.data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
@@ -4,17 +4,41 @@ import (
 	"context"
 	"io/fs"
 	"net/http"
+
+	"github.com/opencontainers/go-digest"
 )
 
 // Instance is a storage instance that stores FBC content of catalogs
 // added to a cluster. It can be used to Store or Delete FBC in the
-// host's filesystem. It also a manager runnable object, that starts
+// host's filesystem, along with the digest of the image the FBC was
+// resolved from, which can be retrieved to determine whether the
+// stored content is current. It also a manager runnable object, that starts
 // a server to serve the content stored.
 type Instance interface {
-	Store(ctx context.Context, catalog string, fsys fs.FS) error
+	Store(ctx context.Context, catalog string, dgst digest.Digest, fsys fs.FS) error
 	Delete(catalog string) error
 	ContentExists(catalog string) bool
+	GetCatalogDigest(catalog string) (digest.Digest, error)
+	GetPackageNames(catalog string) ([]string, error)
 
 	BaseURL(catalog string) string
 	StorageServerHandler() http.Handler
 }
+
+// LocalEvicter is implemented by storage instances whose content is shared by all
+// catalogd replicas. Replicas that are not the leader must not delete shared content,
+// which the leader still serves, and evict their local copy of it instead.
+type LocalEvicter interface {
+	// EvictLocal deletes the locally cached content and index of a catalog, leaving
+	// its shared content in place.
+	EvictLocal(catalog string) error
+}
+
+// LocalSyncer is implemented by storage instances whose content is shared by all
+// catalogd replicas and served from a local copy of it. Reading stored content never
+// reaches out to the shared storage, so replicas sync their local copy when reconciling.
+type LocalSyncer interface {
+	// SyncLocal makes sure that the locally stored content of a catalog is the current
+	// shared content of the catalog.
+	SyncLocal(ctx context.Context, catalog string) error
+}
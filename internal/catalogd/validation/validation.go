@@ -0,0 +1,223 @@
+// Package validation validates the contents of file-based catalogs (FBC) beyond what
+// is required to parse and serve them, so that catalogs which would cause problems
+// during resolution are detected when they are unpacked.
+package validation
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	bsemver "github.com/blang/semver/v4"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+)
+
+// A Problem is a single problem found in the contents of a catalog, identified by the
+// package, schema and name of the blob it was found in.
+type Problem struct {
+	Package string
+	Schema  string
+	Name    string
+	Message string
+}
+
+func (p Problem) String() string {
+	var sb strings.Builder
+	if p.Package != "" {
+		fmt.Fprintf(&sb, "package %q ", p.Package)
+	}
+	fmt.Fprintf(&sb, "%s %q: %s", p.Schema, p.Name, p.Message)
+	return sb.String()
+}
+
+// Validate walks the FBC in fsys and returns the problems found in it, sorted by
+// package, schema and name. An error is returned if the FBC cannot be parsed.
+//
+// The following problems are detected:
+//   - packages without a default channel, or whose default channel does not exist
+//   - channels and bundles of packages that are not declared
+//   - duplicate packages, channels and bundles
+//   - channel entries that reference bundles that do not exist
+//   - channels whose replaces chains contain a cycle
+//   - bundles without a version, or whose version is not valid semver
+func Validate(ctx context.Context, fsys fs.FS) ([]Problem, error) {
+	v := &validator{
+		packages: map[string]*declcfg.Package{},
+		channels: map[string]map[string]*declcfg.Channel{},
+		bundles:  map[string]map[string]struct{}{},
+	}
+	if err := declcfg.WalkMetasFS(ctx, fsys, func(path string, meta *declcfg.Meta, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := v.add(meta); err != nil {
+			return fmt.Errorf("error parsing %s %q in %q: %w", meta.Schema, meta.Name, path, err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	v.validate()
+
+	slices.SortStableFunc(v.problems, func(a, b Problem) int {
+		return cmp.Or(
+			cmp.Compare(a.Package, b.Package),
+			cmp.Compare(a.Schema, b.Schema),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return v.problems, nil
+}
+
+type validator struct {
+	packages map[string]*declcfg.Package
+	// channels and bundles are keyed by package name, then by name.
+	channels map[string]map[string]*declcfg.Channel
+	bundles  map[string]map[string]struct{}
+	problems []Problem
+}
+
+func (v *validator) report(pkg, schema, name, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Package: pkg, Schema: schema, Name: name, Message: fmt.Sprintf(format, args...)})
+}
+
+// add records a single blob, reporting problems that can be detected without
+// knowing the rest of the catalog.
+func (v *validator) add(meta *declcfg.Meta) error {
+	switch meta.Schema {
+	case declcfg.SchemaPackage:
+		var pkg declcfg.Package
+		if err := json.Unmarshal(meta.Blob, &pkg); err != nil {
+			return err
+		}
+		if _, ok := v.packages[pkg.Name]; ok {
+			v.report("", meta.Schema, pkg.Name, "duplicate package")
+			return nil
+		}
+		v.packages[pkg.Name] = &pkg
+	case declcfg.SchemaChannel:
+		var ch declcfg.Channel
+		if err := json.Unmarshal(meta.Blob, &ch); err != nil {
+			return err
+		}
+		if v.channels[ch.Package] == nil {
+			v.channels[ch.Package] = map[string]*declcfg.Channel{}
+		}
+		if _, ok := v.channels[ch.Package][ch.Name]; ok {
+			v.report(ch.Package, meta.Schema, ch.Name, "duplicate channel")
+			return nil
+		}
+		v.channels[ch.Package][ch.Name] = &ch
+	case declcfg.SchemaBundle:
+		// Only the properties of bundles are needed, so the remainder of what may be
+		// large blobs is not decoded.
+		var b struct {
+			Name       string              `json:"name"`
+			Package    string              `json:"package"`
+			Properties []property.Property `json:"properties"`
+		}
+		if err := json.Unmarshal(meta.Blob, &b); err != nil {
+			return err
+		}
+		if v.bundles[b.Package] == nil {
+			v.bundles[b.Package] = map[string]struct{}{}
+		}
+		if _, ok := v.bundles[b.Package][b.Name]; ok {
+			v.report(b.Package, meta.Schema, b.Name, "duplicate bundle")
+			return nil
+		}
+		v.bundles[b.Package][b.Name] = struct{}{}
+		v.validateBundleVersion(b.Package, b.Name, b.Properties)
+	}
+	return nil
+}
+
+func (v *validator) validateBundleVersion(pkg, name string, props []property.Property) {
+	for _, prop := range props {
+		if prop.Type != property.TypePackage {
+			continue
+		}
+		var p property.Package
+		if err := json.Unmarshal(prop.Value, &p); err != nil {
+			v.report(pkg, declcfg.SchemaBundle, name, "invalid %s property: %v", property.TypePackage, err)
+			return
+		}
+		if _, err := bsemver.Parse(p.Version); err != nil {
+			v.report(pkg, declcfg.SchemaBundle, name, "version %q is not valid semver: %v", p.Version, err)
+		}
+		return
+	}
+	v.report(pkg, declcfg.SchemaBundle, name, "missing %s property", property.TypePackage)
+}
+
+// validate reports problems that involve relationships between blobs.
+func (v *validator) validate() {
+	for name, pkg := range v.packages {
+		switch {
+		case pkg.DefaultChannel == "":
+			v.report("", declcfg.SchemaPackage, name, "no default channel")
+		case v.channels[name][pkg.DefaultChannel] == nil:
+			v.report("", declcfg.SchemaPackage, name, "default channel %q does not exist", pkg.DefaultChannel)
+		}
+	}
+	for pkgName, bundles := range v.bundles {
+		if _, ok := v.packages[pkgName]; !ok {
+			for name := range bundles {
+				v.report(pkgName, declcfg.SchemaBundle, name, "package is not declared")
+			}
+		}
+	}
+	for pkgName, channels := range v.channels {
+		for _, ch := range channels {
+			if _, ok := v.packages[pkgName]; !ok {
+				v.report(pkgName, declcfg.SchemaChannel, ch.Name, "package is not declared")
+			}
+			v.validateChannel(ch)
+		}
+	}
+}
+
+func (v *validator) validateChannel(ch *declcfg.Channel) {
+	replaces := make(map[string]string, len(ch.Entries))
+	for _, entry := range ch.Entries {
+		if _, ok := replaces[entry.Name]; ok {
+			v.report(ch.Package, declcfg.SchemaChannel, ch.Name, "duplicate entry %q", entry.Name)
+			continue
+		}
+		replaces[entry.Name] = entry.Replaces
+		if _, ok := v.bundles[ch.Package][entry.Name]; !ok {
+			v.report(ch.Package, declcfg.SchemaChannel, ch.Name, "entry %q references missing bundle", entry.Name)
+		}
+	}
+
+	// Follow the replaces chain from each entry. Entries that were already visited
+	// from an earlier entry are known not to lead to a cycle that has not yet been
+	// reported, so each entry is followed at most once.
+	visited := make(map[string]bool, len(ch.Entries))
+	for _, entry := range ch.Entries {
+		var chain []string
+		onChain := map[string]int{}
+		for name := entry.Name; name != "" && !visited[name]; name = replaces[name] {
+			if i, ok := onChain[name]; ok {
+				cycle := append(chain[i:], name)
+				v.report(ch.Package, declcfg.SchemaChannel, ch.Name, "replaces chain contains a cycle: %s", strings.Join(cycle, " -> "))
+				break
+			}
+			if _, ok := replaces[name]; !ok {
+				// The chain leaves the channel.
+				break
+			}
+			onChain[name] = len(chain)
+			chain = append(chain, name)
+		}
+		for _, name := range chain {
+			visited[name] = true
+		}
+	}
+}
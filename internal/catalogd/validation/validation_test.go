@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+const validCatalog = `{"schema":"olm.package","name":"foo","defaultChannel":"stable"}
+{"schema":"olm.channel","package":"foo","name":"stable","entries":[{"name":"foo.v1.0.0"},{"name":"foo.v1.1.0","replaces":"foo.v1.0.0"},{"name":"foo.v1.2.0","replaces":"foo.v1.1.0","skips":["foo.v0.9.0"]}]}
+{"schema":"olm.bundle","package":"foo","name":"foo.v1.0.0","properties":[{"type":"olm.package","value":{"packageName":"foo","version":"1.0.0"}}]}
+{"schema":"olm.bundle","package":"foo","name":"foo.v1.1.0","properties":[{"type":"olm.package","value":{"packageName":"foo","version":"1.1.0"}}]}
+{"schema":"olm.bundle","package":"foo","name":"foo.v1.2.0","properties":[{"type":"olm.package","value":{"packageName":"foo","version":"1.2.0"}}]}
+{"schema":"olm.deprecations","package":"foo"}
+{"schema":"custom.schema","name":"anything"}
+`
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		catalog          string
+		expectedProblems []string
+		expectedErr      string
+	}{
+		{
+			name:    "valid catalog",
+			catalog: validCatalog,
+		},
+		{
+			name: "package without default channel",
+			catalog: `{"schema":"olm.package","name":"foo"}
+{"schema":"olm.package","name":"bar","defaultChannel":"missing"}
+`,
+			expectedProblems: []string{
+				`olm.package "bar": default channel "missing" does not exist`,
+				`olm.package "foo": no default channel`,
+			},
+		},
+		{
+			name: "duplicates",
+			catalog: validCatalog + `{"schema":"olm.package","name":"foo","defaultChannel":"stable"}
+{"schema":"olm.channel","package":"foo","name":"stable","entries":[]}
+{"schema":"olm.bundle","package":"foo","name":"foo.v1.0.0","properties":[{"type":"olm.package","value":{"packageName":"foo","version":"1.0.0"}}]}
+{"schema":"olm.channel","package":"foo","name":"fast","entries":[{"name":"foo.v1.0.0"},{"name":"foo.v1.0.0"}]}
+`,
+			expectedProblems: []string{
+				`olm.package "foo": duplicate package`,
+				`package "foo" olm.bundle "foo.v1.0.0": duplicate bundle`,
+				`package "foo" olm.channel "fast": duplicate entry "foo.v1.0.0"`,
+				`package "foo" olm.channel "stable": duplicate channel`,
+			},
+		},
+		{
+			name: "entries referencing missing bundles",
+			catalog: validCatalog + `{"schema":"olm.channel","package":"foo","name":"fast","entries":[{"name":"foo.v1.2.0"},{"name":"foo.v2.0.0","replaces":"foo.v1.2.0"}]}
+`,
+			expectedProblems: []string{
+				`package "foo" olm.channel "fast": entry "foo.v2.0.0" references missing bundle`,
+			},
+		},
+		{
+			name: "cyclic replaces",
+			catalog: validCatalog + `{"schema":"olm.channel","package":"foo","name":"fast","entries":[{"name":"foo.v1.0.0","replaces":"foo.v1.2.0"},{"name":"foo.v1.1.0","replaces":"foo.v1.0.0"},{"name":"foo.v1.2.0","replaces":"foo.v1.1.0"}]}
+{"schema":"olm.channel","package":"foo","name":"self","entries":[{"name":"foo.v1.0.0","replaces":"foo.v1.0.0"}]}
+`,
+			expectedProblems: []string{
+				`package "foo" olm.channel "fast": replaces chain contains a cycle: foo.v1.0.0 -> foo.v1.2.0 -> foo.v1.1.0 -> foo.v1.0.0`,
+				`package "foo" olm.channel "self": replaces chain contains a cycle: foo.v1.0.0 -> foo.v1.0.0`,
+			},
+		},
+		{
+			name: "invalid versions",
+			catalog: validCatalog + `{"schema":"olm.bundle","package":"foo","name":"foo.v2","properties":[{"type":"olm.package","value":{"packageName":"foo","version":"v2"}}]}
+{"schema":"olm.bundle","package":"foo","name":"foo.unversioned","properties":[]}
+`,
+			expectedProblems: []string{
+				`package "foo" olm.bundle "foo.unversioned": missing olm.package property`,
+				`package "foo" olm.bundle "foo.v2": version "v2" is not valid semver: No Major.Minor.Patch elements found`,
+			},
+		},
+		{
+			name: "undeclared package",
+			catalog: `{"schema":"olm.channel","package":"bar","name":"stable","entries":[{"name":"bar.v1.0.0"}]}
+{"schema":"olm.bundle","package":"bar","name":"bar.v1.0.0","properties":[{"type":"olm.package","value":{"packageName":"bar","version":"1.0.0"}}]}
+`,
+			expectedProblems: []string{
+				`package "bar" olm.bundle "bar.v1.0.0": package is not declared`,
+				`package "bar" olm.channel "stable": package is not declared`,
+			},
+		},
+		{
+			name:        "invalid FBC",
+			catalog:     `{"schema":"olm.package","name":`,
+			expectedErr: "unexpected EOF",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"catalog.json": &fstest.MapFile{Data: []byte(tc.catalog)}}
+			problems, err := Validate(context.Background(), fsys)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			var actual []string
+			for _, p := range problems {
+				actual = append(actual, p.String())
+			}
+			require.Equal(t, tc.expectedProblems, actual)
+		})
+	}
+}
@@ -2,15 +2,27 @@ package webhook
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogd/overlap"
 )
 
-// ClusterCatalog wraps the external v1.ClusterCatalog type and implements admission.Defaulter
-type ClusterCatalog struct{}
+// ClusterCatalog wraps the external v1.ClusterCatalog type and implements admission.Defaulter.
+// When Packages is set, it also implements admission.Validator, warning about packages that
+// a ClusterCatalog shares with other ClusterCatalogs of the same priority.
+type ClusterCatalog struct {
+	// Client lists the other ClusterCatalogs.
+	Client client.Reader
+	// Packages lists the packages of the stored content of ClusterCatalogs.
+	Packages overlap.PackageLister
+}
 
 // Default is the method that will be called by the webhook to apply defaults.
 // Type-safe method signature - no runtime.Object or type assertion needed.
@@ -28,9 +40,55 @@ func (r *ClusterCatalog) Default(ctx context.Context, obj *ocv1.ClusterCatalog)
 	return nil
 }
 
-// SetupWebhookWithManager sets up the webhook with the manager
+// ValidateCreate never warns, as the content of a new ClusterCatalog is not known yet.
+func (r *ClusterCatalog) ValidateCreate(context.Context, *ocv1.ClusterCatalog) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate warns when the packages of the served content of a ClusterCatalog are
+// also provided by other served ClusterCatalogs with its new priority. Updates are
+// never rejected, and no warning is returned if the overlaps cannot be determined.
+func (r *ClusterCatalog) ValidateUpdate(ctx context.Context, _, newObj *ocv1.ClusterCatalog) (admission.Warnings, error) {
+	if !overlap.IsServing(newObj) {
+		return nil, nil
+	}
+	log := log.FromContext(ctx)
+
+	packages, err := r.Packages.GetPackageNames(newObj.Name)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Error(err, "unable to determine overlapping packages")
+		}
+		return nil, nil
+	}
+	catalogs := &ocv1.ClusterCatalogList{}
+	if err := r.Client.List(ctx, catalogs); err != nil {
+		log.Error(err, "unable to determine overlapping packages")
+		return nil, nil
+	}
+	overlaps, err := overlap.Find(r.Packages, newObj.Name, newObj.Spec.Priority, packages, catalogs.Items)
+	if err != nil {
+		log.Error(err, "unable to determine overlapping packages")
+		return nil, nil
+	}
+	if len(overlaps) == 0 {
+		return nil, nil
+	}
+	return admission.Warnings{overlap.Message(newObj.Spec.Priority, overlaps)}, nil
+}
+
+// ValidateDelete never warns.
+func (r *ClusterCatalog) ValidateDelete(context.Context, *ocv1.ClusterCatalog) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// SetupWebhookWithManager sets up the webhook with the manager. The validating webhook is
+// only served when Packages is set.
 func (r *ClusterCatalog) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr, &ocv1.ClusterCatalog{}).
-		WithDefaulter(r).
-		Complete()
+	blder := ctrl.NewWebhookManagedBy(mgr, &ocv1.ClusterCatalog{}).
+		WithDefaulter(r)
+	if r.Packages != nil {
+		blder = blder.WithValidator(r)
+	}
+	return blder.Complete()
 }
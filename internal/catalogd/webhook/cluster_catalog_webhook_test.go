@@ -2,11 +2,14 @@ package webhook
 
 import (
 	"context"
+	"io/fs"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 )
@@ -71,3 +74,69 @@ func TestClusterCatalogDefaulting(t *testing.T) {
 		})
 	}
 }
+
+type packageLister map[string][]string
+
+func (l packageLister) GetPackageNames(catalog string) ([]string, error) {
+	names, ok := l[catalog]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return names, nil
+}
+
+func servedClusterCatalog(name string, priority int32) *ocv1.ClusterCatalog {
+	return &ocv1.ClusterCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       ocv1.ClusterCatalogSpec{Priority: priority, AvailabilityMode: ocv1.AvailabilityModeAvailable},
+		Status: ocv1.ClusterCatalogStatus{
+			Conditions: []metav1.Condition{{Type: ocv1.TypeServing, Status: metav1.ConditionTrue, Reason: ocv1.ReasonAvailable}},
+		},
+	}
+}
+
+func TestClusterCatalogValidateUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		servedClusterCatalog("test-catalog", 0),
+		servedClusterCatalog("other-catalog", 10),
+	).Build()
+	clusterCatalogWrapper := &ClusterCatalog{
+		Client: cl,
+		Packages: packageLister{
+			"test-catalog":  {"foo", "bar"},
+			"other-catalog": {"bar"},
+		},
+	}
+
+	tests := map[string]struct {
+		catalog          *ocv1.ClusterCatalog
+		expectedWarnings []string
+	}{
+		"no overlap with catalogs of the same priority": {
+			catalog: servedClusterCatalog("test-catalog", 0),
+		},
+		"overlap with catalogs of the new priority": {
+			catalog:          servedClusterCatalog("test-catalog", 10),
+			expectedWarnings: []string{`packages are also provided by ClusterCatalogs with priority 10, which makes their resolution ambiguous: "other-catalog" (bar)`},
+		},
+		"catalog that is not served": {
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-catalog"},
+				Spec:       ocv1.ClusterCatalogSpec{Priority: 10},
+			},
+		},
+		"catalog whose content is not stored": {
+			catalog: servedClusterCatalog("new-catalog", 10),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			warnings, err := clusterCatalogWrapper.ValidateUpdate(context.TODO(), tc.catalog, tc.catalog)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWarnings, []string(warnings))
+		})
+	}
+}
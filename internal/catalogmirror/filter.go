@@ -0,0 +1,142 @@
+package catalogmirror
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/compare"
+	catalogfilter "github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
+	"github.com/operator-framework/operator-controller/internal/shared/util/filter"
+)
+
+// Filter selects the part of a catalog that is mirrored. An empty Filter selects the
+// whole catalog.
+type Filter struct {
+	// Packages are the names of the packages to mirror. All packages are mirrored
+	// when empty.
+	Packages []string
+	// Channels are the names of the channels to mirror. All channels of the mirrored
+	// packages are mirrored when empty.
+	Channels []string
+	// VersionRange is a semver range, with the syntax of the version field of
+	// ClusterExtensions, that the versions of the mirrored bundles must be in.
+	VersionRange string
+}
+
+// Apply returns the part of fbc selected by the filter. Channels only keep the
+// entries of the selected bundles, and channels and packages left without any of them
+// are removed. When the default channel of a package is removed, the first of its
+// remaining channels becomes its default channel.
+func (f Filter) Apply(fbc *declcfg.DeclarativeConfig) (*declcfg.DeclarativeConfig, error) {
+	if len(f.Packages) == 0 && len(f.Channels) == 0 && f.VersionRange == "" {
+		return fbc, nil
+	}
+
+	packageNames := sets.New(f.Packages...)
+	keepPackage := func(name string) bool {
+		return packageNames.Len() == 0 || packageNames.Has(name)
+	}
+	channelNames := sets.New(f.Channels...)
+
+	channelsByPackage := map[string][]declcfg.Channel{}
+	for _, ch := range fbc.Channels {
+		if keepPackage(ch.Package) && (channelNames.Len() == 0 || channelNames.Has(ch.Name)) {
+			channelsByPackage[ch.Package] = append(channelsByPackage[ch.Package], ch)
+		}
+	}
+
+	var predicates []filter.Predicate[declcfg.Bundle]
+	if f.VersionRange != "" {
+		versionRange, err := compare.NewVersionRange(f.VersionRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %w", f.VersionRange, err)
+		}
+		predicates = append(predicates, catalogfilter.InSemverRange(versionRange))
+	}
+
+	out := &declcfg.DeclarativeConfig{}
+	keptBundles := map[string]sets.Set[string]{}
+	for _, b := range fbc.Bundles {
+		if !keepPackage(b.Package) {
+			continue
+		}
+		if !filter.And(append(predicates, catalogfilter.InAnyChannel(channelsByPackage[b.Package]...))...)(b) {
+			continue
+		}
+		out.Bundles = append(out.Bundles, b)
+		if keptBundles[b.Package] == nil {
+			keptBundles[b.Package] = sets.New[string]()
+		}
+		keptBundles[b.Package].Insert(b.Name)
+	}
+
+	keptChannels := map[string][]string{}
+	for _, ch := range fbc.Channels {
+		if !slices.ContainsFunc(channelsByPackage[ch.Package], func(c declcfg.Channel) bool { return c.Name == ch.Name }) {
+			continue
+		}
+		ch.Entries = slices.DeleteFunc(slices.Clone(ch.Entries), func(e declcfg.ChannelEntry) bool {
+			return !keptBundles[ch.Package].Has(e.Name)
+		})
+		if len(ch.Entries) == 0 {
+			continue
+		}
+		out.Channels = append(out.Channels, ch)
+		keptChannels[ch.Package] = append(keptChannels[ch.Package], ch.Name)
+	}
+
+	for _, p := range fbc.Packages {
+		channels := keptChannels[p.Name]
+		if len(channels) == 0 {
+			continue
+		}
+		if !slices.Contains(channels, p.DefaultChannel) {
+			p.DefaultChannel = channels[0]
+		}
+		out.Packages = append(out.Packages, p)
+	}
+
+	for _, d := range fbc.Deprecations {
+		if len(keptChannels[d.Package]) == 0 {
+			continue
+		}
+		d.Entries = slices.DeleteFunc(slices.Clone(d.Entries), func(e declcfg.DeprecationEntry) bool {
+			switch e.Reference.Schema {
+			case declcfg.SchemaBundle:
+				return !keptBundles[d.Package].Has(e.Reference.Name)
+			case declcfg.SchemaChannel:
+				return !slices.Contains(keptChannels[d.Package], e.Reference.Name)
+			}
+			return false
+		})
+		out.Deprecations = append(out.Deprecations, d)
+	}
+
+	for _, o := range fbc.Others {
+		if o.Package == "" || len(keptChannels[o.Package]) > 0 {
+			out.Others = append(out.Others, o)
+		}
+	}
+	return out, nil
+}
+
+// Images returns the references of the bundle images and related images of the
+// bundles of fbc, sorted and without duplicates.
+func Images(fbc *declcfg.DeclarativeConfig) []string {
+	images := sets.New[string]()
+	for _, b := range fbc.Bundles {
+		if b.Image != "" {
+			images.Insert(b.Image)
+		}
+		for _, ri := range b.RelatedImages {
+			if ri.Image != "" {
+				images.Insert(ri.Image)
+			}
+		}
+	}
+	return sets.List(images)
+}
@@ -0,0 +1,133 @@
+package catalogmirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+)
+
+func testBundle(pkg, version string, relatedImages ...string) declcfg.Bundle {
+	b := declcfg.Bundle{
+		Schema:  declcfg.SchemaBundle,
+		Package: pkg,
+		Name:    fmt.Sprintf("%s.v%s", pkg, version),
+		Image:   fmt.Sprintf("quay.io/%s/bundle:v%s", pkg, version),
+		Properties: []property.Property{{
+			Type:  property.TypePackage,
+			Value: json.RawMessage(fmt.Sprintf(`{"packageName":%q,"version":%q}`, pkg, version)),
+		}},
+	}
+	for _, image := range relatedImages {
+		b.RelatedImages = append(b.RelatedImages, declcfg.RelatedImage{Image: image})
+	}
+	return b
+}
+
+func testChannel(pkg, name string, bundles ...string) declcfg.Channel {
+	ch := declcfg.Channel{Schema: declcfg.SchemaChannel, Package: pkg, Name: name}
+	for _, b := range bundles {
+		ch.Entries = append(ch.Entries, declcfg.ChannelEntry{Name: b})
+	}
+	return ch
+}
+
+func testFBC() *declcfg.DeclarativeConfig {
+	return &declcfg.DeclarativeConfig{
+		Packages: []declcfg.Package{
+			{Schema: declcfg.SchemaPackage, Name: "foo", DefaultChannel: "stable"},
+			{Schema: declcfg.SchemaPackage, Name: "bar", DefaultChannel: "stable"},
+		},
+		Channels: []declcfg.Channel{
+			testChannel("foo", "stable", "foo.v1.0.0", "foo.v2.0.0"),
+			testChannel("foo", "fast", "foo.v1.0.0", "foo.v2.0.0", "foo.v3.0.0"),
+			testChannel("bar", "stable", "bar.v1.0.0"),
+		},
+		Bundles: []declcfg.Bundle{
+			testBundle("foo", "1.0.0", "quay.io/foo/operator:v1"),
+			testBundle("foo", "2.0.0", "quay.io/foo/operator:v2"),
+			testBundle("foo", "3.0.0", "quay.io/foo/operator:v3"),
+			testBundle("bar", "1.0.0", "quay.io/foo/operator:v1", "quay.io/bar/bundle:v1.0.0"),
+		},
+		Deprecations: []declcfg.Deprecation{{
+			Schema:  declcfg.SchemaDeprecation,
+			Package: "foo",
+			Entries: []declcfg.DeprecationEntry{
+				{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaBundle, Name: "foo.v1.0.0"}, Message: "use v2"},
+				{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaBundle, Name: "foo.v3.0.0"}, Message: "use v2"},
+				{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaChannel, Name: "fast"}, Message: "use stable"},
+			},
+		}},
+	}
+}
+
+func bundleNames(fbc *declcfg.DeclarativeConfig) []string {
+	var names []string
+	for _, b := range fbc.Bundles {
+		names = append(names, b.Name)
+	}
+	return names
+}
+
+func TestFilterApply(t *testing.T) {
+	t.Run("empty filter selects the whole catalog", func(t *testing.T) {
+		fbc := testFBC()
+		out, err := Filter{}.Apply(fbc)
+		require.NoError(t, err)
+		require.Equal(t, fbc, out)
+	})
+
+	t.Run("packages", func(t *testing.T) {
+		out, err := Filter{Packages: []string{"bar"}}.Apply(testFBC())
+		require.NoError(t, err)
+		require.Len(t, out.Packages, 1)
+		require.Equal(t, "bar", out.Packages[0].Name)
+		require.Equal(t, []string{"bar.v1.0.0"}, bundleNames(out))
+		require.Empty(t, out.Deprecations)
+	})
+
+	t.Run("channels", func(t *testing.T) {
+		out, err := Filter{Packages: []string{"foo"}, Channels: []string{"fast"}}.Apply(testFBC())
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo.v1.0.0", "foo.v2.0.0", "foo.v3.0.0"}, bundleNames(out))
+		require.Len(t, out.Channels, 1)
+		// The default channel is replaced, as it is not mirrored.
+		require.Equal(t, "fast", out.Packages[0].DefaultChannel)
+	})
+
+	t.Run("version range", func(t *testing.T) {
+		out, err := Filter{VersionRange: ">=2.0.0"}.Apply(testFBC())
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo.v2.0.0", "foo.v3.0.0"}, bundleNames(out))
+		require.Len(t, out.Packages, 1)
+		require.Equal(t, []declcfg.Channel{
+			testChannel("foo", "stable", "foo.v2.0.0"),
+			testChannel("foo", "fast", "foo.v2.0.0", "foo.v3.0.0"),
+		}, out.Channels)
+		require.Len(t, out.Deprecations, 1)
+		require.Len(t, out.Deprecations[0].Entries, 2)
+		// The input is not modified.
+		require.Len(t, testFBC().Deprecations[0].Entries, 3)
+	})
+
+	t.Run("invalid version range", func(t *testing.T) {
+		_, err := Filter{VersionRange: "not-a-range"}.Apply(testFBC())
+		require.ErrorContains(t, err, `invalid version range "not-a-range"`)
+	})
+}
+
+func TestImages(t *testing.T) {
+	require.Equal(t, []string{
+		"quay.io/bar/bundle:v1.0.0",
+		"quay.io/foo/bundle:v1.0.0",
+		"quay.io/foo/bundle:v2.0.0",
+		"quay.io/foo/bundle:v3.0.0",
+		"quay.io/foo/operator:v1",
+		"quay.io/foo/operator:v2",
+		"quay.io/foo/operator:v3",
+	}, Images(testFBC()))
+}
@@ -0,0 +1,344 @@
+// Package catalogmirror mirrors a catalog image, and the bundle images and related
+// images referenced by its file-based catalog, to a registry or an OCI layout, so that
+// the catalog can be used in disconnected environments.
+package catalogmirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/oci/layout"
+	"go.podman.io/image/v5/pkg/sysregistriesv2"
+	"go.podman.io/image/v5/types"
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	fsutil "github.com/operator-framework/operator-controller/internal/shared/util/fs"
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+)
+
+const (
+	// CatalogDir is the directory of the output directory that the mirrored FBC is
+	// written to, in which images are referenced by their mirrored references.
+	CatalogDir = "catalog"
+	// RegistriesConfFile is the file of the output directory that the
+	// containers-registries.conf(5) configuration pulling the source images from their
+	// mirrors is written to.
+	RegistriesConfFile = "registries.conf"
+)
+
+// Destination is where images are mirrored to. Exactly one of its fields must be set.
+type Destination struct {
+	// Registry is the registry, and optionally the repository namespace, that images
+	// are mirrored to, e.g. mirror.example.com:5000/olm. The repository of a mirrored
+	// image is the path of its source repository in Registry, and its tag or digest
+	// is the one of its source reference.
+	Registry string
+	// OCILayout is the directory of the OCI layout that images are mirrored to. The
+	// images are named after their source references in the layout.
+	OCILayout string
+}
+
+func (d Destination) validate() error {
+	if (d.Registry == "") == (d.OCILayout == "") {
+		return errors.New("exactly one of a registry or an OCI layout must be set as destination")
+	}
+	return nil
+}
+
+// reference returns the reference that src is mirrored to, along with its Docker
+// reference when it is mirrored to a registry.
+func (d Destination) reference(src reference.Named) (types.ImageReference, reference.Named, error) {
+	if d.OCILayout != "" {
+		ref, err := layout.NewReference(d.OCILayout, src.String())
+		return ref, nil, err
+	}
+	named, err := reference.ParseNormalizedNamed(strings.TrimSuffix(d.Registry, "/") + "/" + reference.Path(src))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating mirrored reference of %q: %w", src, err)
+	}
+	switch src := src.(type) {
+	case reference.Canonical:
+		named, err = reference.WithDigest(named, src.Digest())
+	case reference.Tagged:
+		named, err = reference.WithTag(named, src.Tag())
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating mirrored reference of %q: %w", src, err)
+	}
+	ref, err := docker.NewReference(named)
+	return ref, named, err
+}
+
+// Mapping maps the reference of a source image to the reference of its mirror in a
+// registry.
+type Mapping struct {
+	Source      reference.Named
+	Destination reference.Named
+}
+
+// Options configure Run.
+type Options struct {
+	// CatalogImage is the reference of the catalog image to mirror, e.g. the
+	// spec.source.image.ref of a ClusterCatalog.
+	CatalogImage string
+	// Filter selects the part of the catalog whose bundle and related images are
+	// mirrored. The catalog image is always mirrored as a whole.
+	Filter Filter
+	// Destination is where the images are mirrored to.
+	Destination Destination
+	// OutputDir, if set, is the directory that the mirrored FBC, and the registries
+	// configuration when mirroring to a registry, are written to.
+	OutputDir string
+	// SourceCtx and DestinationCtx are the SystemContexts used to pull images from
+	// their source and push them to the destination.
+	SourceCtx      *types.SystemContext
+	DestinationCtx *types.SystemContext
+	// Parallelism is the number of images mirrored concurrently. Images are mirrored
+	// one at a time when it is not positive.
+	Parallelism int
+}
+
+// Run mirrors the catalog image of opts, and the bundle images and related images of
+// the part of its FBC selected by the filter, to the destination. When mirroring to a
+// registry, the references of these images are replaced with the references of their
+// mirrors in the FBC written to the output directory, which also receives a registries
+// configuration pulling all mirrored images from their mirrors. Run returns the
+// mappings of the mirrored images, which are empty when mirroring to an OCI layout.
+func Run(ctx context.Context, opts Options) ([]Mapping, error) {
+	if err := opts.Destination.validate(); err != nil {
+		return nil, err
+	}
+	catalogRef, err := reference.ParseNormalizedNamed(opts.CatalogImage)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing catalog image reference %q: %w", opts.CatalogImage, err)
+	}
+
+	fbc, err := LoadCatalog(ctx, opts.CatalogImage, opts.SourceCtx)
+	if err != nil {
+		return nil, err
+	}
+	fbc, err = opts.Filter.Apply(fbc)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := []reference.Named{reference.TagNameOnly(catalogRef)}
+	for _, image := range Images(fbc) {
+		ref, err := reference.ParseNormalizedNamed(image)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing image reference %q: %w", image, err)
+		}
+		sources = append(sources, ref)
+	}
+	mappings, err := mirror(ctx, sources, opts)
+	if err != nil {
+		return nil, err
+	}
+	log.FromContext(ctx).Info("mirrored images", "count", len(sources))
+
+	if opts.OutputDir == "" {
+		return mappings, nil
+	}
+	if opts.Destination.Registry != "" {
+		if err := RewriteImages(fbc, mappings); err != nil {
+			return nil, err
+		}
+		if err := writeRegistriesConf(filepath.Join(opts.OutputDir, RegistriesConfFile), mappings); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeCatalog(filepath.Join(opts.OutputDir, CatalogDir), fbc); err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// LoadCatalog pulls the catalog image ref and loads its FBC.
+func LoadCatalog(ctx context.Context, ref string, srcCtx *types.SystemContext) (*declcfg.DeclarativeConfig, error) {
+	cacheDir, err := os.MkdirTemp("", "catalog-mirror-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	defer func() {
+		if err := fsutil.DeleteReadOnlyRecursive(cacheDir); err != nil {
+			log.FromContext(ctx).Error(err, "error removing temporary directory")
+		}
+	}()
+
+	puller := &imageutil.ContainersImagePuller{
+		SourceCtxFunc: func(context.Context) (*types.SystemContext, error) {
+			return srcCtx, nil
+		},
+	}
+	fsys, _, _, err := puller.Pull(ctx, "catalog", ref, imageutil.CatalogCache(cacheDir))
+	if err != nil {
+		return nil, fmt.Errorf("error pulling catalog image: %w", err)
+	}
+	fbc, err := declcfg.LoadFS(ctx, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("error loading catalog: %w", err)
+	}
+	return fbc, nil
+}
+
+// mirror mirrors the images of sources to the destination of opts, and returns their
+// mappings when it is a registry.
+func mirror(ctx context.Context, sources []reference.Named, opts Options) ([]Mapping, error) {
+	type mirroredImage struct {
+		src, dest types.ImageReference
+		mapping   *Mapping
+	}
+	images := make([]mirroredImage, 0, len(sources))
+	sourceRepos := map[string]string{}
+	for _, src := range sources {
+		srcRef, err := docker.NewReference(src)
+		if err != nil {
+			return nil, fmt.Errorf("error creating reference of %q: %w", src, err)
+		}
+		destRef, destNamed, err := opts.Destination.reference(src)
+		if err != nil {
+			return nil, err
+		}
+		image := mirroredImage{src: srcRef, dest: destRef}
+		if destNamed != nil {
+			// Repositories of different registries with the same path would be
+			// mirrored to the same repository.
+			destRepo, srcRepo := destNamed.Name(), src.Name()
+			if other, ok := sourceRepos[destRepo]; ok && other != srcRepo {
+				return nil, fmt.Errorf("repositories %q and %q would both be mirrored to %q", other, srcRepo, destRepo)
+			}
+			sourceRepos[destRepo] = srcRepo
+			image.mapping = &Mapping{Source: src, Destination: destNamed}
+		}
+		images = append(images, image)
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(max(opts.Parallelism, 1))
+	var (
+		mu       sync.Mutex
+		mappings []Mapping
+	)
+	for _, image := range images {
+		eg.Go(func() error {
+			l := log.FromContext(egCtx).WithValues("image", image.src.StringWithinTransport())
+			if _, err := imageutil.Mirror(log.IntoContext(egCtx, l), image.src, image.dest, opts.SourceCtx, opts.DestinationCtx); err != nil {
+				return fmt.Errorf("error mirroring %q: %w", image.src.StringWithinTransport(), err)
+			}
+			l.V(1).Info("mirrored image", "destination", image.dest.StringWithinTransport())
+			if image.mapping != nil {
+				mu.Lock()
+				mappings = append(mappings, *image.mapping)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(mappings, func(a, b Mapping) int {
+		return strings.Compare(a.Source.String(), b.Source.String())
+	})
+	return mappings, nil
+}
+
+// RewriteImages replaces the references of the bundle images and related images of
+// fbc with the references of their mirrors.
+func RewriteImages(fbc *declcfg.DeclarativeConfig, mappings []Mapping) error {
+	mirrors := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		mirrors[m.Source.String()] = m.Destination.String()
+	}
+	rewrite := func(image string) (string, error) {
+		if image == "" {
+			return "", nil
+		}
+		ref, err := reference.ParseNormalizedNamed(image)
+		if err != nil {
+			return "", fmt.Errorf("error parsing image reference %q: %w", image, err)
+		}
+		if mirror, ok := mirrors[ref.String()]; ok {
+			return mirror, nil
+		}
+		return image, nil
+	}
+
+	var err error
+	for i := range fbc.Bundles {
+		b := &fbc.Bundles[i]
+		if b.Image, err = rewrite(b.Image); err != nil {
+			return err
+		}
+		for j := range b.RelatedImages {
+			if b.RelatedImages[j].Image, err = rewrite(b.RelatedImages[j].Image); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RegistriesConf returns a containers-registries.conf(5) configuration that pulls the
+// images of the source repositories of mappings from the repositories of their mirrors.
+// It allows pulling images referenced by the workloads of installed bundles, and
+// catalog images referenced by ClusterCatalogs, from their mirrors without changing
+// their references.
+func RegistriesConf(mappings []Mapping) sysregistriesv2.V2RegistriesConf {
+	mirrors := map[string]string{}
+	for _, m := range mappings {
+		mirrors[m.Source.Name()] = m.Destination.Name()
+	}
+	conf := sysregistriesv2.V2RegistriesConf{}
+	for _, src := range slices.Sorted(maps.Keys(mirrors)) {
+		conf.Registries = append(conf.Registries, sysregistriesv2.Registry{
+			Prefix:   src,
+			Endpoint: sysregistriesv2.Endpoint{Location: src},
+			Mirrors:  []sysregistriesv2.Endpoint{{Location: mirrors[src]}},
+		})
+	}
+	return conf
+}
+
+func writeRegistriesConf(path string, mappings []Mapping) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := toml.NewEncoder(f).Encode(RegistriesConf(mappings)); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("error writing registries configuration: %w", err)
+	}
+	return f.Close()
+}
+
+func writeCatalog(dir string, fbc *declcfg.DeclarativeConfig) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, "catalog.json"))
+	if err != nil {
+		return err
+	}
+	if err := declcfg.WriteJSON(*fbc, f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("error writing catalog: %w", err)
+	}
+	return f.Close()
+}
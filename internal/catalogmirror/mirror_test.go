@@ -0,0 +1,217 @@
+package catalogmirror
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/oci/layout"
+	"go.podman.io/image/v5/pkg/sysregistriesv2"
+	"go.podman.io/image/v5/types"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+)
+
+func startRegistry(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return u.Host
+}
+
+// systemContext returns a SystemContext pulling from and pushing to the test
+// registries over plain HTTP, with an insecure signature policy.
+func systemContext(t *testing.T) *types.SystemContext {
+	t.Helper()
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "policy.json")
+	require.NoError(t, os.WriteFile(policyPath, []byte(`{"default":[{"type":"insecureAcceptAnything"}]}`), 0600))
+	registriesConfPath := filepath.Join(dir, "registries.conf")
+	require.NoError(t, os.WriteFile(registriesConfPath, nil, 0600))
+	return &types.SystemContext{
+		SignaturePolicyPath:         policyPath,
+		SystemRegistriesConfPath:    registriesConfPath,
+		DockerInsecureSkipTLSVerify: types.OptionalBoolTrue,
+	}
+}
+
+// pushTestCatalog pushes a catalog image with the test FBC, referencing bundle and
+// related images in the given registry, along with these images.
+func pushTestCatalog(t *testing.T, host string) string {
+	t.Helper()
+	fbc := testFBC()
+	for i := range fbc.Bundles {
+		b := &fbc.Bundles[i]
+		b.Image = host + "/" + reference.Path(mustParse(t, b.Image)) + ":" + mustParse(t, b.Image).(reference.Tagged).Tag()
+		for j := range b.RelatedImages {
+			ri := mustParse(t, b.RelatedImages[j].Image)
+			b.RelatedImages[j].Image = host + "/" + reference.Path(ri) + ":" + ri.(reference.Tagged).Tag()
+		}
+	}
+	for _, image := range Images(fbc) {
+		img, err := crane.Image(map[string][]byte{"image": []byte(image)})
+		require.NoError(t, err)
+		require.NoError(t, crane.Push(img, image))
+	}
+
+	catalogFile := filepath.Join(t.TempDir(), "catalog.json")
+	f, err := os.Create(catalogFile)
+	require.NoError(t, err)
+	require.NoError(t, declcfg.WriteJSON(*fbc, f))
+	require.NoError(t, f.Close())
+	data, err := os.ReadFile(catalogFile)
+	require.NoError(t, err)
+
+	img, err := crane.Image(map[string][]byte{"configs/catalog.json": data})
+	require.NoError(t, err)
+	cfg, err := img.ConfigFile()
+	require.NoError(t, err)
+	cfg.Config.Labels = map[string]string{imageutil.ConfigDirLabel: "/configs"}
+	img, err = mutate.ConfigFile(img, cfg)
+	require.NoError(t, err)
+	catalogImage := host + "/catalogs/test-catalog:latest"
+	require.NoError(t, crane.Push(img, catalogImage))
+	return catalogImage
+}
+
+func mustParse(t *testing.T, image string) reference.Named {
+	t.Helper()
+	ref, err := reference.ParseNormalizedNamed(image)
+	require.NoError(t, err)
+	return ref
+}
+
+func TestRunToRegistry(t *testing.T) {
+	src, dest := startRegistry(t), startRegistry(t)
+	catalogImage := pushTestCatalog(t, src)
+	outputDir := t.TempDir()
+
+	mappings, err := Run(context.Background(), Options{
+		CatalogImage:   catalogImage,
+		Filter:         Filter{Packages: []string{"foo"}, VersionRange: ">=2.0.0"},
+		Destination:    Destination{Registry: dest + "/mirror"},
+		OutputDir:      outputDir,
+		SourceCtx:      systemContext(t),
+		DestinationCtx: systemContext(t),
+		Parallelism:    2,
+	})
+	require.NoError(t, err)
+
+	var mirrored []string
+	for _, m := range mappings {
+		mirrored = append(mirrored, m.Destination.String())
+		srcDigest, err := crane.Digest(m.Source.String())
+		require.NoError(t, err)
+		destDigest, err := crane.Digest(m.Destination.String())
+		require.NoError(t, err)
+		require.Equal(t, srcDigest, destDigest)
+	}
+	require.Equal(t, []string{
+		dest + "/mirror/catalogs/test-catalog:latest",
+		dest + "/mirror/foo/bundle:v2.0.0",
+		dest + "/mirror/foo/bundle:v3.0.0",
+		dest + "/mirror/foo/operator:v2",
+		dest + "/mirror/foo/operator:v3",
+	}, mirrored)
+
+	// Bundle and related images are referenced by their mirrors in the written FBC.
+	fbc, err := declcfg.LoadFS(context.Background(), os.DirFS(filepath.Join(outputDir, CatalogDir)))
+	require.NoError(t, err)
+	require.Len(t, fbc.Bundles, 2)
+	require.Equal(t, dest+"/mirror/foo/bundle:v2.0.0", fbc.Bundles[0].Image)
+	require.Equal(t, dest+"/mirror/foo/operator:v2", fbc.Bundles[0].RelatedImages[0].Image)
+
+	var conf sysregistriesv2.V2RegistriesConf
+	_, err = toml.DecodeFile(filepath.Join(outputDir, RegistriesConfFile), &conf)
+	require.NoError(t, err)
+	require.Len(t, conf.Registries, 3)
+	require.Equal(t, sysregistriesv2.Registry{
+		Prefix:   src + "/catalogs/test-catalog",
+		Endpoint: sysregistriesv2.Endpoint{Location: src + "/catalogs/test-catalog"},
+		Mirrors:  []sysregistriesv2.Endpoint{{Location: dest + "/mirror/catalogs/test-catalog"}},
+	}, conf.Registries[0])
+}
+
+func TestRunToOCILayout(t *testing.T) {
+	src := startRegistry(t)
+	catalogImage := pushTestCatalog(t, src)
+	layoutDir := filepath.Join(t.TempDir(), "layout")
+	outputDir := t.TempDir()
+
+	mappings, err := Run(context.Background(), Options{
+		CatalogImage: catalogImage,
+		Filter:       Filter{Packages: []string{"bar"}},
+		Destination:  Destination{OCILayout: layoutDir},
+		OutputDir:    outputDir,
+		SourceCtx:    systemContext(t),
+	})
+	require.NoError(t, err)
+	require.Empty(t, mappings)
+
+	for _, image := range []string{catalogImage, src + "/bar/bundle:v1.0.0", src + "/foo/operator:v1"} {
+		ref, err := layout.NewReference(layoutDir, image)
+		require.NoError(t, err)
+		img, err := ref.NewImageSource(context.Background(), nil)
+		require.NoError(t, err, image)
+		require.NoError(t, img.Close())
+	}
+
+	// Images keep their references in the written FBC, and no registries
+	// configuration is written.
+	fbc, err := declcfg.LoadFS(context.Background(), os.DirFS(filepath.Join(outputDir, CatalogDir)))
+	require.NoError(t, err)
+	require.Equal(t, src+"/bar/bundle:v1.0.0", fbc.Bundles[0].Image)
+	require.NoFileExists(t, filepath.Join(outputDir, RegistriesConfFile))
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{
+			name:    "no destination",
+			opts:    Options{CatalogImage: "quay.io/catalogs/test-catalog:latest"},
+			wantErr: "exactly one of a registry or an OCI layout must be set",
+		},
+		{
+			name:    "both destinations",
+			opts:    Options{CatalogImage: "quay.io/catalogs/test-catalog:latest", Destination: Destination{Registry: "mirror.example.com", OCILayout: "layout"}},
+			wantErr: "exactly one of a registry or an OCI layout must be set",
+		},
+		{
+			name:    "invalid catalog image",
+			opts:    Options{CatalogImage: "Invalid", Destination: Destination{Registry: "mirror.example.com"}},
+			wantErr: `error parsing catalog image reference "Invalid"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Run(context.Background(), tc.opts)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestMirrorRepositoryCollision(t *testing.T) {
+	_, err := mirror(context.Background(), []reference.Named{
+		mustParse(t, "quay.io/foo/operator:v1"),
+		mustParse(t, "registry.example.com/foo/operator:v1"),
+	}, Options{Destination: Destination{Registry: "mirror.example.com"}})
+	require.ErrorContains(t, err, fmt.Sprintf("repositories %q and %q would both be mirrored to %q",
+		"quay.io/foo/operator", "registry.example.com/foo/operator", "mirror.example.com/foo/operator"))
+}
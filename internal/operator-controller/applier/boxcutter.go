@@ -108,6 +108,9 @@ func (r *SimpleRevisionGenerator) GenerateRevisionFromHelmRelease(
 	if v, ok := helmRelease.Labels[labels.BundleReleaseKey]; ok {
 		revisionAnnotations[labels.BundleReleaseKey] = v
 	}
+	if v := ReleaseCatalog(helmRelease); v != "" {
+		revisionAnnotations[labels.CatalogNameKey] = v
+	}
 	rev := r.buildClusterObjectSet(objs, ext, revisionAnnotations)
 	rev.WithName(fmt.Sprintf("%s-1", ext.Name))
 	rev.Spec.WithRevision(1)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"slices"
 	"strings"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/operator-framework/operator-controller/internal/operator-controller/contentmanager"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/contentmanager/cache"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/features"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/util"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
 )
@@ -98,6 +100,7 @@ func (h *Helm) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExte
 	if err != nil {
 		return false, "", err
 	}
+	storageLabels = recordCatalogInChart(chrt, storageLabels)
 	values := chartutil.Values{}
 
 	post := &postrenderer{
@@ -244,6 +247,35 @@ func (h *Helm) reconcileExistingRelease(ctx context.Context, ac helmclient.Actio
 	return true, "", nil
 }
 
+// recordCatalogInChart moves the name of the catalog that provided the bundle from
+// storageLabels to the annotations of the chart. The key of a namespaced Catalog can be
+// much longer than the 63 characters allowed in label values, which release labels may
+// be stored as.
+func recordCatalogInChart(chrt *chart.Chart, storageLabels map[string]string) map[string]string {
+	catalog, ok := storageLabels[labels.CatalogNameKey]
+	if !ok {
+		return storageLabels
+	}
+	if chrt.Metadata == nil {
+		chrt.Metadata = &chart.Metadata{}
+	}
+	chrt.Metadata.Annotations = util.MergeMaps(chrt.Metadata.Annotations, map[string]string{labels.CatalogNameKey: catalog})
+	storageLabels = maps.Clone(storageLabels)
+	delete(storageLabels, labels.CatalogNameKey)
+	return storageLabels
+}
+
+// ReleaseCatalog returns the name of the catalog that provided the bundle of a release,
+// recorded by recordCatalogInChart, or in the labels of releases installed before.
+func ReleaseCatalog(rel *release.Release) string {
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		if catalog := rel.Chart.Metadata.Annotations[labels.CatalogNameKey]; catalog != "" {
+			return catalog
+		}
+	}
+	return rel.Labels[labels.CatalogNameKey]
+}
+
 func (h *Helm) buildHelmChart(bundleFS fs.FS, ext *ocv1.ClusterExtension) (*chart.Chart, error) {
 	if h.HelmChartProvider == nil {
 		return nil, errors.New("HelmChartProvider is nil")
@@ -0,0 +1,43 @@
+package applier
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+
+	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
+)
+
+func TestRecordCatalogInChart(t *testing.T) {
+	// The key of a namespaced Catalog may be much longer than a label value.
+	catalog := strings.Repeat("n", 63) + "_" + strings.Repeat("c", 253)
+	storageLabels := map[string]string{
+		labels.BundleNameKey:  "argocd.v1.0.0",
+		labels.CatalogNameKey: catalog,
+	}
+	chrt := &chart.Chart{Metadata: &chart.Metadata{Name: "argocd", Annotations: map[string]string{"owner": "team-a"}}}
+
+	recorded := recordCatalogInChart(chrt, storageLabels)
+	require.Equal(t, map[string]string{labels.BundleNameKey: "argocd.v1.0.0"}, recorded)
+	require.Equal(t, catalog, storageLabels[labels.CatalogNameKey], "storageLabels of the caller must not be modified")
+	require.Equal(t, map[string]string{"owner": "team-a", labels.CatalogNameKey: catalog}, chrt.Metadata.Annotations)
+	require.Equal(t, catalog, ReleaseCatalog(&release.Release{Chart: chrt, Labels: recorded}))
+
+	noCatalog := map[string]string{labels.BundleNameKey: "argocd.v1.0.0"}
+	chrt = &chart.Chart{}
+	require.Equal(t, noCatalog, recordCatalogInChart(chrt, noCatalog))
+	require.Nil(t, chrt.Metadata)
+}
+
+func TestReleaseCatalogFromLabels(t *testing.T) {
+	// Releases installed before the catalog was recorded in the chart have it in their labels.
+	rel := &release.Release{
+		Chart:  &chart.Chart{Metadata: &chart.Metadata{Name: "argocd"}},
+		Labels: map[string]string{labels.CatalogNameKey: "community"},
+	}
+	require.Equal(t, "community", ReleaseCatalog(rel))
+	require.Empty(t, ReleaseCatalog(&release.Release{}))
+}
@@ -1,27 +1,54 @@
 package cache
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
 
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client"
 )
 
-var _ client.Cache = &filesystemCache{}
+var (
+	_ client.PackageCache     = &filesystemCache{}
+	_ client.ProvidedAPIIndex = &filesystemCache{}
+)
+
+// FilesystemCacheOption configures a filesystemCache.
+type FilesystemCacheOption func(*filesystemCache)
+
+// WithMaxPackageCacheSize sets the estimated size in memory of the parsed packages
+// that the cache keeps. It defaults to DefaultMaxPackageCacheSize.
+func WithMaxPackageCacheSize(size int64) FilesystemCacheOption {
+	return func(fsc *filesystemCache) {
+		fsc.packages = newPackageCache(size)
+	}
+}
 
-func NewFilesystemCache(cachePath string) *filesystemCache {
-	return &filesystemCache{
+func NewFilesystemCache(cachePath string, opts ...FilesystemCacheOption) *filesystemCache {
+	fsc := &filesystemCache{
 		cachePath:              cachePath,
 		mutex:                  sync.RWMutex{},
 		cacheDataByCatalogName: map[string]cacheData{},
+		packages:               newPackageCache(DefaultMaxPackageCacheSize),
 	}
+	for _, opt := range opts {
+		opt(fsc)
+	}
+	return fsc
 }
 
 // cacheData holds information about a catalog
@@ -31,15 +58,21 @@ func NewFilesystemCache(cachePath string) *filesystemCache {
 type cacheData struct {
 	Ref   string
 	Error error
+	// PackagesByGVK indexes the names of the packages, in lexical order, by the
+	// APIs that their bundles provide.
+	PackagesByGVK map[property.GVK][]string
 }
 
 // FilesystemCache is a cache that
 // uses the local filesystem for caching
-// catalog contents.
+// catalog contents, and keeps the packages
+// parsed from them in memory.
 type filesystemCache struct {
 	mutex                  sync.RWMutex
 	cachePath              string
 	cacheDataByCatalogName map[string]cacheData
+	packages               *packageCache
+	packageLoads           singleflight.Group
 }
 
 // Put writes content from source to the filesystem and stores errToCache
@@ -56,36 +89,46 @@ type filesystemCache struct {
 //
 // This cache implementation tracks only one version of cache per catalog,
 // so Put will override any existing cache on the filesystem for catalogName
-// if resolvedRef does not match the one which is already tracked.
+// if resolvedRef does not match the one which is already tracked, and drop
+// the parsed packages of the catalog.
 func (fsc *filesystemCache) Put(catalogName, resolvedRef string, source io.Reader, errToCache error) (fs.FS, error) {
 	fsc.mutex.Lock()
 	defer fsc.mutex.Unlock()
 
+	fsc.packages.removeCatalog(catalogName)
+
 	var cacheFS fs.FS
+	var packagesByGVK map[property.GVK][]string
 	if errToCache == nil {
-		cacheFS, errToCache = fsc.writeFS(catalogName, source)
+		cacheFS, packagesByGVK, errToCache = fsc.writeFS(catalogName, source)
 	}
 	fsc.cacheDataByCatalogName[catalogName] = cacheData{
-		Ref:   resolvedRef,
-		Error: errToCache,
+		Ref:           resolvedRef,
+		Error:         errToCache,
+		PackagesByGVK: packagesByGVK,
 	}
 
 	return cacheFS, errToCache
 }
 
-func (fsc *filesystemCache) writeFS(catalogName string, source io.Reader) (fs.FS, error) {
+// writeFS writes the catalog contents read from source to the filesystem, and
+// returns them with the names of their packages indexed by the APIs that their
+// bundles provide.
+func (fsc *filesystemCache) writeFS(catalogName string, source io.Reader) (fs.FS, map[property.GVK][]string, error) {
 	cacheDir := fsc.cacheDir(catalogName)
 
 	if err := fsc.removeOrphanedTempDirs(catalogName); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	tmpDir, err := os.MkdirTemp(fsc.cachePath, fmt.Sprintf(".%s-", catalogName))
 	if err != nil {
-		return nil, fmt.Errorf("error creating temporary directory to unpack catalog metadata: %v", err)
+		return nil, nil, fmt.Errorf("error creating temporary directory to unpack catalog metadata: %v", err)
 	}
 	defer os.RemoveAll(tmpDir)
 
+	packagesByGVK := map[property.GVK]sets.Set[string]{}
+
 	if err := declcfg.WalkMetasReader(source, func(meta *declcfg.Meta, err error) error {
 		if err != nil {
 			return fmt.Errorf("error parsing catalog contents: %v", err)
@@ -105,19 +148,55 @@ func (fsc *filesystemCache) writeFS(catalogName string, source io.Reader) (fs.FS
 		if err := os.WriteFile(metaPath, meta.Blob, 0600); err != nil {
 			return fmt.Errorf("error writing catalog metadata to file: %v", err)
 		}
+		if meta.Schema == declcfg.SchemaBundle {
+			for _, gvk := range providedGVKs(meta.Blob) {
+				if packagesByGVK[gvk] == nil {
+					packagesByGVK[gvk] = sets.New[string]()
+				}
+				packagesByGVK[gvk].Insert(pkgName)
+			}
+		}
 		return nil
 	}); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := os.RemoveAll(cacheDir); err != nil {
-		return nil, fmt.Errorf("error removing old cache directory: %v", err)
+		return nil, nil, fmt.Errorf("error removing old cache directory: %v", err)
 	}
 	if err := os.Rename(tmpDir, cacheDir); err != nil {
-		return nil, fmt.Errorf("error moving temporary directory to cache directory: %v", err)
+		return nil, nil, fmt.Errorf("error moving temporary directory to cache directory: %v", err)
 	}
 
-	return os.DirFS(cacheDir), nil
+	index := make(map[property.GVK][]string, len(packagesByGVK))
+	for gvk, pkgNames := range packagesByGVK {
+		index[gvk] = sets.List(pkgNames)
+	}
+	return os.DirFS(cacheDir), index, nil
+}
+
+// providedGVKs returns the APIs provided by a bundle, as listed by its olm.gvk
+// properties. Properties that cannot be parsed are ignored, as they are when
+// resolving the bundle.
+func providedGVKs(bundleBlob []byte) []property.GVK {
+	var bundle struct {
+		Properties []property.Property `json:"properties"`
+	}
+	if err := json.Unmarshal(bundleBlob, &bundle); err != nil {
+		return nil
+	}
+	var gvks []property.GVK
+	for _, p := range bundle.Properties {
+		if p.Type != property.TypeGVK {
+			continue
+		}
+		var gvk property.GVK
+		if err := json.Unmarshal(p.Value, &gvk); err != nil {
+			continue
+		}
+		gvks = append(gvks, gvk)
+	}
+	return gvks
 }
 
 // Get returns cache for a specified catalog name and version (resolvedRef).
@@ -148,6 +227,64 @@ func (fsc *filesystemCache) get(catalogName, resolvedRef string) (fs.FS, error)
 	return nil, nil
 }
 
+// GetPackage returns the package pkgName from the cache for a specified catalog
+// name and version (resolvedRef), as described by client.PackageCache. Packages
+// are parsed once, and kept in memory until the cache of the catalog is replaced
+// or removed, or they are evicted to make room for more recently used packages.
+func (fsc *filesystemCache) GetPackage(ctx context.Context, catalogName, resolvedRef, pkgName string) (*declcfg.DeclarativeConfig, error) {
+	// Holding the read lock while loading the package ensures that the cache
+	// of the catalog is not replaced while it is being parsed.
+	fsc.mutex.RLock()
+	defer fsc.mutex.RUnlock()
+
+	catalogFsys, err := fsc.get(catalogName, resolvedRef)
+	if err != nil || catalogFsys == nil {
+		return nil, err
+	}
+
+	key := packageKey{catalogName: catalogName, resolvedRef: resolvedRef, pkgName: pkgName}
+	if fbc, ok := fsc.packages.get(key); ok {
+		return copyConfig(fbc), nil
+	}
+
+	// Concurrent resolutions of the same package wait for a single load, which is
+	// not canceled with the context of the resolution that started it, as it would
+	// fail the others.
+	v, err, _ := fsc.packageLoads.Do(fmt.Sprintf("%s/%s/%s", catalogName, resolvedRef, pkgName), func() (any, error) {
+		fbc, err := client.LoadPackage(context.WithoutCancel(ctx), catalogFsys, pkgName)
+		if err != nil {
+			return nil, err
+		}
+		size, err := packageSize(catalogFsys, pkgName)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("error getting size of package %q: %v", pkgName, err)
+		}
+		fsc.packages.add(key, fbc, packageCost(size))
+		return fbc, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyConfig(v.(*declcfg.DeclarativeConfig)), nil
+}
+
+// PackagesProvidingGVK returns the names of the packages, in lexical order, with
+// bundles that provide gvk, from the cache for a specified catalog name and
+// version (resolvedRef), as described by client.ProvidedAPIIndex.
+func (fsc *filesystemCache) PackagesProvidingGVK(catalogName, resolvedRef string, gvk property.GVK) ([]string, bool, error) {
+	fsc.mutex.RLock()
+	defer fsc.mutex.RUnlock()
+
+	data, ok := fsc.cacheDataByCatalogName[catalogName]
+	if !ok || data.Ref != resolvedRef {
+		return nil, false, nil
+	}
+	if data.Error != nil {
+		return nil, false, data.Error
+	}
+	return slices.Clone(data.PackagesByGVK[gvk]), true, nil
+}
+
 // Remove deletes cache directory for a given catalog from the filesystem
 func (fsc *filesystemCache) Remove(catalogName string) error {
 	cacheDir := fsc.cacheDir(catalogName)
@@ -155,6 +292,7 @@ func (fsc *filesystemCache) Remove(catalogName string) error {
 	fsc.mutex.Lock()
 	defer fsc.mutex.Unlock()
 
+	fsc.packages.removeCatalog(catalogName)
 	if _, exists := fsc.cacheDataByCatalogName[catalogName]; !exists {
 		return nil
 	}
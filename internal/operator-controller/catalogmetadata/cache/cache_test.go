@@ -2,6 +2,7 @@ package cache_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,9 +17,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/cache"
+	catalogclient "github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client"
 )
 
 const (
@@ -193,6 +200,231 @@ func TestFilesystemCachePutCleansOrphanedTempDirs(t *testing.T) {
 	assert.DirExists(t, filepath.Join(cacheDir, catalogName), "real cache dir should exist")
 }
 
+func TestFilesystemCacheGetPackage(t *testing.T) {
+	const (
+		catalogName = "test-catalog"
+		resolvedRef = "fake/catalog@sha256:fakesha"
+	)
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	c := cache.NewFilesystemCache(cacheDir)
+
+	t.Log("Get package from empty cache")
+	fbc, err := c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	assert.Nil(t, fbc)
+
+	t.Log("Get package from cache populated with an error")
+	_, err = c.Put(catalogName, resolvedRef, nil, errors.New("fake put error"))
+	require.Error(t, err)
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.EqualError(t, err, "fake put error")
+	assert.Nil(t, fbc)
+
+	t.Log("Get package from populated cache")
+	_, err = c.Put(catalogName, resolvedRef, defaultContent(), nil)
+	require.NoError(t, err)
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	require.Len(t, fbc.Bundles, 1)
+	assert.Equal(t, "fake1.v1.0.0", fbc.Bundles[0].Name)
+
+	t.Log("Filtering the returned package does not modify the parsed package")
+	fbc.Bundles = fbc.Bundles[:0]
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	assert.Len(t, fbc.Bundles, 1)
+
+	t.Log("Get missing package")
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "missing")
+	require.NoError(t, err)
+	assert.Equal(t, &declcfg.DeclarativeConfig{}, fbc)
+
+	t.Log("Loading a package is not canceled with the context of the caller")
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = c.Put(catalogName, resolvedRef, defaultContent(), nil)
+	require.NoError(t, err)
+	fbc, err = c.GetPackage(canceledCtx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	assert.Len(t, fbc.Bundles, 1)
+
+	t.Log("Get parsed package without reading the filesystem")
+	require.NoError(t, os.RemoveAll(filepath.Join(cacheDir, catalogName, "fake1")))
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	assert.Len(t, fbc.Bundles, 1)
+
+	t.Log("Put drops the parsed packages of the catalog")
+	_, err = c.Put(catalogName, resolvedRef, strings.NewReader(package1+stableChannel), nil)
+	require.NoError(t, err)
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	assert.Empty(t, fbc.Bundles)
+
+	t.Log("Remove drops the parsed packages of the catalog")
+	require.NoError(t, c.Remove(catalogName))
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	assert.Nil(t, fbc)
+}
+
+func TestFilesystemCachePackagesProvidingGVK(t *testing.T) {
+	const (
+		catalogName = "test-catalog"
+		resolvedRef = "fake/catalog@sha256:fakesha"
+	)
+	certificateGVK := property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+	apiBundle := func(pkgName string, gvks ...property.GVK) string {
+		props := []property.Property{property.MustBuildPackage(pkgName, "1.0.0")}
+		for _, gvk := range gvks {
+			props = append(props, property.MustBuildGVK(gvk.Group, gvk.Version, gvk.Kind))
+		}
+		b, err := json.Marshal(declcfg.Bundle{Schema: declcfg.SchemaBundle, Name: pkgName + ".v1.0.0", Package: pkgName, Properties: props})
+		require.NoError(t, err)
+		return string(b)
+	}
+	c := cache.NewFilesystemCache(t.TempDir())
+
+	t.Log("Get packages from empty cache")
+	pkgNames, found, err := c.PackagesProvidingGVK(catalogName, resolvedRef, certificateGVK)
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, pkgNames)
+
+	t.Log("Get packages from cache populated with an error")
+	_, err = c.Put(catalogName, resolvedRef, nil, errors.New("fake put error"))
+	require.Error(t, err)
+	_, _, err = c.PackagesProvidingGVK(catalogName, resolvedRef, certificateGVK)
+	require.EqualError(t, err, "fake put error")
+
+	t.Log("Get packages from populated cache")
+	_, err = c.Put(catalogName, resolvedRef, strings.NewReader(
+		apiBundle("cert-utils", certificateGVK)+
+			apiBundle("cert-manager", property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"}, certificateGVK)+
+			apiBundle("fake1")+
+			`{"schema": "olm.bundle", "name": "broken.v1.0.0", "package": "broken", "properties": [{"type": "olm.gvk", "value": "broken"}]}`,
+	), nil)
+	require.NoError(t, err)
+	pkgNames, found, err = c.PackagesProvidingGVK(catalogName, resolvedRef, certificateGVK)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"cert-manager", "cert-utils"}, pkgNames)
+
+	pkgNames, found, err = c.PackagesProvidingGVK(catalogName, resolvedRef, property.GVK{Group: "example.com", Version: "v1", Kind: "Missing"})
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Empty(t, pkgNames)
+
+	t.Log("Get packages of another version of the catalog")
+	_, found, err = c.PackagesProvidingGVK(catalogName, "fake/catalog@sha256:othersha", certificateGVK)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	t.Log("Remove drops the index of the catalog")
+	require.NoError(t, c.Remove(catalogName))
+	_, found, err = c.PackagesProvidingGVK(catalogName, resolvedRef, certificateGVK)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestFilesystemCacheGetPackageEviction(t *testing.T) {
+	const (
+		catalogName = "test-catalog"
+		resolvedRef = "fake/catalog@sha256:fakesha"
+	)
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+
+	// Only one of the packages fits in the cache, as both are small enough to be
+	// counted at the minimum size of a cached package, 1KiB.
+	c := cache.NewFilesystemCache(cacheDir, cache.WithMaxPackageCacheSize(1<<10))
+
+	package2 := strings.ReplaceAll(package1, "fake1", "fake2")
+	bundle2 := strings.ReplaceAll(bundle1, "fake1", "fake2")
+	_, err := c.Put(catalogName, resolvedRef, strings.NewReader(package1+bundle1+stableChannel+package2+bundle2), nil)
+	require.NoError(t, err)
+
+	fbc, err := c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	require.Len(t, fbc.Bundles, 1)
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake2")
+	require.NoError(t, err)
+	require.Len(t, fbc.Bundles, 1)
+
+	t.Log("The least recently used package is parsed again from the filesystem")
+	require.NoError(t, os.RemoveAll(filepath.Join(cacheDir, catalogName, "fake1")))
+	require.NoError(t, os.RemoveAll(filepath.Join(cacheDir, catalogName, "fake2")))
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake2")
+	require.NoError(t, err)
+	assert.Len(t, fbc.Bundles, 1)
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake1")
+	require.NoError(t, err)
+	assert.Empty(t, fbc.Bundles)
+
+	t.Log("Packages missing from the catalog count towards the size of the cache")
+	fbc, err = c.GetPackage(ctx, catalogName, resolvedRef, "fake2")
+	require.NoError(t, err)
+	assert.Empty(t, fbc.Bundles)
+}
+
+// BenchmarkClientGetPackage compares getting a package with the parsed packages
+// kept in memory, to parsing the package on every call.
+func BenchmarkClientGetPackage(b *testing.B) {
+	const bundles = 500
+	var content strings.Builder
+	content.WriteString(package1)
+	for i := range bundles {
+		fmt.Fprintf(&content, `{
+			"schema": "olm.bundle",
+			"name": "fake1.v1.0.%[1]d",
+			"package": "fake1",
+			"image": "fake-image:v1.0.%[1]d",
+			"properties": [
+				{"type": "olm.package", "value": {"packageName": "fake1", "version": "1.0.%[1]d"}},
+				{"type": "olm.gvk", "value": {"group": "example.com", "kind": "Fake", "version": "v1"}},
+				{"type": "olm.csv.metadata", "value": {"annotations": {"description": "a fake bundle"}, "installModes": [{"type": "AllNamespaces", "supported": true}]}}
+			]
+		}`, i)
+	}
+
+	catalog := &ocv1.ClusterCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-catalog"},
+		Status: ocv1.ClusterCatalogStatus{
+			Conditions: []metav1.Condition{{Type: ocv1.TypeServing, Status: metav1.ConditionTrue}},
+			ResolvedSource: &ocv1.ResolvedCatalogSource{Image: &ocv1.ResolvedImageSource{
+				Ref: "fake/catalog@sha256:fakesha",
+			}},
+		},
+	}
+	c := cache.NewFilesystemCache(b.TempDir())
+	_, err := c.Put(catalog.Name, catalog.Status.ResolvedSource.Image.Ref, strings.NewReader(content.String()), nil)
+	require.NoError(b, err)
+
+	for _, bc := range []struct {
+		name  string
+		cache catalogclient.Cache
+	}{
+		{name: "parsed", cache: c},
+		// Hide GetPackage, so that the package is parsed on every call.
+		{name: "unparsed", cache: struct{ catalogclient.Cache }{c}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			client := catalogclient.New(bc.cache, nil)
+			b.ReportAllocs()
+			for b.Loop() {
+				fbc, err := client.GetPackage(b.Context(), catalog, "fake1")
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(fbc.Bundles) != bundles {
+					b.Fatalf("expected %d bundles, got %d", bundles, len(fbc.Bundles))
+				}
+			}
+		})
+	}
+}
+
 func equalFilesystems(expected, actual fs.FS) error {
 	normalizeJSON := func(data []byte) []byte {
 		var v interface{}
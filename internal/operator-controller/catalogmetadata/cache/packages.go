@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"container/list"
+	"io/fs"
+	"slices"
+	"sync"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+// DefaultMaxPackageCacheSize is the default estimated size in memory of the parsed
+// packages that a filesystemCache keeps.
+const DefaultMaxPackageCacheSize int64 = 128 << 20
+
+const (
+	// parsedSizeFactor estimates the size in memory of a parsed package from the
+	// size of the metadata files it is parsed from. Parsed packages take up to about
+	// twice the size of compact JSON, as every string, slice and map in them is a
+	// separate allocation.
+	parsedSizeFactor = 2
+
+	// minPackageCost is the smallest size of a cached package, which accounts for
+	// its key and bookkeeping, so that the packages that do not exist in a catalog,
+	// which are cached as empty configs, still count towards the maximum size.
+	minPackageCost int64 = 1 << 10
+)
+
+type packageKey struct {
+	catalogName string
+	resolvedRef string
+	pkgName     string
+}
+
+type packageEntry struct {
+	key  packageKey
+	fbc  *declcfg.DeclarativeConfig
+	size int64
+}
+
+// packageCache keeps parsed packages in memory, so that resolving many
+// ClusterExtensions does not parse the same package over and over. It evicts
+// the least recently used packages once their estimated size in memory exceeds
+// maxSize.
+type packageCache struct {
+	mutex   sync.Mutex
+	maxSize int64
+	size    int64
+	lru     *list.List
+	entries map[packageKey]*list.Element
+}
+
+func newPackageCache(maxSize int64) *packageCache {
+	return &packageCache{
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: map[packageKey]*list.Element{},
+	}
+}
+
+func (pc *packageCache) get(key packageKey) (*declcfg.DeclarativeConfig, bool) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+
+	elem, ok := pc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	pc.lru.MoveToFront(elem)
+	return elem.Value.(*packageEntry).fbc, true
+}
+
+func (pc *packageCache) add(key packageKey, fbc *declcfg.DeclarativeConfig, size int64) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+
+	if size > pc.maxSize {
+		// Caching the package would evict all the others.
+		return
+	}
+	if elem, ok := pc.entries[key]; ok {
+		pc.removeElement(elem)
+	}
+	pc.entries[key] = pc.lru.PushFront(&packageEntry{key: key, fbc: fbc, size: size})
+	pc.size += size
+	for pc.size > pc.maxSize {
+		pc.removeElement(pc.lru.Back())
+	}
+}
+
+// removeCatalog removes all the packages of a catalog, whatever their version.
+func (pc *packageCache) removeCatalog(catalogName string) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+
+	for key, elem := range pc.entries {
+		if key.catalogName == catalogName {
+			pc.removeElement(elem)
+		}
+	}
+}
+
+func (pc *packageCache) removeElement(elem *list.Element) {
+	entry := pc.lru.Remove(elem).(*packageEntry)
+	delete(pc.entries, entry.key)
+	pc.size -= entry.size
+}
+
+// packageSize returns the size of the metadata files of a package.
+func packageSize(catalogFsys fs.FS, pkgName string) (int64, error) {
+	var size int64
+	err := fs.WalkDir(catalogFsys, pkgName, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// packageCost returns the estimated size in memory of a package parsed from
+// metadata files of the given size.
+func packageCost(fileSize int64) int64 {
+	return max(fileSize*parsedSizeFactor, minPackageCost)
+}
+
+// copyConfig returns a copy of fbc that shares its elements, so that callers can
+// filter and sort it without modifying the cached config.
+func copyConfig(fbc *declcfg.DeclarativeConfig) *declcfg.DeclarativeConfig {
+	return &declcfg.DeclarativeConfig{
+		Packages:     slices.Clone(fbc.Packages),
+		Channels:     slices.Clone(fbc.Channels),
+		Bundles:      slices.Clone(fbc.Bundles),
+		Deprecations: slices.Clone(fbc.Deprecations),
+		Others:       slices.Clone(fbc.Others),
+	}
+}
@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+func TestPackageCost(t *testing.T) {
+	assert.Equal(t, minPackageCost, packageCost(0))
+	assert.Equal(t, 2*minPackageCost, packageCost(minPackageCost))
+}
+
+func TestPackageCacheCountsMissingPackages(t *testing.T) {
+	pc := newPackageCache(2 * minPackageCost)
+	for _, pkgName := range []string{"missing1", "missing2", "missing3"} {
+		pc.add(packageKey{catalogName: "catalog", pkgName: pkgName}, &declcfg.DeclarativeConfig{}, packageCost(0))
+	}
+
+	assert.Equal(t, 2*minPackageCost, pc.size)
+	_, ok := pc.get(packageKey{catalogName: "catalog", pkgName: "missing1"})
+	assert.False(t, ok, "the least recently used package must be evicted")
+	_, ok = pc.get(packageKey{catalogName: "catalog", pkgName: "missing3"})
+	assert.True(t, ok)
+}
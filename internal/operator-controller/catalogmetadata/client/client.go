@@ -8,12 +8,14 @@ import (
 	"io/fs"
 	"net/http"
 	"net/url"
+	"path"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	httputil "github.com/operator-framework/operator-controller/internal/shared/util/http"
@@ -48,6 +50,45 @@ type Cache interface {
 	Put(catalogName, resolvedRef string, source io.Reader, errToCache error) (fs.FS, error)
 }
 
+// PackageCache is implemented by Caches that also keep the packages parsed from their
+// contents, so that GetPackage does not parse a package again on every call.
+type PackageCache interface {
+	Cache
+
+	// GetPackage returns the package pkgName, as loaded by LoadPackage, from the cache
+	// for a specified catalog name and version (resolvedRef).
+	//
+	// Method behaviour is as follows:
+	//   - If cache exists, it returns the package, or an empty config if the
+	//     catalog does not contain the package
+	//   - If cache doesn't exist, it returns nil config and nil error
+	//   - If there was an error during cache population,
+	//     it returns nil config and the error from the cache population.
+	//
+	// The returned config is a copy of the cached one that callers may filter and
+	// sort, but the bundles, channels and other elements it contains are shared and
+	// must not be modified.
+	GetPackage(ctx context.Context, catalogName, resolvedRef, pkgName string) (*declcfg.DeclarativeConfig, error)
+}
+
+// ProvidedAPIIndex is implemented by Caches that index the packages of their contents
+// by the APIs that their bundles provide, so that ListPackagesProvidingGVK does not
+// parse every package of a catalog.
+type ProvidedAPIIndex interface {
+	Cache
+
+	// PackagesProvidingGVK returns the names of the packages, in lexical order, with
+	// bundles that provide gvk, from the cache for a specified catalog name and
+	// version (resolvedRef).
+	//
+	// Method behaviour is as follows:
+	//   - If cache exists, it returns the names of the packages and true
+	//   - If cache doesn't exist, it returns nil names and false
+	//   - If there was an error during cache population,
+	//     it returns the error from the cache population.
+	PackagesProvidingGVK(catalogName, resolvedRef string, gvk property.GVK) ([]string, bool, error)
+}
+
 func New(cache Cache, httpClient func() (*http.Client, error)) *Client {
 	return &Client{
 		cache:      cache,
@@ -74,6 +115,79 @@ func (c *Client) GetPackage(ctx context.Context, catalog *ocv1.ClusterCatalog, p
 		return nil, fmt.Errorf("cache for catalog %q not found", catalog.Name)
 	}
 
+	if pc, ok := c.cache.(PackageCache); ok {
+		pkgFBC, err := pc.GetPackage(ctx, catalog.Name, catalog.Status.ResolvedSource.Image.Ref, pkgName)
+		if err == nil && pkgFBC == nil {
+			// The cache was removed since it was retrieved above.
+			return nil, fmt.Errorf("cache for catalog %q not found", catalog.Name)
+		}
+		return pkgFBC, err
+	}
+	return LoadPackage(ctx, catalogFsys, pkgName)
+}
+
+// ListPackages returns the names of the packages provided by a catalog, in lexical order.
+func (c *Client) ListPackages(_ context.Context, catalog *ocv1.ClusterCatalog) ([]string, error) {
+	if err := validateCatalog(catalog); err != nil {
+		return nil, err
+	}
+
+	catalogFsys, err := c.cache.Get(catalog.Name, catalog.Status.ResolvedSource.Image.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving cache for catalog %q: %v", catalog.Name, err)
+	}
+	if catalogFsys == nil {
+		return nil, fmt.Errorf("cache for catalog %q not found", catalog.Name)
+	}
+
+	entries, err := fs.ReadDir(catalogFsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error listing packages of catalog %q: %v", catalog.Name, err)
+	}
+	var pkgNames []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		// Metadata that does not belong to a package is cached in directories named
+		// after its schema, alongside the package directories.
+		if _, err := fs.Stat(catalogFsys, path.Join(entry.Name(), declcfg.SchemaPackage)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("error listing packages of catalog %q: %v", catalog.Name, err)
+		}
+		pkgNames = append(pkgNames, entry.Name())
+	}
+	return pkgNames, nil
+}
+
+// ListPackagesProvidingGVK returns the names of the packages of a catalog with bundles
+// that provide gvk, in lexical order. When the cache does not index the packages by
+// the APIs that they provide, it returns the names of all the packages of the catalog,
+// and callers must check which of their bundles provide gvk.
+func (c *Client) ListPackagesProvidingGVK(ctx context.Context, catalog *ocv1.ClusterCatalog, gvk property.GVK) ([]string, error) {
+	idx, ok := c.cache.(ProvidedAPIIndex)
+	if !ok {
+		return c.ListPackages(ctx, catalog)
+	}
+	if err := validateCatalog(catalog); err != nil {
+		return nil, err
+	}
+
+	pkgNames, found, err := idx.PackagesProvidingGVK(catalog.Name, catalog.Status.ResolvedSource.Image.Ref, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving cache for catalog %q: %v", catalog.Name, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("cache for catalog %q not found", catalog.Name)
+	}
+	return pkgNames, nil
+}
+
+// LoadPackage parses the package pkgName from the cached contents of a catalog.
+// It returns an empty config if the catalog does not contain the package.
+func LoadPackage(ctx context.Context, catalogFsys fs.FS, pkgName string) (*declcfg.DeclarativeConfig, error) {
 	pkgFsys, err := fs.Sub(catalogFsys, pkgName)
 	if err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
@@ -106,9 +220,9 @@ func (c *Client) PopulateCache(ctx context.Context, catalog *ocv1.ClusterCatalog
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		// Do not cache non-200 responses (e.g. 404 from a non-leader catalogd pod).
-		// Returning the error directly lets the next reconcile retry a fresh HTTP
-		// request and eventually hit the leader.
+		// Do not cache non-200 responses (e.g. 404 from a catalogd pod that has not
+		// stored the catalog content yet). Returning the error directly lets the next
+		// reconcile retry a fresh HTTP request.
 		return nil, fmt.Errorf("error: received unexpected response status code %d", resp.StatusCode)
 	}
 
@@ -16,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	catalogclient "github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client"
@@ -165,6 +166,91 @@ func TestClientGetPackage(t *testing.T) {
 	}
 }
 
+func TestClientListPackages(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+
+	cache := mockcatalogclient.NewMockCache(ctrl)
+	cache.EXPECT().Get("catalog-1", "fake/catalog@sha256:fakesha").Return(fstest.MapFS{
+		"pkg-b/olm.package/pkg-b.json":         &fstest.MapFile{Data: []byte(`{"schema": "olm.package","name": "pkg-b"}`)},
+		"pkg-b/olm.bundle/pkg-b.v1.0.0.json":   &fstest.MapFile{Data: []byte(`{"schema": "olm.bundle","name": "pkg-b.v1.0.0","package": "pkg-b"}`)},
+		"pkg-a/olm.package/pkg-a.json":         &fstest.MapFile{Data: []byte(`{"schema": "olm.package","name": "pkg-a"}`)},
+		"olm.unknown/unknown.json":             &fstest.MapFile{Data: []byte(`{"schema": "olm.unknown","name": "unknown"}`)},
+		"orphan/olm.bundle/orphan.v1.0.0.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.bundle","name": "orphan.v1.0.0","package": "orphan"}`)},
+	}, nil)
+
+	c := catalogclient.New(cache, func() (*http.Client, error) { return nil, errors.New("unexpected request") })
+	pkgNames, err := c.ListPackages(ctx, defaultCatalog())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pkg-a", "pkg-b"}, pkgNames)
+
+	cache.EXPECT().Get("catalog-1", "fake/catalog@sha256:fakesha").Return(nil, nil)
+	_, err = c.ListPackages(ctx, defaultCatalog())
+	require.ErrorContains(t, err, `cache for catalog "catalog-1" not found`)
+}
+
+// providedAPIIndex is a Cache that indexes the packages of a catalog by the APIs
+// that their bundles provide.
+type providedAPIIndex struct {
+	catalogclient.Cache
+	packagesByGVK map[property.GVK][]string
+	err           error
+}
+
+func (idx providedAPIIndex) PackagesProvidingGVK(_, _ string, gvk property.GVK) ([]string, bool, error) {
+	if idx.err != nil {
+		return nil, false, idx.err
+	}
+	if idx.packagesByGVK == nil {
+		return nil, false, nil
+	}
+	return idx.packagesByGVK[gvk], true, nil
+}
+
+func TestClientListPackagesProvidingGVK(t *testing.T) {
+	ctx := context.Background()
+	certificateGVK := property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+	noHTTPClient := func() (*http.Client, error) { return nil, errors.New("unexpected request") }
+
+	t.Run("indexed", func(t *testing.T) {
+		c := catalogclient.New(providedAPIIndex{packagesByGVK: map[property.GVK][]string{
+			certificateGVK: {"cert-manager", "cert-utils"},
+		}}, noHTTPClient)
+		pkgNames, err := c.ListPackagesProvidingGVK(ctx, defaultCatalog(), certificateGVK)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cert-manager", "cert-utils"}, pkgNames)
+	})
+
+	t.Run("not cached", func(t *testing.T) {
+		c := catalogclient.New(providedAPIIndex{}, noHTTPClient)
+		_, err := c.ListPackagesProvidingGVK(ctx, defaultCatalog(), certificateGVK)
+		require.ErrorContains(t, err, `cache for catalog "catalog-1" not found`)
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		c := catalogclient.New(providedAPIIndex{err: errors.New("fake cache error")}, noHTTPClient)
+		_, err := c.ListPackagesProvidingGVK(ctx, defaultCatalog(), certificateGVK)
+		require.ErrorContains(t, err, "fake cache error")
+	})
+
+	t.Run("not served", func(t *testing.T) {
+		c := catalogclient.New(providedAPIIndex{}, noHTTPClient)
+		_, err := c.ListPackagesProvidingGVK(ctx, &ocv1.ClusterCatalog{ObjectMeta: metav1.ObjectMeta{Name: "catalog-1"}}, certificateGVK)
+		require.ErrorContains(t, err, `catalog "catalog-1" is not being served`)
+	})
+
+	t.Run("not indexed", func(t *testing.T) {
+		cache := mockcatalogclient.NewMockCache(gomock.NewController(t))
+		cache.EXPECT().Get("catalog-1", "fake/catalog@sha256:fakesha").Return(fstest.MapFS{
+			"pkg-a/olm.package/pkg-a.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.package","name": "pkg-a"}`)},
+		}, nil)
+		c := catalogclient.New(cache, noHTTPClient)
+		pkgNames, err := c.ListPackagesProvidingGVK(ctx, defaultCatalog(), certificateGVK)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pkg-a"}, pkgNames)
+	})
+}
+
 func TestClientPopulateCache(t *testing.T) {
 	testFS := fstest.MapFS{
 		"pkg-present/olm.package/pkg-present.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.package","name": "pkg-present"}`)},
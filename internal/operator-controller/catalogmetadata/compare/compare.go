@@ -1,6 +1,7 @@
 package compare
 
 import (
+	"fmt"
 	"strings"
 
 	mmsemver "github.com/Masterminds/semver/v3"
@@ -38,6 +39,60 @@ func newMastermindsRange(versionRange string) (bsemver.Range, error) {
 	}, nil
 }
 
+// NewReleaseRange returns a function that tests whether a release satisfies the
+// provided releaseRange: one or more comma-delimited comparison strings, each a
+// release optionally preceded by one of the operators "=", "!=", ">", ">=", "<"
+// or "<=". Releases are compared the same way as by ByVersionAndRelease, so a
+// missing release is lower than any release.
+//
+// This function is intended to be used to parse the ClusterExtension.spec.source.catalog.release
+// field. See the API documentation for more details on the supported syntax.
+func NewReleaseRange(releaseRange string) (func(declcfg.Release) bool, error) {
+	var checks []func(declcfg.Release) bool
+	for _, comparison := range strings.Split(releaseRange, ",") {
+		comparison = strings.TrimSpace(comparison)
+		relStr := strings.TrimLeft(comparison, "=!<>")
+		op := comparison[:len(comparison)-len(relStr)]
+		relStr = strings.TrimSpace(relStr)
+		if relStr == "" {
+			return nil, fmt.Errorf("invalid release comparison %q: missing release", comparison)
+		}
+		rel, err := declcfg.NewRelease(relStr)
+		if err != nil {
+			return nil, err
+		}
+
+		var satisfied func(int) bool
+		switch op {
+		case "", "=":
+			satisfied = func(c int) bool { return c == 0 }
+		case "!=":
+			satisfied = func(c int) bool { return c != 0 }
+		case ">":
+			satisfied = func(c int) bool { return c > 0 }
+		case ">=":
+			satisfied = func(c int) bool { return c >= 0 }
+		case "<":
+			satisfied = func(c int) bool { return c < 0 }
+		case "<=":
+			satisfied = func(c int) bool { return c <= 0 }
+		default:
+			return nil, fmt.Errorf("invalid release comparison %q: unknown operator %q", comparison, op)
+		}
+		checks = append(checks, func(in declcfg.Release) bool {
+			return satisfied(in.Compare(rel))
+		})
+	}
+	return func(in declcfg.Release) bool {
+		for _, check := range checks {
+			if !check(in) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
 // ByVersionAndRelease is a comparison function that compares bundles by
 // version and release. Bundles with lower versions/releases are
 // considered less than bundles with higher versions/releases.
@@ -85,6 +85,88 @@ func TestNewVersionRange(t *testing.T) {
 	}
 }
 
+func TestNewReleaseRange(t *testing.T) {
+	release := func(s string) declcfg.Release {
+		r, err := declcfg.NewRelease(s)
+		require.NoError(t, err)
+		return r
+	}
+	for _, tc := range []struct {
+		name         string
+		releaseRange string
+		matches      []string
+		doesNotMatch []string
+		wantErr      string
+	}{
+		{
+			name:         "exact release",
+			releaseRange: "3",
+			matches:      []string{"3"},
+			doesNotMatch: []string{"", "2", "4", "3.1"},
+		},
+		{
+			name:         "exact release with operator",
+			releaseRange: "= 3",
+			matches:      []string{"3"},
+			doesNotMatch: []string{"", "4"},
+		},
+		{
+			name:         "minimum release",
+			releaseRange: ">=3",
+			matches:      []string{"3", "3.1", "10"},
+			doesNotMatch: []string{"", "2", "2.9"},
+		},
+		{
+			name:         "bounded releases",
+			releaseRange: ">2, <5, != 3",
+			matches:      []string{"4", "2.1"},
+			doesNotMatch: []string{"", "2", "3", "5"},
+		},
+		{
+			name:         "at most release",
+			releaseRange: "<=2",
+			matches:      []string{"", "1", "2"},
+			doesNotMatch: []string{"2.1", "3"},
+		},
+		{
+			name:         "alphanumeric releases",
+			releaseRange: ">alpha",
+			matches:      []string{"beta", "alpha.1"},
+			doesNotMatch: []string{"", "1", "alpha"},
+		},
+		{
+			name:         "unknown operator",
+			releaseRange: "=>3",
+			wantErr:      `invalid release comparison "=>3": unknown operator "=>"`,
+		},
+		{
+			name:         "missing release",
+			releaseRange: ">=3,",
+			wantErr:      `invalid release comparison "": missing release`,
+		},
+		{
+			name:         "invalid release",
+			releaseRange: ">=03",
+			wantErr:      `invalid release "03"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			releaseRange, err := compare.NewReleaseRange(tc.releaseRange)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, r := range tc.matches {
+				assert.True(t, releaseRange(release(r)), "release %q should match", r)
+			}
+			for _, r := range tc.doesNotMatch {
+				assert.False(t, releaseRange(release(r)), "release %q should not match", r)
+			}
+		})
+	}
+}
+
 func TestByVersionAndRelease(t *testing.T) {
 	b1 := declcfg.Bundle{
 		Name: "package1.v1.0.0",
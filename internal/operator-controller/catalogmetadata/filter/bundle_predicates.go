@@ -1,9 +1,12 @@
 package filter
 
 import (
+	"encoding/json"
+
 	bsemver "github.com/blang/semver/v4"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
 
 	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
 	"github.com/operator-framework/operator-controller/internal/shared/util/filter"
@@ -22,6 +25,23 @@ func ExactVersionRelease(expect declcfg.VersionRelease) filter.Predicate[declcfg
 	}
 }
 
+// InVersionReleaseRange returns a predicate that matches bundles whose version
+// falls within versionRange and whose release satisfies releaseRange, as returned
+// by compare.NewVersionRange and compare.NewReleaseRange. A nil range matches any
+// version or release.
+func InVersionReleaseRange(versionRange bsemver.Range, releaseRange func(declcfg.Release) bool) filter.Predicate[declcfg.Bundle] {
+	return func(b declcfg.Bundle) bool {
+		vr, err := bundleutil.GetVersionAndRelease(b)
+		if err != nil {
+			return false
+		}
+		if versionRange != nil && !versionRange(vr.Version) {
+			return false
+		}
+		return releaseRange == nil || releaseRange(vr.Release)
+	}
+}
+
 // InSemverRange returns a predicate that matches bundles whose version falls within
 // the provided semver range. The range is applied only to the semver version portion,
 // ignoring the release metadata.
@@ -47,3 +67,23 @@ func InAnyChannel(channels ...declcfg.Channel) filter.Predicate[declcfg.Bundle]
 		return false
 	}
 }
+
+// ProvidesGVK returns a predicate that matches bundles with an olm.gvk property
+// for the provided group, version and kind.
+func ProvidesGVK(gvk property.GVK) filter.Predicate[declcfg.Bundle] {
+	return func(b declcfg.Bundle) bool {
+		for _, p := range b.Properties {
+			if p.Type != property.TypeGVK {
+				continue
+			}
+			var provided property.GVK
+			if err := json.Unmarshal(p.Value, &provided); err != nil {
+				continue
+			}
+			if provided == gvk {
+				return true
+			}
+		}
+		return false
+	}
+}
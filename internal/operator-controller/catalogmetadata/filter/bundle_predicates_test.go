@@ -50,6 +50,40 @@ func TestInSemverRange(t *testing.T) {
 	assert.False(t, f(b3))
 }
 
+func TestInVersionReleaseRange(t *testing.T) {
+	bundle := func(version string) declcfg.Bundle {
+		return declcfg.Bundle{
+			Properties: []property.Property{
+				{
+					Type:  property.TypePackage,
+					Value: json.RawMessage(`{"packageName": "package1", "version": "` + version + `"}`),
+				},
+			},
+		}
+	}
+
+	vRange, err := compare.NewVersionRange(">=1.0.0, <2.0.0")
+	require.NoError(t, err)
+	rRange, err := compare.NewReleaseRange(">=3")
+	require.NoError(t, err)
+
+	f := filter.InVersionReleaseRange(vRange, rRange)
+	assert.True(t, f(bundle("1.4.2+3")))
+	assert.True(t, f(bundle("1.0.0+10")))
+	assert.False(t, f(bundle("2.0.0+3")))
+	assert.False(t, f(bundle("1.4.2+2")))
+	assert.False(t, f(bundle("1.4.2")))
+	assert.False(t, f(bundle("broken")))
+
+	releaseOnly := filter.InVersionReleaseRange(nil, rRange)
+	assert.True(t, releaseOnly(bundle("2.0.0+3")))
+	assert.False(t, releaseOnly(bundle("2.0.0")))
+
+	versionOnly := filter.InVersionReleaseRange(vRange, nil)
+	assert.True(t, versionOnly(bundle("1.4.2")))
+	assert.False(t, versionOnly(bundle("2.0.0+3")))
+}
+
 func TestInAnyChannel(t *testing.T) {
 	alpha := declcfg.Channel{Name: "alpha", Entries: []declcfg.ChannelEntry{{Name: "b1"}, {Name: "b2"}}}
 	stable := declcfg.Channel{Name: "stable", Entries: []declcfg.ChannelEntry{{Name: "b1"}}}
@@ -68,3 +102,23 @@ func TestInAnyChannel(t *testing.T) {
 	assert.False(t, fStable(b2))
 	assert.False(t, fStable(b3))
 }
+
+func TestProvidesGVK(t *testing.T) {
+	bundle := func(gvks ...string) declcfg.Bundle {
+		b := declcfg.Bundle{Properties: []property.Property{
+			{Type: property.TypePackage, Value: json.RawMessage(`{"packageName": "package1", "version": "1.0.0"}`)},
+		}}
+		for _, gvk := range gvks {
+			b.Properties = append(b.Properties, property.Property{Type: property.TypeGVK, Value: json.RawMessage(gvk)})
+		}
+		return b
+	}
+
+	f := filter.ProvidesGVK(property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"})
+
+	assert.True(t, f(bundle(`{"group": "cert-manager.io", "version": "v1", "kind": "Issuer"}`, `{"group": "cert-manager.io", "version": "v1", "kind": "Certificate"}`)))
+	assert.False(t, f(bundle(`{"group": "cert-manager.io", "version": "v1alpha1", "kind": "Certificate"}`)))
+	assert.False(t, f(bundle(`{"group": "example.com", "version": "v1", "kind": "Certificate"}`)))
+	assert.False(t, f(bundle(`broken`)))
+	assert.False(t, f(bundle()))
+}
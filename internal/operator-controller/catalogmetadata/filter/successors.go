@@ -95,3 +95,40 @@ func legacySuccessor(installedBundle ocv1.BundleMetadata, channels ...declcfg.Ch
 		return false
 	}, nil
 }
+
+// InUpgradeScope returns a predicate that matches bundles whose version is within
+// the given upgrade scope relative to the version of the installed bundle. The
+// release of the bundles is not taken into account.
+func InUpgradeScope(installedBundle ocv1.BundleMetadata, scope ocv1.UpgradeScope) (filter.Predicate[declcfg.Bundle], error) {
+	installedVersionRelease, err := parseInstalledBundleVersionRelease(installedBundle)
+	if err != nil {
+		return nil, err
+	}
+	installed := installedVersionRelease.Version
+
+	var inScope func(bsemver.Version) bool
+	switch scope {
+	case ocv1.UpgradeScopePatchOnly:
+		inScope = func(v bsemver.Version) bool {
+			return v.Major == installed.Major && v.Minor == installed.Minor
+		}
+	case ocv1.UpgradeScopeMinorOnly:
+		inScope = func(v bsemver.Version) bool {
+			return v.Major == installed.Major && v.Minor <= installed.Minor+1
+		}
+	case ocv1.UpgradeScopeNoMajor:
+		inScope = func(v bsemver.Version) bool {
+			return v.Major == installed.Major
+		}
+	default:
+		return nil, fmt.Errorf("unknown upgrade scope %q", scope)
+	}
+
+	return func(candidateBundle declcfg.Bundle) bool {
+		vr, err := bundleutil.GetVersionAndRelease(candidateBundle)
+		if err != nil {
+			return false
+		}
+		return inScope(vr.Version)
+	}, nil
+}
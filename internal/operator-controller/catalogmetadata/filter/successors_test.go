@@ -320,3 +320,57 @@ func TestSuccessorsOf_Errors(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestInUpgradeScope(t *testing.T) {
+	bundle := func(version string) declcfg.Bundle {
+		return declcfg.Bundle{
+			Name: "test-package.v" + version,
+			Properties: []property.Property{
+				property.MustBuildPackage("test-package", version),
+			},
+		}
+	}
+	installedBundle := ocv1.BundleMetadata{
+		Name:    "test-package.v1.2.3",
+		Version: "1.2.3",
+	}
+	versions := []string{"1.1.0", "1.2.3", "1.2.4", "1.3.0", "1.3.5", "1.4.0", "2.0.0", "broken"}
+
+	for _, tc := range []struct {
+		scope ocv1.UpgradeScope
+		want  []string
+	}{
+		{scope: ocv1.UpgradeScopePatchOnly, want: []string{"1.2.3", "1.2.4"}},
+		{scope: ocv1.UpgradeScopeMinorOnly, want: []string{"1.1.0", "1.2.3", "1.2.4", "1.3.0", "1.3.5"}},
+		{scope: ocv1.UpgradeScopeNoMajor, want: []string{"1.1.0", "1.2.3", "1.2.4", "1.3.0", "1.3.5", "1.4.0"}},
+	} {
+		t.Run(string(tc.scope), func(t *testing.T) {
+			f, err := InUpgradeScope(installedBundle, tc.scope)
+			require.NoError(t, err)
+			var got []string
+			for _, v := range versions {
+				if f(bundle(v)) {
+					got = append(got, v)
+				}
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("registry+v1 release", func(t *testing.T) {
+		f, err := InUpgradeScope(ocv1.BundleMetadata{Name: "test-package.v1.2.3+1", Version: "1.2.3+1"}, ocv1.UpgradeScopePatchOnly)
+		require.NoError(t, err)
+		assert.True(t, f(bundle("1.2.3+2")))
+		assert.False(t, f(bundle("1.3.0+1")))
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := InUpgradeScope(installedBundle, "Unknown")
+		require.ErrorContains(t, err, `unknown upgrade scope "Unknown"`)
+	})
+
+	t.Run("invalid installed bundle version", func(t *testing.T) {
+		_, err := InUpgradeScope(ocv1.BundleMetadata{Name: "test", Version: "invalid"}, ocv1.UpgradeScopePatchOnly)
+		require.Error(t, err)
+	})
+}
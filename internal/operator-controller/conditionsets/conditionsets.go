@@ -30,6 +30,7 @@ var ConditionTypes = []string{
 	ocv1.TypePackageDeprecated,
 	ocv1.TypeChannelDeprecated,
 	ocv1.TypeBundleDeprecated,
+	ocv1.TypeBundleDenied,
 	ocv1.TypeProgressing,
 }
 
@@ -38,6 +39,8 @@ var ConditionReasons = []string{
 	ocv1.ReasonDeprecated,
 	ocv1.ReasonNotDeprecated,
 	ocv1.ReasonDeprecationStatusUnknown,
+	ocv1.ReasonDenied,
+	ocv1.ReasonNotDenied,
 	ocv1.ReasonFailed,
 	ocv1.ReasonBlocked,
 	ocv1.ReasonInvalidConfiguration,
@@ -65,6 +65,7 @@ func (d *BoxcutterRevisionStatesGetter) GetRevisionStates(ctx context.Context, e
 			RevisionName: rev.Name,
 			Package:      rev.Annotations[labels.PackageNameKey],
 			Image:        rev.Annotations[labels.BundleReferenceKey],
+			Catalog:      rev.Annotations[labels.CatalogNameKey],
 			Conditions:   rev.Status.Conditions,
 			BundleMetadata: ocv1.BundleMetadata{
 				Name:    rev.Annotations[labels.BundleNameKey],
@@ -112,6 +113,9 @@ func ApplyBundleWithBoxcutter(apply func(ctx context.Context, contentFS fs.FS, e
 		if state.resolvedRevisionMetadata.Release != nil {
 			revisionAnnotations[labels.BundleReleaseKey] = *state.resolvedRevisionMetadata.Release
 		}
+		if state.resolvedRevisionMetadata.Catalog != "" {
+			revisionAnnotations[labels.CatalogNameKey] = state.resolvedRevisionMetadata.Catalog
+		}
 		objLbls := map[string]string{
 			labels.OwnerKindKey: ocv1.ClusterExtensionKind,
 			labels.OwnerNameKey: ext.GetName(),
@@ -142,7 +146,8 @@ func ApplyBundleWithBoxcutter(apply func(ctx context.Context, contentFS fs.FS, e
 				}
 			}
 			ext.Status.Install = &ocv1.ClusterExtensionInstallStatus{
-				Bundle: i.BundleMetadata,
+				Bundle:  i.BundleMetadata,
+				Catalog: i.Catalog,
 			}
 			ext.Status.ActiveRevisions = []ocv1.RevisionStatus{{Name: i.RevisionName}}
 		}
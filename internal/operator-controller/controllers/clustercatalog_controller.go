@@ -25,8 +25,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
 )
 
 type CatalogCache interface {
@@ -43,6 +45,9 @@ type ClusterCatalogReconciler struct {
 	client.Client
 	CatalogCache          CatalogCache
 	CatalogCachePopulator CatalogCachePopulator
+	// NamespacedCatalogs enables caching the content of namespaced Catalogs,
+	// under their keys, alongside that of ClusterCatalogs.
+	NamespacedCatalogs bool
 }
 
 func (r *ClusterCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -64,13 +69,43 @@ func (r *ClusterCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	return ctrl.Result{}, r.populateCache(ctx, existingCatalog)
+}
+
+// reconcileNamespacedCatalog caches the content of a namespaced Catalog under its key.
+func (r *ClusterCatalogReconciler) reconcileNamespacedCatalog(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := log.FromContext(ctx).WithName("catalog")
+	ctx = log.IntoContext(ctx, l)
+
+	l.Info("reconcile starting")
+	defer l.Info("reconcile ending")
+
+	key := catalogutil.NamespacedKey(req.Namespace, req.Name)
+	existingCatalog := &ocv1.Catalog{}
+	err := r.Get(ctx, req.NamespacedName, existingCatalog)
+	if apierrors.IsNotFound(err) {
+		if err := r.CatalogCache.Remove(key); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error removing cache for catalog %q: %v", key, err)
+		}
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.populateCache(ctx, catalogutil.AsClusterCatalog(existingCatalog))
+}
+
+func (r *ClusterCatalogReconciler) populateCache(ctx context.Context, existingCatalog *ocv1.ClusterCatalog) error {
+	l := log.FromContext(ctx)
+
 	if existingCatalog.Status.ResolvedSource == nil ||
 		existingCatalog.Status.ResolvedSource.Image == nil ||
 		existingCatalog.Status.ResolvedSource.Image.Ref == "" {
 		// Reference is not known yet - skip cache population with no error.
 		// Once the reference is resolved another reconcile cycle
 		// will be triggered and we will progress further.
-		return ctrl.Result{}, nil
+		return nil
 	}
 
 	catalogFsys, err := r.CatalogCache.Get(existingCatalog.Name, existingCatalog.Status.ResolvedSource.Image.Ref)
@@ -78,14 +113,14 @@ func (r *ClusterCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		l.Info("retrying cache population: found previous error from catalog cache", "cacheErr", err)
 	} else if catalogFsys != nil {
 		// Cache already exists so we do not need to populate it
-		return ctrl.Result{}, nil
+		return nil
 	}
 
 	if _, err = r.CatalogCachePopulator.PopulateCache(ctx, existingCatalog); err != nil {
-		return ctrl.Result{}, fmt.Errorf("error populating cache for catalog %q: %v", existingCatalog.Name, err)
+		return fmt.Errorf("error populating cache for catalog %q: %v", existingCatalog.Name, err)
 	}
 
-	return ctrl.Result{}, nil
+	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -94,6 +129,14 @@ func (r *ClusterCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Named("controller-operator-clustercatalog-controller").
 		For(&ocv1.ClusterCatalog{}).
 		Build(r)
+	if err != nil || !r.NamespacedCatalogs {
+		return err
+	}
+
+	_, err = ctrl.NewControllerManagedBy(mgr).
+		Named("controller-operator-catalog-controller").
+		For(&ocv1.Catalog{}).
+		Build(reconcile.Func(r.reconcileNamespacedCatalog))
 
 	return err
 }
@@ -27,11 +27,11 @@ import (
 	"github.com/go-logr/logr"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,8 +48,10 @@ import (
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/applier"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/conditionsets"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
 	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	k8sutil "github.com/operator-framework/operator-controller/internal/shared/util/k8s"
 )
@@ -62,9 +64,11 @@ const (
 type reconcileState struct {
 	revisionStates           *RevisionStates
 	resolvedRevisionMetadata *RevisionMetadata
+	resolvedBundle           *declcfg.Bundle
 	imageFS                  fs.FS
 	resolvedDeprecation      *declcfg.Deprecation
 	hasCatalogData           bool
+	bundleDenials            *resolve.BundleDenials
 }
 
 // ReconcileStepFunc represents a single step in the ClusterExtension reconciliation process.
@@ -172,12 +176,13 @@ func (r *ClusterExtensionReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 // ensureFailureConditionsWithReason keeps every non-deprecation condition present.
 // If one is missing, we add it with the given reason and message so users see why
-// reconcile failed. Deprecation conditions are handled later by SetDeprecationStatus.
+// reconcile failed. Deprecation conditions are handled later by SetDeprecationStatus,
+// and the BundleDenied condition by CheckBundleDenial, when enabled.
 //
 //nolint:unparam // reason parameter is designed to be flexible, even if current callers use the same value
 func ensureFailureConditionsWithReason(ext *ocv1.ClusterExtension, reason v1alpha1.ConditionReason, message string) {
 	for _, condType := range conditionsets.ConditionTypes {
-		if isDeprecationCondition(condType) {
+		if isDeprecationCondition(condType) || condType == ocv1.TypeBundleDenied {
 			continue
 		}
 		cond := apimeta.FindStatusCondition(ext.Status.Conditions, condType)
@@ -367,30 +372,12 @@ type deprecationInfo struct {
 // and bundle entries that matter for this ClusterExtension. An empty bundle name means
 // nothing is installed yet, so we leave bundle status Unknown/Absent.
 func buildDeprecationInfo(ext *ocv1.ClusterExtension, installedBundleName string, deprecation *declcfg.Deprecation) deprecationInfo {
-	info := deprecationInfo{BundleStatus: metav1.ConditionUnknown}
-	channelSet := sets.New[string]()
-	if ext.Spec.Source.Catalog != nil {
-		channelSet.Insert(ext.Spec.Source.Catalog.Channels...)
-	}
-
-	if deprecation != nil {
-		for _, entry := range deprecation.Entries {
-			switch entry.Reference.Schema {
-			case declcfg.SchemaPackage:
-				info.PackageEntries = append(info.PackageEntries, entry)
-			case declcfg.SchemaChannel:
-				// Include channel deprecations if:
-				// 1. No channels specified (channelSet empty) - any channel could be auto-selected
-				// 2. The deprecated channel matches one of the specified channels
-				if len(channelSet) == 0 || channelSet.Has(entry.Reference.Name) {
-					info.ChannelEntries = append(info.ChannelEntries, entry)
-				}
-			case declcfg.SchemaBundle:
-				if installedBundleName != "" && entry.Reference.Name == installedBundleName {
-					info.BundleEntries = append(info.BundleEntries, entry)
-				}
-			}
-		}
+	entries := resolve.ApplicableDeprecationEntries(ext, deprecation, installedBundleName)
+	info := deprecationInfo{
+		PackageEntries: entries.Package,
+		ChannelEntries: entries.Channel,
+		BundleEntries:  entries.Bundle,
+		BundleStatus:   metav1.ConditionUnknown,
 	}
 
 	// installedBundleName is empty when nothing is installed. In that case we want
@@ -417,6 +404,55 @@ func collectDeprecationMessages(entries []declcfg.DeprecationEntry) []string {
 	return messages
 }
 
+// setBundleDeniedStatus updates the BundleDenied condition based on the denials of the
+// installed bundle:
+//   - Denied -> BundleDenied True with Reason: Denied, listing the policies that deny it
+//   - Not denied -> BundleDenied False with Reason: NotDenied
+//   - No bundle installed -> BundleDenied Unknown with Reason: Absent
+func setBundleDeniedStatus(ext *ocv1.ClusterExtension, bundleDenials *resolve.BundleDenials, installed *RevisionMetadata) {
+	if installed == nil {
+		SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+			Type:               ocv1.TypeBundleDenied,
+			Status:             metav1.ConditionUnknown,
+			Reason:             ocv1.ReasonAbsent,
+			Message:            "no bundle installed yet",
+			ObservedGeneration: ext.GetGeneration(),
+		})
+		return
+	}
+
+	packageName := installed.Package
+	if packageName == "" {
+		packageName = getPackageName(ext)
+	}
+	denials := bundleDenials.ForInstalled(packageName, installed.BundleMetadata, installed.Image)
+	if len(denials) == 0 {
+		SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+			Type:               ocv1.TypeBundleDenied,
+			Status:             metav1.ConditionFalse,
+			Reason:             ocv1.ReasonNotDenied,
+			Message:            "not denied",
+			ObservedGeneration: ext.GetGeneration(),
+		})
+		return
+	}
+
+	messages := make([]string, 0, len(denials)+1)
+	for _, d := range denials {
+		messages = append(messages, fmt.Sprintf("bundle %q is %s", installed.Name, d))
+	}
+	if resolve.Remediates(denials) {
+		messages = append(messages, "upgrading to an allowed successor")
+	}
+	SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+		Type:               ocv1.TypeBundleDenied,
+		Status:             metav1.ConditionTrue,
+		Reason:             ocv1.ReasonDenied,
+		Message:            strings.Join(messages, "\n"),
+		ObservedGeneration: ext.GetGeneration(),
+	})
+}
+
 type ControllerBuilderOption func(builder *ctrl.Builder)
 
 func WithOwns(obj client.Object) ControllerBuilderOption {
@@ -425,13 +461,36 @@ func WithOwns(obj client.Object) ControllerBuilderOption {
 	}
 }
 
+// WithBundleDenyPolicies reconciles all ClusterExtensions when a ClusterBundleDenyPolicy
+// changes, so that changes to the denied bundles are reflected immediately.
+func WithBundleDenyPolicies(c client.Reader, logger logr.Logger) ControllerBuilderOption {
+	return func(builder *ctrl.Builder) {
+		builder.Watches(&ocv1.ClusterBundleDenyPolicy{},
+			crhandler.EnqueueRequestsFromMapFunc(allClusterExtensionRequests(c, logger)))
+	}
+}
+
+// WithInstallPolicies reconciles all ClusterExtensions when a ClusterExtensionInstallPolicy
+// changes, so that extensions blocked by a policy resume once it allows them, and the
+// ClusterExtensions installed into a namespace when its labels change, as policies select
+// ClusterExtensions by the labels of their namespace.
+func WithInstallPolicies(c client.Reader, logger logr.Logger) ControllerBuilderOption {
+	return func(ctrlBuilder *ctrl.Builder) {
+		ctrlBuilder.Watches(&ocv1.ClusterExtensionInstallPolicy{},
+			crhandler.EnqueueRequestsFromMapFunc(allClusterExtensionRequests(c, logger)))
+		ctrlBuilder.Watches(&corev1.Namespace{},
+			crhandler.EnqueueRequestsFromMapFunc(namespaceClusterExtensionRequests(c, logger)),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterExtensionReconciler) SetupWithManager(mgr ctrl.Manager, opts ...ControllerBuilderOption) (crcontroller.Controller, error) {
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&ocv1.ClusterExtension{}).
 		Named("controller-operator-cluster-extension-controller").
 		Watches(&ocv1.ClusterCatalog{},
-			crhandler.EnqueueRequestsFromMapFunc(clusterExtensionRequestsForCatalog(mgr.GetClient(), mgr.GetLogger())),
+			crhandler.EnqueueRequestsFromMapFunc(allClusterExtensionRequests(mgr.GetClient(), mgr.GetLogger())),
 			builder.WithPredicates(predicate.Funcs{
 				UpdateFunc: func(ue event.UpdateEvent) bool {
 					oldObject, isOldCatalog := ue.ObjectOld.(*ocv1.ClusterCatalog)
@@ -474,15 +533,15 @@ func wrapErrorWithResolutionInfo(resolved ocv1.BundleMetadata, err error) error
 	return fmt.Errorf("error for resolved bundle %q with version %q: %w", resolved.Name, resolved.Version, err)
 }
 
-// Generate reconcile requests for all cluster extensions affected by a catalog change
-func clusterExtensionRequestsForCatalog(c client.Reader, logger logr.Logger) crhandler.MapFunc {
-	return func(ctx context.Context, _ client.Object) []reconcile.Request {
-		// no way of associating an extension to a catalog so create reconcile requests for everything
+// Generate reconcile requests for all cluster extensions affected by a catalog or policy change
+func allClusterExtensionRequests(c client.Reader, logger logr.Logger) crhandler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		// no way of associating an extension to a catalog or policy so create reconcile requests for everything
 		clusterExtensions := metav1.PartialObjectMetadataList{}
 		clusterExtensions.SetGroupVersionKind(ocv1.GroupVersion.WithKind("ClusterExtensionList"))
 		err := c.List(ctx, &clusterExtensions)
 		if err != nil {
-			logger.Error(err, "unable to enqueue cluster extensions for catalog reconcile")
+			logger.Error(err, "unable to enqueue cluster extensions", "trigger", obj.GetName())
 			return nil
 		}
 		var requests []reconcile.Request
@@ -498,10 +557,33 @@ func clusterExtensionRequestsForCatalog(c client.Reader, logger logr.Logger) crh
 	}
 }
 
+// Generate reconcile requests for the cluster extensions installed into a namespace
+func namespaceClusterExtensionRequests(c client.Reader, logger logr.Logger) crhandler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		clusterExtensions := ocv1.ClusterExtensionList{}
+		if err := c.List(ctx, &clusterExtensions); err != nil {
+			logger.Error(err, "unable to enqueue cluster extensions", "trigger", obj.GetName())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, ext := range clusterExtensions.Items {
+			if ext.Spec.Namespace != obj.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: ext.GetName()},
+			})
+		}
+		return requests
+	}
+}
+
 type RevisionMetadata struct {
 	RevisionName string
 	Package      string
 	Image        string
+	// Catalog is the name of the catalog that provided the bundle, if known.
+	Catalog string
 	ocv1.BundleMetadata
 	Conditions []metav1.Condition
 }
@@ -537,6 +619,7 @@ func (d *HelmRevisionStatesGetter) GetRevisionStates(ctx context.Context, ext *o
 			rs.Installed = &RevisionMetadata{
 				Package: rel.Labels[labels.PackageNameKey],
 				Image:   rel.Labels[labels.BundleReferenceKey],
+				Catalog: applier.ReleaseCatalog(rel),
 				BundleMetadata: ocv1.BundleMetadata{
 					Name:    rel.Labels[labels.BundleNameKey],
 					Version: rel.Labels[labels.BundleVersionKey],
@@ -129,8 +129,8 @@ func TestClusterExtensionShortCircuitsReconcileDuringDeletion(t *testing.T) {
 func TestClusterExtensionResolutionFails(t *testing.T) {
 	pkgName := fmt.Sprintf("non-existent-%s", rand.String(6))
 	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
-			return nil, nil, nil, fmt.Errorf("no package %q found", pkgName)
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+			return nil, nil, nil, "", fmt.Errorf("no package %q found", pkgName)
 		})
 	})
 
@@ -194,13 +194,13 @@ func TestClusterExtensionResolutionFailsWithDeprecationData(t *testing.T) {
 	pkgName := fmt.Sprintf("deprecated-%s", rand.String(6))
 	deprecationMessage := "package marked deprecated in catalog"
 	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			return nil, nil, &declcfg.Deprecation{
 				Entries: []declcfg.DeprecationEntry{{
 					Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaPackage},
 					Message:   deprecationMessage,
 				}},
-			}, fmt.Errorf("no package %q found", pkgName)
+			}, "", fmt.Errorf("no package %q found", pkgName)
 		})
 	})
 
@@ -260,7 +260,7 @@ func TestClusterExtensionUpgradeShowsInstalledBundleDeprecation(t *testing.T) {
 	deprecationMessage := "v1.0.0 is deprecated, please upgrade to v2.0.0"
 
 	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			v := declcfg.VersionRelease{
 				Version: bsemver.MustParse("2.0.0"),
 			}
@@ -277,7 +277,7 @@ func TestClusterExtensionUpgradeShowsInstalledBundleDeprecation(t *testing.T) {
 						},
 						Message: deprecationMessage,
 					}},
-				}, nil
+				}, "", nil
 		})
 		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
 			Installed: &controllers.RevisionMetadata{
@@ -341,6 +341,61 @@ func TestClusterExtensionUpgradeShowsInstalledBundleDeprecation(t *testing.T) {
 	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
 }
 
+// TestClusterExtensionResolvesWithInstalledCatalog verifies that the catalog that
+// provided the installed bundle is passed to the resolver, which upgrades stick to.
+func TestClusterExtensionResolvesWithInstalledCatalog(t *testing.T) {
+	ctx := context.Background()
+	pkgName := fmt.Sprintf("sticky-%s", rand.String(6))
+	installed := ocv1.BundleMetadata{Name: fmt.Sprintf("%s.v1.0.0", pkgName), Version: "1.0.0"}
+
+	var (
+		gotInstalledBundle  *ocv1.BundleMetadata
+		gotInstalledCatalog string
+	)
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+			gotInstalledBundle, gotInstalledCatalog = installedBundle, installedCatalog
+			v := declcfg.VersionRelease{Version: bsemver.MustParse("2.0.0")}
+			return &declcfg.Bundle{
+				Name:    fmt.Sprintf("%s.v2.0.0", pkgName),
+				Package: pkgName,
+				Image:   fmt.Sprintf("quay.io/example/%s@sha256:resolved200", pkgName),
+			}, &v, nil, "community", nil
+		})
+		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
+			Installed: &controllers.RevisionMetadata{
+				Package:        pkgName,
+				BundleMetadata: installed,
+				Image:          fmt.Sprintf("quay.io/example/%s@sha256:installed100", pkgName),
+				Catalog:        "community",
+			},
+		}, nil)
+		d.ImagePuller = &imageutil.FakePuller{ImageFS: fstest.MapFS{}}
+		d.Applier = newMockApplier(gomock.NewController(t), false, nil)
+	})
+
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: extKey.Name},
+		Spec: ocv1.ClusterExtensionSpec{
+			Source: ocv1.SourceConfig{
+				SourceType: "Catalog",
+				Catalog:    &ocv1.CatalogFilter{PackageName: pkgName},
+			},
+			Namespace:      "default",
+			ServiceAccount: ocv1.ServiceAccountReference{Name: "default"},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, &installed, gotInstalledBundle)
+	require.Equal(t, "community", gotInstalledCatalog)
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
 // TestClusterExtensionUpgradeFromDeprecatedBundleClearsDeprecation verifies that after
 // a successful upgrade from a deprecated bundle to a non-deprecated bundle, the deprecation
 // conditions are updated in the SAME reconciliation cycle (no stale conditions).
@@ -361,7 +416,7 @@ func TestClusterExtensionUpgradeFromDeprecatedBundleClearsDeprecation(t *testing
 	deprecationMessage := fmt.Sprintf("%s is deprecated. Uninstall and install v1.0.3 for support.", installedBundleName)
 
 	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			v := declcfg.VersionRelease{
 				Version: bsemver.MustParse("1.0.3"),
 			}
@@ -377,7 +432,7 @@ func TestClusterExtensionUpgradeFromDeprecatedBundleClearsDeprecation(t *testing
 						},
 						Message: deprecationMessage,
 					}},
-				}, nil
+				}, "", nil
 		})
 		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
 			Installed: &controllers.RevisionMetadata{
@@ -458,8 +513,8 @@ func TestClusterExtensionResolutionFailsWithoutCatalogDeprecationData(t *testing
 	catalogName := fmt.Sprintf("test-catalog-%s", rand.String(6))
 	installedBundleName := fmt.Sprintf("%s.v1.0.0", pkgName)
 	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
-			return nil, nil, nil, fmt.Errorf("no bundles found for package %q", pkgName)
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+			return nil, nil, nil, "", fmt.Errorf("no bundles found for package %q", pkgName)
 		})
 
 		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
@@ -584,7 +639,7 @@ func TestClusterExtensionResolutionSuccessfulUnpackFails(t *testing.T) {
 					}
 				},
 				func(d *deps) {
-					d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+					d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 						v := declcfg.VersionRelease{
 							Version: bsemver.MustParse("1.0.0"),
 						}
@@ -592,7 +647,7 @@ func TestClusterExtensionResolutionSuccessfulUnpackFails(t *testing.T) {
 							Name:    "prometheus.v1.0.0",
 							Package: "prometheus",
 							Image:   "quay.io/operatorhubio/prometheus@fake1.0.0",
-						}, &v, nil, nil
+						}, &v, nil, "", nil
 					})
 				},
 			)
@@ -661,7 +716,7 @@ func TestClusterExtensionResolutionAndUnpackSuccessfulApplierFails(t *testing.T)
 			d.ImagePuller = &imageutil.FakePuller{
 				ImageFS: fstest.MapFS{},
 			}
-			d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+			d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 				v := declcfg.VersionRelease{
 					Version: bsemver.MustParse("1.0.0"),
 				}
@@ -669,7 +724,7 @@ func TestClusterExtensionResolutionAndUnpackSuccessfulApplierFails(t *testing.T)
 					Name:    "prometheus.v1.0.0",
 					Package: "prometheus",
 					Image:   "quay.io/operatorhubio/prometheus@fake1.0.0",
-				}, &v, nil, nil
+				}, &v, nil, "", nil
 			})
 			d.Applier = newMockApplier(gomock.NewController(t), false, errors.New("apply failure"))
 		})
@@ -777,7 +832,7 @@ func TestClusterExtensionBoxcutterApplierFailsDoesNotLeakDeprecationErrors(t *te
 		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
 			RollingOut: []*controllers.RevisionMetadata{{}},
 		}, nil)
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			v := declcfg.VersionRelease{
 				Version: bsemver.MustParse("1.0.0"),
 			}
@@ -785,7 +840,7 @@ func TestClusterExtensionBoxcutterApplierFailsDoesNotLeakDeprecationErrors(t *te
 				Name:    "prometheus.v1.0.0",
 				Package: "prometheus",
 				Image:   "quay.io/operatorhubio/prometheus@fake1.0.0",
-			}, &v, nil, nil
+			}, &v, nil, "", nil
 		})
 		d.ImagePuller = &imageutil.FakePuller{ImageFS: fstest.MapFS{}}
 		d.Applier = newMockApplier(gomock.NewController(t), false, errors.New("boxcutter apply failure"))
@@ -1043,7 +1098,7 @@ func TestClusterExtensionApplierFailsWithBundleInstalled(t *testing.T) {
 		d.ImagePuller = &imageutil.FakePuller{
 			ImageFS: fstest.MapFS{},
 		}
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			v := declcfg.VersionRelease{
 				Version: bsemver.MustParse("1.0.0"),
 			}
@@ -1051,7 +1106,7 @@ func TestClusterExtensionApplierFailsWithBundleInstalled(t *testing.T) {
 				Name:    "prometheus.v1.0.0",
 				Package: "prometheus",
 				Image:   "quay.io/operatorhubio/prometheus@fake1.0.0",
-			}, &v, nil, nil
+			}, &v, nil, "", nil
 		})
 
 		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
@@ -1133,7 +1188,7 @@ func TestClusterExtensionManagerFailed(t *testing.T) {
 		d.ImagePuller = &imageutil.FakePuller{
 			ImageFS: fstest.MapFS{},
 		}
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			v := declcfg.VersionRelease{
 				Version: bsemver.MustParse("1.0.0"),
 			}
@@ -1141,7 +1196,7 @@ func TestClusterExtensionManagerFailed(t *testing.T) {
 				Name:    "prometheus.v1.0.0",
 				Package: "prometheus",
 				Image:   "quay.io/operatorhubio/prometheus@fake1.0.0",
-			}, &v, nil, nil
+			}, &v, nil, "", nil
 		})
 		d.Applier = newMockApplier(gomock.NewController(t), true, errors.New("manager fail"))
 	})
@@ -1209,7 +1264,7 @@ func TestClusterExtensionManagedContentCacheWatchFail(t *testing.T) {
 		d.ImagePuller = &imageutil.FakePuller{
 			ImageFS: fstest.MapFS{},
 		}
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			v := declcfg.VersionRelease{
 				Version: bsemver.MustParse("1.0.0"),
 			}
@@ -1217,7 +1272,7 @@ func TestClusterExtensionManagedContentCacheWatchFail(t *testing.T) {
 				Name:    "prometheus.v1.0.0",
 				Package: "prometheus",
 				Image:   "quay.io/operatorhubio/prometheus@fake1.0.0",
-			}, &v, nil, nil
+			}, &v, nil, "", nil
 		})
 		d.Applier = newMockApplier(gomock.NewController(t), true, errors.New("watch error"))
 	})
@@ -1287,7 +1342,7 @@ func TestClusterExtensionInstallationSucceeds(t *testing.T) {
 		d.ImagePuller = &imageutil.FakePuller{
 			ImageFS: fstest.MapFS{},
 		}
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			v := declcfg.VersionRelease{
 				Version: bsemver.MustParse("1.0.0"),
 			}
@@ -1295,7 +1350,7 @@ func TestClusterExtensionInstallationSucceeds(t *testing.T) {
 				Name:    "prometheus.v1.0.0",
 				Package: "prometheus",
 				Image:   "quay.io/operatorhubio/prometheus@fake1.0.0",
-			}, &v, nil, nil
+			}, &v, nil, "", nil
 		})
 		d.Applier = newMockApplier(gomock.NewController(t), true, nil)
 	})
@@ -1366,7 +1421,7 @@ func TestClusterExtensionDeleteFinalizerFails(t *testing.T) {
 		d.ImagePuller = &imageutil.FakePuller{
 			ImageFS: fstest.MapFS{},
 		}
-		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		d.Resolver = resolve.Func(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 			v := declcfg.VersionRelease{
 				Version: bsemver.MustParse("1.0.0"),
 			}
@@ -1374,7 +1429,7 @@ func TestClusterExtensionDeleteFinalizerFails(t *testing.T) {
 				Name:    "prometheus.v1.0.0",
 				Package: "prometheus",
 				Image:   "quay.io/operatorhubio/prometheus@fake1.0.0",
-			}, &v, nil, nil
+			}, &v, nil, "", nil
 		})
 		d.Applier = newMockApplier(gomock.NewController(t), true, nil)
 		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
@@ -2612,7 +2667,7 @@ func TestResolutionFallbackToInstalledBundle(t *testing.T) {
 		resolveAttempt := 0
 		cl, reconciler := newClientAndReconciler(t, func(d *deps) {
 			// First reconcile: catalog available, second reconcile: catalog unavailable
-			d.Resolver = resolve.Func(func(_ context.Context, _ *ocv1.ClusterExtension, _ *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+			d.Resolver = resolve.Func(func(_ context.Context, _ *ocv1.ClusterExtension, _ *ocv1.BundleMetadata, _ string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 				resolveAttempt++
 				if resolveAttempt == 1 {
 					// First reconcile: catalog available, resolve to version 1.0.0
@@ -2621,10 +2676,10 @@ func TestResolutionFallbackToInstalledBundle(t *testing.T) {
 						Name:    "test.1.0.0",
 						Package: "test-pkg",
 						Image:   "test-image:1.0.0",
-					}, &v, &declcfg.Deprecation{}, nil
+					}, &v, &declcfg.Deprecation{}, "", nil
 				}
 				// Second reconcile: catalog unavailable
-				return nil, nil, nil, fmt.Errorf("catalog unavailable")
+				return nil, nil, nil, "", fmt.Errorf("catalog unavailable")
 			})
 			// Applier succeeds (resources maintained)
 			d.Applier = newMockApplier(gomock.NewController(t), true, nil)
@@ -2710,8 +2765,8 @@ func TestResolutionFallbackToInstalledBundle(t *testing.T) {
 
 	t.Run("fails when version upgrade requested without catalog", func(t *testing.T) {
 		cl, reconciler := newClientAndReconciler(t, func(d *deps) {
-			d.Resolver = resolve.Func(func(_ context.Context, _ *ocv1.ClusterExtension, _ *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
-				return nil, nil, nil, fmt.Errorf("catalog unavailable")
+			d.Resolver = resolve.Func(func(_ context.Context, _ *ocv1.ClusterExtension, _ *ocv1.BundleMetadata, _ string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+				return nil, nil, nil, "", fmt.Errorf("catalog unavailable")
 			})
 			d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
 				Installed: &controllers.RevisionMetadata{
@@ -2768,11 +2823,11 @@ func TestResolutionFallbackToInstalledBundle(t *testing.T) {
 		resolveAttempt := 0
 		cl, reconciler := newClientAndReconciler(t, func(d *deps) {
 			// First attempt: catalog unavailable, then becomes available
-			d.Resolver = resolve.Func(func(_ context.Context, _ *ocv1.ClusterExtension, _ *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+			d.Resolver = resolve.Func(func(_ context.Context, _ *ocv1.ClusterExtension, _ *ocv1.BundleMetadata, _ string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
 				resolveAttempt++
 				if resolveAttempt == 1 {
 					// First reconcile: catalog unavailable
-					return nil, nil, nil, fmt.Errorf("catalog temporarily unavailable")
+					return nil, nil, nil, "", fmt.Errorf("catalog temporarily unavailable")
 				}
 				// Second reconcile (triggered by catalog watch): catalog available with new version
 				v := declcfg.VersionRelease{Version: bsemver.MustParse("2.0.0")}
@@ -2780,7 +2835,7 @@ func TestResolutionFallbackToInstalledBundle(t *testing.T) {
 					Name:    "test.2.0.0",
 					Package: "test-pkg",
 					Image:   "test-image:2.0.0",
-				}, &v, &declcfg.Deprecation{}, nil
+				}, &v, &declcfg.Deprecation{}, "", nil
 			})
 			d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
 				Installed: &controllers.RevisionMetadata{
@@ -2864,8 +2919,8 @@ func TestResolutionFallbackToInstalledBundle(t *testing.T) {
 	t.Run("retries when catalogs exist but resolution fails", func(t *testing.T) {
 		cl, reconciler := newClientAndReconciler(t, func(d *deps) {
 			// Resolver fails (transient issue)
-			d.Resolver = resolve.Func(func(_ context.Context, _ *ocv1.ClusterExtension, _ *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
-				return nil, nil, nil, fmt.Errorf("transient catalog issue: cache stale")
+			d.Resolver = resolve.Func(func(_ context.Context, _ *ocv1.ClusterExtension, _ *ocv1.BundleMetadata, _ string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+				return nil, nil, nil, "", fmt.Errorf("transient catalog issue: cache stale")
 			})
 			d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
 				Installed: &controllers.RevisionMetadata{
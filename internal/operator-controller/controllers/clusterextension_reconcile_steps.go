@@ -29,9 +29,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/finalizer"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/crdownership"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/features"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/installpolicy"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
@@ -129,6 +135,30 @@ func RetrieveRevisionStates(r RevisionStatesGetter) ReconcileStepFunc {
 	}
 }
 
+// CheckBundleDenial reports in the BundleDenied condition whether the installed bundle
+// is denied by a ClusterBundleDenyPolicy. The policies are listed with listPolicies.
+// It does nothing unless the BundleDenyPolicies feature is enabled.
+func CheckBundleDenial(listPolicies func(context.Context) ([]ocv1.ClusterBundleDenyPolicy, error)) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		if !features.OperatorControllerFeatureGate.Enabled(features.BundleDenyPolicies) {
+			return nil, nil
+		}
+
+		policies, err := listPolicies(ctx)
+		if err == nil {
+			state.bundleDenials, err = resolve.NewBundleDenials(policies)
+		}
+		if err != nil {
+			err = fmt.Errorf("error checking bundle deny policies: %w", err)
+			setStatusProgressing(ext, err)
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+		setBundleDeniedStatus(ext, state.bundleDenials, state.revisionStates.Installed)
+		return nil, nil
+	}
+}
+
 // ResolveBundle resolves the bundle to install or roll out for a ClusterExtension.
 // It requires a controller-runtime client (in addition to the resolve.Resolver) to enable
 // intelligent error handling when resolution fails. The client is used to check if ClusterCatalogs
@@ -153,11 +183,15 @@ func ResolveBundle(r resolve.Resolver, c client.Client) ReconcileStepFunc {
 
 		// Resolve a new bundle from the catalog
 		l.V(1).Info("resolving bundle")
-		var bm *ocv1.BundleMetadata
+		var (
+			bm               *ocv1.BundleMetadata
+			installedCatalog string
+		)
 		if state.revisionStates.Installed != nil {
 			bm = &state.revisionStates.Installed.BundleMetadata
+			installedCatalog = state.revisionStates.Installed.Catalog
 		}
-		resolvedBundle, resolvedBundleVersion, resolvedDeprecation, err := r.Resolve(ctx, ext, bm)
+		resolvedBundle, resolvedBundleVersion, resolvedDeprecation, resolvedCatalog, err := r.Resolve(ctx, ext, bm, installedCatalog)
 
 		// Get the installed bundle name for deprecation status.
 		// BundleDeprecated should reflect what's currently running, not what we're trying to install.
@@ -174,11 +208,8 @@ func ResolveBundle(r resolve.Resolver, c client.Client) ReconcileStepFunc {
 		// Note: We DO check for deprecation data even when resolution fails (hasCatalogData = err == nil || resolvedDeprecation != nil).
 		// This allows us to show package/channel deprecation warnings even when we can't resolve a specific bundle.
 		//
-		// TODO: Open question - what if different catalogs have different opinions of what's deprecated?
-		//   If we can't resolve a bundle, how do we know which catalog to trust for deprecation information?
-		//   Perhaps if the package shows up in multiple catalogs and deprecations don't match, we can set
-		//   the deprecation status to unknown? Or perhaps we somehow combine the deprecation information from
-		//   all catalogs? This needs a follow-up discussion and PR.
+		// When the package is provided by multiple catalogs, the resolver merges their deprecations according
+		// to its deprecation merge strategy, and names the catalogs that reported each deprecation.
 		hasCatalogData := err == nil || resolvedDeprecation != nil
 		state.resolvedDeprecation = resolvedDeprecation
 		state.hasCatalogData = hasCatalogData
@@ -188,9 +219,11 @@ func ResolveBundle(r resolve.Resolver, c client.Client) ReconcileStepFunc {
 			return handleResolutionError(ctx, c, state, ext, err)
 		}
 
+		state.resolvedBundle = resolvedBundle
 		state.resolvedRevisionMetadata = &RevisionMetadata{
 			Package: resolvedBundle.Package,
 			Image:   resolvedBundle.Image,
+			Catalog: resolvedCatalog,
 			// MetadataFor accepts VersionRelease input and normalizes bundle metadata.
 			// - With BundleReleaseSupport enabled, it stores release information in BundleMetadata.Release
 			//   (whether provided explicitly via pkg.Release or extracted from registry+v1 build metadata,
@@ -230,8 +263,11 @@ func handleResolutionError(ctx context.Context, c client.Client, state *reconcil
 
 	// Check if the spec is requesting a specific version that differs from installed
 	specVersion := ""
-	if ext.Spec.Source.Catalog != nil {
+	switch {
+	case ext.Spec.Source.Catalog != nil:
 		specVersion = ext.Spec.Source.Catalog.Version
+	case ext.Spec.Source.ProvidedAPI != nil:
+		specVersion = ext.Spec.Source.ProvidedAPI.BundleVersion
 	}
 	installedVersion := state.revisionStates.Installed.Version
 
@@ -252,10 +288,7 @@ func handleResolutionError(ctx context.Context, c client.Client, state *reconcil
 	catalogsExist, catalogCheckErr := CheckCatalogsExist(ctx, c, ext)
 	if catalogCheckErr != nil {
 		msg := fmt.Sprintf("failed to resolve bundle: %v", err)
-		var catalogName string
-		if ext.Spec.Source.Catalog != nil {
-			catalogName = getCatalogNameFromSelector(ext.Spec.Source.Catalog.Selector)
-		}
+		catalogName := getCatalogNameFromSelector(getCatalogSelector(ext))
 		l.Error(catalogCheckErr, "error checking if ClusterCatalogs exist, will retry resolution",
 			"resolutionError", err,
 			"packageName", getPackageName(ext),
@@ -270,10 +303,7 @@ func handleResolutionError(ctx context.Context, c client.Client, state *reconcil
 		// ClusterCatalogs exist but resolution failed - likely a transient issue (ClusterCatalog updating, cache stale, etc.)
 		// Retry resolution instead of falling back
 		msg := fmt.Sprintf("failed to resolve bundle, retrying: %v", err)
-		var catalogName string
-		if ext.Spec.Source.Catalog != nil {
-			catalogName = getCatalogNameFromSelector(ext.Spec.Source.Catalog.Selector)
-		}
+		catalogName := getCatalogNameFromSelector(getCatalogSelector(ext))
 		l.Error(err, "resolution failed but matching ClusterCatalogs exist - retrying instead of falling back",
 			"packageName", getPackageName(ext),
 			"catalogName", catalogName)
@@ -286,10 +316,7 @@ func handleResolutionError(ctx context.Context, c client.Client, state *reconcil
 	// ClusterCatalogs don't exist (deleted) - fall back to installed bundle to maintain current state.
 	// The controller watches ClusterCatalog resources, so when ClusterCatalogs become available again,
 	// a reconcile will be triggered automatically, allowing the extension to upgrade.
-	var catalogName string
-	if ext.Spec.Source.Catalog != nil {
-		catalogName = getCatalogNameFromSelector(ext.Spec.Source.Catalog.Selector)
-	}
+	catalogName := getCatalogNameFromSelector(getCatalogSelector(ext))
 	l.Info("matching ClusterCatalogs unavailable or deleted - falling back to installed bundle to maintain workload",
 		"resolutionError", err.Error(),
 		"packageName", getPackageName(ext),
@@ -313,6 +340,18 @@ func getCatalogNameFromSelector(selector *metav1.LabelSelector) string {
 	return selector.MatchLabels["olm.operatorframework.io/metadata.name"]
 }
 
+// getCatalogSelector returns the selector of the catalogs that the extension is
+// resolved from. Returns nil if the extension does not select catalogs.
+func getCatalogSelector(ext *ocv1.ClusterExtension) *metav1.LabelSelector {
+	switch {
+	case ext.Spec.Source.Catalog != nil:
+		return ext.Spec.Source.Catalog.Selector
+	case ext.Spec.Source.ProvidedAPI != nil:
+		return ext.Spec.Source.ProvidedAPI.Selector
+	}
+	return nil
+}
+
 // getPackageName safely extracts the package name from the extension spec.
 // Returns empty string if Catalog source is nil.
 func getPackageName(ext *ocv1.ClusterExtension) string {
@@ -324,30 +363,31 @@ func getPackageName(ext *ocv1.ClusterExtension) string {
 
 // CheckCatalogsExist checks if any ClusterCatalogs matching the extension's selector exist.
 // Returns true if at least one matching ClusterCatalog exists, false if none exist.
+// When the NamespacedCatalogs feature is enabled, matching Catalogs in the extension's
+// namespace are considered as well.
 // Treats "CRD doesn't exist" errors as "no ClusterCatalogs exist" (returns false, nil).
 // Returns an error only if the check itself fails unexpectedly.
 func CheckCatalogsExist(ctx context.Context, c client.Client, ext *ocv1.ClusterExtension) (bool, error) {
-	var catalogList *ocv1.ClusterCatalogList
-	var listErr error
+	var listOpts []client.ListOption
 
-	if ext.Spec.Source.Catalog == nil || ext.Spec.Source.Catalog.Selector == nil {
+	catalogSelector := getCatalogSelector(ext)
+	if catalogSelector == nil {
 		// No selector means all ClusterCatalogs match - check if any ClusterCatalogs exist at all
-		catalogList = &ocv1.ClusterCatalogList{}
-		listErr = c.List(ctx, catalogList, client.Limit(1))
+		listOpts = append(listOpts, client.Limit(1))
 	} else {
 		// Convert label selector to k8slabels.Selector
 		// Note: An empty LabelSelector matches everything by default
-		selector, err := metav1.LabelSelectorAsSelector(ext.Spec.Source.Catalog.Selector)
+		selector, err := metav1.LabelSelectorAsSelector(catalogSelector)
 		if err != nil {
 			return false, fmt.Errorf("invalid catalog selector: %w", err)
 		}
 
 		// List ClusterCatalogs matching the selector (limit to 1 since we only care if any exist)
-		catalogList = &ocv1.ClusterCatalogList{}
-		listErr = c.List(ctx, catalogList, client.MatchingLabelsSelector{Selector: selector}, client.Limit(1))
+		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selector}, client.Limit(1))
 	}
 
-	if listErr != nil {
+	catalogList := &ocv1.ClusterCatalogList{}
+	if listErr := c.List(ctx, catalogList, listOpts...); listErr != nil {
 		// Check if the error is because the ClusterCatalog CRD doesn't exist
 		// This can happen if catalogd is not installed, which means no ClusterCatalogs exist
 		if apimeta.IsNoMatchError(listErr) {
@@ -355,11 +395,94 @@ func CheckCatalogsExist(ctx context.Context, c client.Client, ext *ocv1.ClusterE
 		}
 		return false, fmt.Errorf("failed to list ClusterCatalogs: %w", listErr)
 	}
+	if len(catalogList.Items) > 0 || !features.OperatorControllerFeatureGate.Enabled(features.NamespacedCatalogs) {
+		return len(catalogList.Items) > 0, nil
+	}
+
+	namespacedList := &ocv1.CatalogList{}
+	if listErr := c.List(ctx, namespacedList, append(listOpts, client.InNamespace(ext.Spec.Namespace))...); listErr != nil {
+		if apimeta.IsNoMatchError(listErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to list Catalogs: %w", listErr)
+	}
 
-	return len(catalogList.Items) > 0, nil
+	return len(namespacedList.Items) > 0, nil
 }
 
-func UnpackBundle(i imageutil.Puller, cache imageutil.Cache) ReconcileStepFunc {
+// CheckInstallPolicies blocks the installation of the resolved bundle when the extension
+// does not satisfy the ClusterExtensionInstallPolicies, as evaluated by check. The validations
+// that access the bundle are only evaluated when a bundle was resolved from a catalog, and
+// not while a previously resolved bundle is rolling out. It does nothing unless the
+// InstallPolicies feature is enabled.
+func CheckInstallPolicies(check func(context.Context, *ocv1.ClusterExtension, map[string]any) error) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		if !features.OperatorControllerFeatureGate.Enabled(features.InstallPolicies) {
+			return nil, nil
+		}
+		if state.resolvedRevisionMetadata == nil {
+			return nil, fmt.Errorf("unable to retrieve bundle information")
+		}
+
+		var bundle map[string]any
+		var err error
+		if state.resolvedBundle != nil {
+			bundle, err = installpolicy.Bundle(*state.resolvedBundle, state.resolvedRevisionMetadata.Version, state.resolvedRevisionMetadata.Catalog)
+		}
+		if err == nil {
+			err = check(ctx, ext, bundle)
+		}
+		if err != nil {
+			if installpolicy.IsViolation(err) {
+				// Policy violations require changes to the extension or the policies, which
+				// trigger a new reconciliation.
+				err = reconcile.TerminalError(err)
+			} else {
+				err = fmt.Errorf("error checking install policies: %w", err)
+			}
+			setStatusProgressing(ext, wrapErrorWithResolutionInfo(state.resolvedRevisionMetadata.BundleMetadata, err))
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+		return nil, nil
+	}
+}
+
+// CheckCRDOwnership blocks the installation of the resolved bundle when it provides
+// CustomResourceDefinitions that are managed by other ClusterExtensions, as evaluated
+// by check, instead of failing to apply them. It is only evaluated when a bundle was
+// resolved from a catalog, and not while a previously resolved bundle is rolling out.
+// It does nothing unless the CRDOwnershipCheck feature is enabled.
+func CheckCRDOwnership(check func(context.Context, *ocv1.ClusterExtension, declcfg.Bundle) error) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		if !features.OperatorControllerFeatureGate.Enabled(features.CRDOwnershipCheck) {
+			return nil, nil
+		}
+		if state.resolvedRevisionMetadata == nil {
+			return nil, fmt.Errorf("unable to retrieve bundle information")
+		}
+		if state.resolvedBundle == nil {
+			return nil, nil
+		}
+
+		if err := check(ctx, ext, *state.resolvedBundle); err != nil {
+			// CustomResourceDefinitions are not watched, so conflicts are retried until
+			// the other ClusterExtensions release them.
+			if !crdownership.IsConflict(err) {
+				err = fmt.Errorf("error checking CustomResourceDefinition ownership: %w", err)
+			}
+			setStatusProgressing(ext, wrapErrorWithResolutionInfo(state.resolvedRevisionMetadata.BundleMetadata, err))
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+		return nil, nil
+	}
+}
+
+// UnpackBundle pulls the image of the resolved bundle. The pull secret referenced by
+// the bundle pull configuration of the extension is read with c from the namespace
+// of the extension.
+func UnpackBundle(i imageutil.Puller, cache imageutil.Cache, c client.Reader) ReconcileStepFunc {
 	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
 		l := log.FromContext(ctx)
 
@@ -368,9 +491,20 @@ func UnpackBundle(i imageutil.Puller, cache imageutil.Cache) ReconcileStepFunc {
 			return nil, fmt.Errorf("unable to retrieve bundle information")
 		}
 
+		pullCtx := ctx
+		if ext.Spec.Source.Catalog != nil && ext.Spec.Source.Catalog.BundlePullConfig != nil {
+			opts, err := imageutil.NewPullOptions(ctx, c, ext.Spec.Namespace, ext.Spec.Source.Catalog.BundlePullConfig)
+			if err != nil {
+				setStatusProgressing(ext, wrapErrorWithResolutionInfo(state.resolvedRevisionMetadata.BundleMetadata, err))
+				setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+				return nil, err
+			}
+			pullCtx = imageutil.WithPullOptions(ctx, opts)
+		}
+
 		// Always try to pull the bundle content (Pull uses cache-first strategy, so this is efficient)
 		l.V(1).Info("pulling bundle content")
-		imageFS, _, _, err := i.Pull(ctx, ext.GetName(), state.resolvedRevisionMetadata.Image, cache)
+		imageFS, _, _, err := i.Pull(pullCtx, ext.GetName(), state.resolvedRevisionMetadata.Image, cache)
 
 		// Check if resolved bundle matches installed bundle (no version change)
 		bundleUnchanged := state.revisionStates != nil &&
@@ -419,6 +553,9 @@ func ApplyBundle(a Applier) ReconcileStepFunc {
 		if state.resolvedRevisionMetadata.Release != nil {
 			revisionAnnotations[labels.BundleReleaseKey] = *state.resolvedRevisionMetadata.Release
 		}
+		if state.resolvedRevisionMetadata.Catalog != "" {
+			revisionAnnotations[labels.CatalogNameKey] = state.resolvedRevisionMetadata.Catalog
+		}
 		objLbls := map[string]string{
 			labels.OwnerKindKey: ocv1.ClusterExtensionKind,
 			labels.OwnerNameKey: ext.GetName(),
@@ -451,6 +588,9 @@ func ApplyBundle(a Applier) ReconcileStepFunc {
 		// resolution.
 		if rolloutSucceeded && state.revisionStates.Installed != nil {
 			SetDeprecationStatus(ext, state.revisionStates.Installed.Name, state.resolvedDeprecation, state.hasCatalogData)
+			if state.bundleDenials != nil {
+				setBundleDeniedStatus(ext, state.bundleDenials, state.revisionStates.Installed)
+			}
 		}
 
 		// If there was an error applying the resolved bundle,
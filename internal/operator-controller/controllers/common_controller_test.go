@@ -1,20 +1,31 @@
 package controllers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/crdownership"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/features"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/installpolicy"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/scheme"
 	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 )
 
@@ -454,3 +465,348 @@ func TestSetInstalledStatusFromRevisionStates_ConfigValidationError(t *testing.T
 		})
 	}
 }
+
+func TestSetInstalledStatusFromRevisionStates_Catalog(t *testing.T) {
+	ext := &ocv1.ClusterExtension{}
+	setInstalledStatusFromRevisionStates(ext, &RevisionStates{
+		Installed: &RevisionMetadata{
+			Package: "test-package",
+			Image:   "quay.io/test/bundle:v1.0.0",
+			Catalog: "test-catalog",
+			BundleMetadata: ocv1.BundleMetadata{
+				Name:    "test-package.v1.0.0",
+				Version: "1.0.0",
+			},
+		},
+	})
+	require.Equal(t, &ocv1.ClusterExtensionInstallStatus{
+		Bundle: ocv1.BundleMetadata{
+			Name:    "test-package.v1.0.0",
+			Version: "1.0.0",
+		},
+		Catalog: "test-catalog",
+	}, ext.Status.Install)
+}
+
+func TestCheckBundleDenial(t *testing.T) {
+	installed := &RevisionMetadata{
+		Package: "test-package",
+		Image:   "quay.io/test/bundle:v1.0.0",
+		BundleMetadata: ocv1.BundleMetadata{
+			Name:    "test-package.v1.0.0",
+			Version: "1.0.0",
+		},
+	}
+	policy := func(remediation ocv1.BundleDenyRemediation, versionRange string) ocv1.ClusterBundleDenyPolicy {
+		return ocv1.ClusterBundleDenyPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "cves"},
+			Spec: ocv1.ClusterBundleDenyPolicySpec{
+				Entries:     []ocv1.BundleDenyEntry{{PackageName: "test-package", VersionRange: versionRange, Reason: "CVE-1"}},
+				Remediation: remediation,
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		policies      []ocv1.ClusterBundleDenyPolicy
+		listErr       error
+		installed     *RevisionMetadata
+		wantErr       string
+		wantCondition *metav1.Condition
+	}{
+		{
+			name:      "denied",
+			policies:  []ocv1.ClusterBundleDenyPolicy{policy("", "<1.0.1")},
+			installed: installed,
+			wantCondition: &metav1.Condition{
+				Status:  metav1.ConditionTrue,
+				Reason:  ocv1.ReasonDenied,
+				Message: `bundle "test-package.v1.0.0" is denied by ClusterBundleDenyPolicy "cves": CVE-1`,
+			},
+		},
+		{
+			name:      "denied with remediation",
+			policies:  []ocv1.ClusterBundleDenyPolicy{policy(ocv1.BundleDenyRemediationUpgrade, "")},
+			installed: installed,
+			wantCondition: &metav1.Condition{
+				Status:  metav1.ConditionTrue,
+				Reason:  ocv1.ReasonDenied,
+				Message: "bundle \"test-package.v1.0.0\" is denied by ClusterBundleDenyPolicy \"cves\": CVE-1\nupgrading to an allowed successor",
+			},
+		},
+		{
+			name:      "not denied",
+			policies:  []ocv1.ClusterBundleDenyPolicy{policy("", ">=1.0.1")},
+			installed: installed,
+			wantCondition: &metav1.Condition{
+				Status:  metav1.ConditionFalse,
+				Reason:  ocv1.ReasonNotDenied,
+				Message: "not denied",
+			},
+		},
+		{
+			name:     "no bundle installed",
+			policies: []ocv1.ClusterBundleDenyPolicy{policy("", "")},
+			wantCondition: &metav1.Condition{
+				Status:  metav1.ConditionUnknown,
+				Reason:  ocv1.ReasonAbsent,
+				Message: "no bundle installed yet",
+			},
+		},
+		{
+			name:      "error listing policies",
+			listErr:   errors.New("fake error"),
+			installed: installed,
+			wantErr:   "error checking bundle deny policies: fake error",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, features.OperatorControllerFeatureGate.Set(fmt.Sprintf("%s=true", features.BundleDenyPolicies)))
+			defer func() {
+				require.NoError(t, features.OperatorControllerFeatureGate.Set(fmt.Sprintf("%s=false", features.BundleDenyPolicies)))
+			}()
+
+			ext := &ocv1.ClusterExtension{}
+			state := &reconcileState{revisionStates: &RevisionStates{Installed: tc.installed}}
+			step := CheckBundleDenial(func(context.Context) ([]ocv1.ClusterBundleDenyPolicy, error) {
+				return tc.policies, tc.listErr
+			})
+			res, err := step(context.Background(), state, ext)
+			require.Nil(t, res)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				require.Nil(t, meta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeBundleDenied))
+				return
+			}
+			require.NoError(t, err)
+			cond := meta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeBundleDenied)
+			require.NotNil(t, cond)
+			require.Equal(t, tc.wantCondition.Status, cond.Status)
+			require.Equal(t, tc.wantCondition.Reason, cond.Reason)
+			require.Equal(t, tc.wantCondition.Message, cond.Message)
+		})
+	}
+
+	t.Run("feature disabled", func(t *testing.T) {
+		ext := &ocv1.ClusterExtension{}
+		step := CheckBundleDenial(func(context.Context) ([]ocv1.ClusterBundleDenyPolicy, error) {
+			return nil, errors.New("must not be called")
+		})
+		res, err := step(context.Background(), &reconcileState{revisionStates: &RevisionStates{Installed: installed}}, ext)
+		require.Nil(t, res)
+		require.NoError(t, err)
+		require.Empty(t, ext.Status.Conditions)
+	})
+}
+
+func TestCheckInstallPolicies(t *testing.T) {
+	resolved := &RevisionMetadata{
+		Package: "test-package",
+		Catalog: "test-catalog",
+		BundleMetadata: ocv1.BundleMetadata{
+			Name:    "test-package.v1.0.0",
+			Version: "1.0.0",
+		},
+	}
+	resolvedBundle := &declcfg.Bundle{Name: "test-package.v1.0.0", Package: "test-package"}
+	wantBundle := map[string]any{
+		"name":         "test-package.v1.0.0",
+		"package":      "test-package",
+		"version":      "1.0.0",
+		"catalog":      "test-catalog",
+		"properties":   []any{},
+		"providedGVKs": []any{},
+		"installModes": []any{},
+	}
+	violation := &installpolicy.ViolationError{Violations: []installpolicy.Violation{{Policy: "trusted", Message: "only trusted catalogs"}}}
+
+	for _, tc := range []struct {
+		name           string
+		resolvedBundle *declcfg.Bundle
+		checkErr       error
+		wantBundle     map[string]any
+		wantErr        string
+		wantReason     string
+		wantMessage    string
+	}{
+		{
+			name:           "allowed",
+			resolvedBundle: resolvedBundle,
+			wantBundle:     wantBundle,
+		},
+		{
+			name: "rolling out without resolved bundle",
+		},
+		{
+			name:           "violated",
+			resolvedBundle: resolvedBundle,
+			checkErr:       violation,
+			wantBundle:     wantBundle,
+			wantErr:        `violates ClusterExtensionInstallPolicy "trusted": only trusted catalogs`,
+			wantReason:     ocv1.ReasonBlocked,
+			wantMessage:    `error for resolved bundle "test-package.v1.0.0" with version "1.0.0": violates ClusterExtensionInstallPolicy "trusted": only trusted catalogs`,
+		},
+		{
+			name:           "error checking policies",
+			resolvedBundle: resolvedBundle,
+			checkErr:       errors.New("fake error"),
+			wantBundle:     wantBundle,
+			wantErr:        "error checking install policies: fake error",
+			wantReason:     ocv1.ReasonRetrying,
+			wantMessage:    `error for resolved bundle "test-package.v1.0.0" with version "1.0.0": error checking install policies: fake error`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, features.OperatorControllerFeatureGate.Set(fmt.Sprintf("%s=true", features.InstallPolicies)))
+			defer func() {
+				require.NoError(t, features.OperatorControllerFeatureGate.Set(fmt.Sprintf("%s=false", features.InstallPolicies)))
+			}()
+
+			ext := &ocv1.ClusterExtension{}
+			state := &reconcileState{
+				revisionStates:           &RevisionStates{},
+				resolvedRevisionMetadata: resolved,
+				resolvedBundle:           tc.resolvedBundle,
+			}
+			var gotBundle map[string]any
+			step := CheckInstallPolicies(func(_ context.Context, _ *ocv1.ClusterExtension, bundle map[string]any) error {
+				gotBundle = bundle
+				return tc.checkErr
+			})
+			res, err := step(context.Background(), state, ext)
+			require.Nil(t, res)
+			require.Equal(t, tc.wantBundle, gotBundle)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				require.Empty(t, ext.Status.Conditions)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+			cond := meta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+			require.NotNil(t, cond)
+			require.Equal(t, tc.wantReason, cond.Reason)
+			require.Equal(t, tc.wantMessage, cond.Message)
+		})
+	}
+
+	t.Run("feature disabled", func(t *testing.T) {
+		step := CheckInstallPolicies(func(context.Context, *ocv1.ClusterExtension, map[string]any) error {
+			return errors.New("must not be called")
+		})
+		res, err := step(context.Background(), &reconcileState{resolvedRevisionMetadata: resolved}, &ocv1.ClusterExtension{})
+		require.Nil(t, res)
+		require.NoError(t, err)
+	})
+}
+
+func TestNamespaceClusterExtensionRequests(t *testing.T) {
+	ext := func(name, namespace string) *ocv1.ClusterExtension {
+		return &ocv1.ClusterExtension{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       ocv1.ClusterExtensionSpec{Namespace: namespace},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		ext("argocd", "team-a"),
+		ext("prometheus", "team-a"),
+		ext("cert-manager", "team-b"),
+	).Build()
+
+	requests := namespaceClusterExtensionRequests(c, logr.Discard())(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}})
+	require.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "argocd"}},
+		{NamespacedName: types.NamespacedName{Name: "prometheus"}},
+	}, requests)
+}
+
+func TestCheckCRDOwnership(t *testing.T) {
+	resolved := &RevisionMetadata{
+		Package: "test-package",
+		BundleMetadata: ocv1.BundleMetadata{
+			Name:    "test-package.v1.0.0",
+			Version: "1.0.0",
+		},
+	}
+	resolvedBundle := &declcfg.Bundle{Name: "test-package.v1.0.0", Package: "test-package"}
+	conflict := &crdownership.ConflictError{
+		Bundle:    "test-package.v1.0.0",
+		Conflicts: []crdownership.Conflict{{CRD: "tests.example.com", ClusterExtension: "other"}},
+	}
+
+	for _, tc := range []struct {
+		name           string
+		resolvedBundle *declcfg.Bundle
+		checkErr       error
+		wantChecked    bool
+		wantErr        string
+		wantMessage    string
+	}{
+		{
+			name:           "no conflict",
+			resolvedBundle: resolvedBundle,
+			wantChecked:    true,
+		},
+		{
+			name: "rolling out without resolved bundle",
+		},
+		{
+			name:           "conflict",
+			resolvedBundle: resolvedBundle,
+			checkErr:       conflict,
+			wantChecked:    true,
+			wantErr:        `CustomResourceDefinition "tests.example.com" is already managed by ClusterExtension "other"`,
+			wantMessage:    `error for resolved bundle "test-package.v1.0.0" with version "1.0.0": bundle "test-package.v1.0.0" conflicts with other ClusterExtensions: CustomResourceDefinition "tests.example.com" is already managed by ClusterExtension "other"`,
+		},
+		{
+			name:           "error checking ownership",
+			resolvedBundle: resolvedBundle,
+			checkErr:       errors.New("fake error"),
+			wantChecked:    true,
+			wantErr:        "error checking CustomResourceDefinition ownership: fake error",
+			wantMessage:    `error for resolved bundle "test-package.v1.0.0" with version "1.0.0": error checking CustomResourceDefinition ownership: fake error`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, features.OperatorControllerFeatureGate.Set(fmt.Sprintf("%s=true", features.CRDOwnershipCheck)))
+			defer func() {
+				require.NoError(t, features.OperatorControllerFeatureGate.Set(fmt.Sprintf("%s=false", features.CRDOwnershipCheck)))
+			}()
+
+			ext := &ocv1.ClusterExtension{}
+			state := &reconcileState{
+				revisionStates:           &RevisionStates{},
+				resolvedRevisionMetadata: resolved,
+				resolvedBundle:           tc.resolvedBundle,
+			}
+			checked := false
+			step := CheckCRDOwnership(func(_ context.Context, _ *ocv1.ClusterExtension, bundle declcfg.Bundle) error {
+				checked = true
+				require.Equal(t, *tc.resolvedBundle, bundle)
+				return tc.checkErr
+			})
+			res, err := step(context.Background(), state, ext)
+			require.Nil(t, res)
+			require.Equal(t, tc.wantChecked, checked)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				require.Empty(t, ext.Status.Conditions)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+			cond := meta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+			require.NotNil(t, cond)
+			require.Equal(t, ocv1.ReasonRetrying, cond.Reason)
+			require.Equal(t, tc.wantMessage, cond.Message)
+		})
+	}
+
+	t.Run("feature disabled", func(t *testing.T) {
+		step := CheckCRDOwnership(func(context.Context, *ocv1.ClusterExtension, declcfg.Bundle) error {
+			return errors.New("must not be called")
+		})
+		res, err := step(context.Background(), &reconcileState{resolvedRevisionMetadata: resolved, resolvedBundle: resolvedBundle}, &ocv1.ClusterExtension{})
+		require.Nil(t, res)
+		require.NoError(t, err)
+	})
+}
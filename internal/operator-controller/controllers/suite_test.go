@@ -107,7 +107,7 @@ func newClientAndReconciler(t *testing.T, opts ...reconcilerOption) (client.Clie
 		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.ResolveBundle(r, cl))
 	}
 	if i := d.ImagePuller; i != nil {
-		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.UnpackBundle(i, d.ImageCache))
+		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.UnpackBundle(i, d.ImageCache, cl))
 	}
 	if a := d.Applier; a != nil {
 		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.ApplyBundle(a))
@@ -0,0 +1,150 @@
+// Package crdownership detects CustomResourceDefinitions that a ClusterExtension would
+// take over from another ClusterExtension, before its bundle is applied.
+package crdownership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
+)
+
+// Conflict is a CustomResourceDefinition of a bundle that is already managed by
+// another ClusterExtension.
+type Conflict struct {
+	// CRD is the name of the CustomResourceDefinition.
+	CRD string
+	// ClusterExtension is the name of the ClusterExtension that manages it.
+	ClusterExtension string
+}
+
+// ConflictError is returned when a bundle provides CustomResourceDefinitions that
+// are managed by other ClusterExtensions.
+type ConflictError struct {
+	Bundle    string
+	Conflicts []Conflict
+}
+
+func (e *ConflictError) Error() string {
+	msgs := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		msgs = append(msgs, fmt.Sprintf("CustomResourceDefinition %q is already managed by ClusterExtension %q", c.CRD, c.ClusterExtension))
+	}
+	return fmt.Sprintf("bundle %q conflicts with other ClusterExtensions: %s", e.Bundle, strings.Join(msgs, ", "))
+}
+
+// BundleCRDs describes the CustomResourceDefinitions that a bundle provides, as
+// declared by its catalog metadata.
+type BundleCRDs struct {
+	// Names are the names of the CustomResourceDefinitions owned by the
+	// ClusterServiceVersion of the bundle, from its olm.csv.metadata property.
+	Names sets.Set[string]
+	// GroupKinds are the group and kind of the APIs that the bundle provides,
+	// from its olm.gvk properties.
+	GroupKinds sets.Set[schema.GroupKind]
+}
+
+// CRDsOf returns the CustomResourceDefinitions that a bundle provides, as declared
+// by its olm.csv.metadata and olm.gvk properties.
+func CRDsOf(bundle declcfg.Bundle) (*BundleCRDs, error) {
+	props, err := property.Parse(bundle.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing properties of bundle %q: %w", bundle.Name, err)
+	}
+
+	crds := &BundleCRDs{
+		Names:      sets.New[string](),
+		GroupKinds: sets.New[schema.GroupKind](),
+	}
+	for _, csv := range props.CSVMetadatas {
+		for _, owned := range csv.CustomResourceDefinitions.Owned {
+			crds.Names.Insert(owned.Name)
+		}
+	}
+	for _, gvk := range props.GVKs {
+		crds.GroupKinds.Insert(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
+	}
+	return crds, nil
+}
+
+// Matches returns whether crd is one of the CustomResourceDefinitions of the bundle.
+func (b *BundleCRDs) Matches(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	return b.Names.Has(crd.Name) || b.GroupKinds.Has(schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind})
+}
+
+// Checker detects the CustomResourceDefinitions of a bundle that are managed by
+// other ClusterExtensions, as recorded by the owner labels of the CustomResourceDefinitions.
+type Checker struct {
+	// Client lists the CustomResourceDefinitions managed by ClusterExtensions.
+	Client apiextensionsv1client.CustomResourceDefinitionInterface
+}
+
+// Check returns a *ConflictError when the bundle resolved for the ClusterExtension
+// provides CustomResourceDefinitions that are managed by other ClusterExtensions.
+func (c *Checker) Check(ctx context.Context, ext *ocv1.ClusterExtension, bundle declcfg.Bundle) error {
+	bundleCRDs, err := CRDsOf(bundle)
+	if err != nil {
+		return err
+	}
+	if bundleCRDs.Names.Len() == 0 && bundleCRDs.GroupKinds.Len() == 0 {
+		return nil
+	}
+
+	selector, err := managedByOthersSelector(ext.Name)
+	if err != nil {
+		return err
+	}
+	crds, err := c.Client.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("error listing CustomResourceDefinitions: %w", err)
+	}
+
+	var conflicts []Conflict
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if bundleCRDs.Matches(crd) {
+			conflicts = append(conflicts, Conflict{CRD: crd.Name, ClusterExtension: crd.Labels[labels.OwnerNameKey]})
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	slices.SortFunc(conflicts, func(a, b Conflict) int { return strings.Compare(a.CRD, b.CRD) })
+	return &ConflictError{Bundle: bundle.Name, Conflicts: conflicts}
+}
+
+// managedByOthersSelector selects the objects managed by ClusterExtensions other
+// than the named one.
+func managedByOthersSelector(extName string) (k8slabels.Selector, error) {
+	ownerKind, err := k8slabels.NewRequirement(labels.OwnerKindKey, selection.Equals, []string{ocv1.ClusterExtensionKind})
+	if err != nil {
+		return nil, err
+	}
+	ownerName, err := k8slabels.NewRequirement(labels.OwnerNameKey, selection.NotEquals, []string{extName})
+	if err != nil {
+		return nil, err
+	}
+	return k8slabels.NewSelector().Add(*ownerKind, *ownerName), nil
+}
+
+// IsConflict returns whether err reports CustomResourceDefinitions managed by
+// other ClusterExtensions.
+func IsConflict(err error) bool {
+	var conflictErr *ConflictError
+	return errors.As(err, &conflictErr)
+}
@@ -0,0 +1,150 @@
+package crdownership_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/crdownership"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
+	mockcrdclient "github.com/operator-framework/operator-controller/internal/testutil/mock/crdclient"
+)
+
+func crd(name, group, kind string, objLabels map[string]string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: objLabels},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: kind},
+		},
+	}
+}
+
+func ownedBy(extName string) map[string]string {
+	return map[string]string{
+		labels.OwnerKindKey: ocv1.ClusterExtensionKind,
+		labels.OwnerNameKey: extName,
+	}
+}
+
+func bundle(t *testing.T, ownedCRDs []string, gvks ...property.GVK) declcfg.Bundle {
+	b := declcfg.Bundle{Name: "cert-manager.v1.14.0", Package: "cert-manager"}
+	for _, gvk := range gvks {
+		b.Properties = append(b.Properties, property.MustBuildGVK(gvk.Group, gvk.Version, gvk.Kind))
+	}
+	if len(ownedCRDs) > 0 {
+		csv := property.CSVMetadata{}
+		for _, name := range ownedCRDs {
+			csv.CustomResourceDefinitions.Owned = append(csv.CustomResourceDefinitions.Owned, v1alpha1.CRDDescription{Name: name})
+		}
+		value, err := json.Marshal(csv)
+		require.NoError(t, err)
+		b.Properties = append(b.Properties, property.Property{Type: property.TypeCSVMetadata, Value: value})
+	}
+	return b
+}
+
+func TestChecker_Check(t *testing.T) {
+	ext := &ocv1.ClusterExtension{ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"}}
+	certificateGVK := property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+	for _, tc := range []struct {
+		name    string
+		crds    []*apiextensionsv1.CustomResourceDefinition
+		bundle  declcfg.Bundle
+		wantErr string
+	}{
+		{
+			name:   "no CustomResourceDefinitions in the bundle",
+			crds:   []*apiextensionsv1.CustomResourceDefinition{crd("certificates.cert-manager.io", "cert-manager.io", "Certificate", ownedBy("other"))},
+			bundle: bundle(t, nil),
+		},
+		{
+			name:   "CustomResourceDefinition not installed",
+			bundle: bundle(t, []string{"certificates.cert-manager.io"}, certificateGVK),
+		},
+		{
+			name:   "CustomResourceDefinition managed by the same ClusterExtension",
+			crds:   []*apiextensionsv1.CustomResourceDefinition{crd("certificates.cert-manager.io", "cert-manager.io", "Certificate", ownedBy("cert-manager"))},
+			bundle: bundle(t, []string{"certificates.cert-manager.io"}, certificateGVK),
+		},
+		{
+			name:   "CustomResourceDefinition not managed by a ClusterExtension",
+			crds:   []*apiextensionsv1.CustomResourceDefinition{crd("certificates.cert-manager.io", "cert-manager.io", "Certificate", nil)},
+			bundle: bundle(t, []string{"certificates.cert-manager.io"}, certificateGVK),
+		},
+		{
+			name:   "unrelated CustomResourceDefinition managed by another ClusterExtension",
+			crds:   []*apiextensionsv1.CustomResourceDefinition{crd("argocds.argoproj.io", "argoproj.io", "ArgoCD", ownedBy("argocd"))},
+			bundle: bundle(t, []string{"certificates.cert-manager.io"}, certificateGVK),
+		},
+		{
+			name:    "owned CustomResourceDefinition managed by another ClusterExtension",
+			crds:    []*apiextensionsv1.CustomResourceDefinition{crd("certificates.cert-manager.io", "cert-manager.io", "Certificate", ownedBy("cert-utils"))},
+			bundle:  bundle(t, []string{"certificates.cert-manager.io"}),
+			wantErr: `bundle "cert-manager.v1.14.0" conflicts with other ClusterExtensions: CustomResourceDefinition "certificates.cert-manager.io" is already managed by ClusterExtension "cert-utils"`,
+		},
+		{
+			name: "provided API managed by other ClusterExtensions",
+			crds: []*apiextensionsv1.CustomResourceDefinition{
+				crd("issuers.cert-manager.io", "cert-manager.io", "Issuer", ownedBy("cert-issuers")),
+				crd("certificates.cert-manager.io", "cert-manager.io", "Certificate", ownedBy("cert-utils")),
+			},
+			bundle:  bundle(t, nil, certificateGVK, property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"}),
+			wantErr: `bundle "cert-manager.v1.14.0" conflicts with other ClusterExtensions: CustomResourceDefinition "certificates.cert-manager.io" is already managed by ClusterExtension "cert-utils", CustomResourceDefinition "issuers.cert-manager.io" is already managed by ClusterExtension "cert-issuers"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := mockcrdclient.NewMockCustomResourceDefinitionInterface(gomock.NewController(t))
+			m.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts metav1.ListOptions) (*apiextensionsv1.CustomResourceDefinitionList, error) {
+				selector, err := k8slabels.Parse(opts.LabelSelector)
+				require.NoError(t, err)
+				list := &apiextensionsv1.CustomResourceDefinitionList{}
+				for _, c := range tc.crds {
+					if selector.Matches(k8slabels.Set(c.Labels)) {
+						list.Items = append(list.Items, *c)
+					}
+				}
+				return list, nil
+			}).AnyTimes()
+			checker := &crdownership.Checker{Client: m}
+
+			err := checker.Check(context.Background(), ext, tc.bundle)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.wantErr)
+			require.True(t, crdownership.IsConflict(err))
+		})
+	}
+}
+
+func TestChecker_CheckListError(t *testing.T) {
+	m := mockcrdclient.NewMockCustomResourceDefinitionInterface(gomock.NewController(t))
+	m.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("fake error"))
+	checker := &crdownership.Checker{Client: m}
+
+	ext := &ocv1.ClusterExtension{ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"}}
+	err := checker.Check(context.Background(), ext, bundle(t, []string{"certificates.cert-manager.io"}))
+	require.EqualError(t, err, "error listing CustomResourceDefinitions: fake error")
+	require.False(t, crdownership.IsConflict(err))
+}
+
+func TestIsConflict(t *testing.T) {
+	require.False(t, crdownership.IsConflict(errors.New("fake error")))
+	require.True(t, crdownership.IsConflict(fmt.Errorf("wrapped: %w", &crdownership.ConflictError{})))
+}
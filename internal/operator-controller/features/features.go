@@ -20,6 +20,10 @@ const (
 	BoxcutterRuntime                  featuregate.Feature = "BoxcutterRuntime"
 	DeploymentConfig                  featuregate.Feature = "DeploymentConfig"
 	BundleReleaseSupport              featuregate.Feature = "BundleReleaseSupport"
+	NamespacedCatalogs                featuregate.Feature = "NamespacedCatalogs"
+	BundleDenyPolicies                featuregate.Feature = "BundleDenyPolicies"
+	InstallPolicies                   featuregate.Feature = "InstallPolicies"
+	CRDOwnershipCheck                 featuregate.Feature = "CRDOwnershipCheck"
 )
 
 var operatorControllerFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -101,6 +105,42 @@ var operatorControllerFeatureGates = map[featuregate.Feature]featuregate.Feature
 		PreRelease:    featuregate.Alpha,
 		LockToDefault: false,
 	},
+
+	// NamespacedCatalogs enables resolving ClusterExtensions from namespaced
+	// Catalogs in the namespace of their service account, in addition to
+	// ClusterCatalogs.
+	NamespacedCatalogs: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// BundleDenyPolicies enables ClusterBundleDenyPolicies, which deny bundles
+	// cluster-wide during resolution, and the BundleDenied condition of
+	// ClusterExtensions.
+	BundleDenyPolicies: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// InstallPolicies enables ClusterExtensionInstallPolicies, which restrict what
+	// ClusterExtensions may install with CEL expressions, both at admission and
+	// before the resolved bundle is unpacked.
+	InstallPolicies: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// CRDOwnershipCheck blocks the installation of bundles that provide
+	// CustomResourceDefinitions managed by other ClusterExtensions, before
+	// the bundles are unpacked.
+	CRDOwnershipCheck: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
 }
 
 var OperatorControllerFeatureGate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
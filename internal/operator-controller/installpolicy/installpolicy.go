@@ -0,0 +1,260 @@
+// Package installpolicy evaluates the CEL validations of ClusterExtensionInstallPolicies.
+package installpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// The variables that the validation expressions can access.
+const (
+	ObjectVariable = "object"
+	BundleVariable = "bundle"
+)
+
+// costLimit bounds the cost of evaluating a single expression, so that expressions
+// iterating over large bundles cannot stall admission or reconciliation.
+const costLimit = 1_000_000
+
+var newEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(ObjectVariable, cel.DynType),
+		cel.Variable(BundleVariable, cel.DynType),
+		ext.Strings(),
+		ext.Sets(),
+	)
+})
+
+// Violation is a validation of a ClusterExtensionInstallPolicy that a ClusterExtension
+// does not satisfy.
+type Violation struct {
+	Policy  string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("violates ClusterExtensionInstallPolicy %q: %s", v.Policy, v.Message)
+}
+
+// ViolationError is returned when a ClusterExtension does not satisfy the validations
+// of ClusterExtensionInstallPolicies.
+type ViolationError struct {
+	Violations []Violation
+}
+
+func (e *ViolationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.String())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Policies are compiled ClusterExtensionInstallPolicies.
+type Policies struct {
+	policies []policy
+}
+
+type policy struct {
+	name        string
+	selector    labels.Selector
+	validations []validation
+}
+
+type validation struct {
+	message    string
+	program    cel.Program
+	usesBundle bool
+}
+
+// Compile compiles the given policies. It returns an error when a policy is invalid,
+// so that invalid policies deny installations rather than allow them.
+func Compile(policies []ocv1.ClusterExtensionInstallPolicy) (*Policies, error) {
+	p := &Policies{}
+	for i := range policies {
+		cp, err := compile(&policies[i])
+		if err != nil {
+			return nil, fmt.Errorf("ClusterExtensionInstallPolicy %q is invalid: %w", policies[i].Name, err)
+		}
+		p.policies = append(p.policies, *cp)
+	}
+	return p, nil
+}
+
+// Validate returns an error when the namespace selector or an expression of the
+// given policy is invalid.
+func Validate(p *ocv1.ClusterExtensionInstallPolicy) error {
+	_, err := compile(p)
+	return err
+}
+
+func compile(p *ocv1.ClusterExtensionInstallPolicy) (*policy, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, fmt.Errorf("error creating CEL environment: %w", err)
+	}
+
+	cp := &policy{name: p.Name, selector: labels.Everything()}
+	if p.Spec.NamespaceSelector != nil {
+		cp.selector, err = metav1.LabelSelectorAsSelector(p.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("spec.namespaceSelector: %w", err)
+		}
+	}
+	for i, v := range p.Spec.Validations {
+		ast, iss := env.Compile(v.Expression)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("spec.validations[%d].expression: %w", i, iss.Err())
+		}
+		if t := ast.OutputType(); !t.IsExactType(types.BoolType) && !t.IsExactType(types.DynType) {
+			return nil, fmt.Errorf("spec.validations[%d].expression: must evaluate to a bool, not %s", i, t)
+		}
+		program, err := env.Program(ast, cel.CostLimit(costLimit))
+		if err != nil {
+			return nil, fmt.Errorf("spec.validations[%d].expression: %w", i, err)
+		}
+		usesBundle := false
+		for _, ref := range ast.NativeRep().ReferenceMap() {
+			if ref.Name == BundleVariable {
+				usesBundle = true
+				break
+			}
+		}
+		cp.validations = append(cp.validations, validation{message: v.Message, program: program, usesBundle: usesBundle})
+	}
+	return cp, nil
+}
+
+// Evaluate returns a *ViolationError listing the validations of the policies that the
+// ClusterExtension does not satisfy. Only the policies whose namespace selector matches
+// namespaceLabels, the labels of the namespace of the ClusterExtension, apply.
+//
+// bundle is the value of the bundle variable, as returned by Bundle. When it is nil,
+// the validations that access the bundle variable are not evaluated.
+func (p *Policies) Evaluate(ext *ocv1.ClusterExtension, namespaceLabels map[string]string, bundle map[string]any) error {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ext)
+	if err != nil {
+		return fmt.Errorf("error converting ClusterExtension: %w", err)
+	}
+	vars := map[string]any{ObjectVariable: object, BundleVariable: bundle}
+
+	var violations []Violation
+	for _, cp := range p.policies {
+		if !cp.selector.Matches(labels.Set(namespaceLabels)) {
+			continue
+		}
+		for _, v := range cp.validations {
+			if v.usesBundle && bundle == nil {
+				continue
+			}
+			out, _, err := v.program.Eval(vars)
+			switch {
+			case err != nil:
+				violations = append(violations, Violation{Policy: cp.name, Message: fmt.Sprintf("%s (error evaluating expression: %v)", v.message, err)})
+			case out != types.True:
+				violations = append(violations, Violation{Policy: cp.name, Message: v.message})
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &ViolationError{Violations: violations}
+	}
+	return nil
+}
+
+// Bundle returns the value of the bundle variable for the given bundle with the given
+// version, resolved from the given catalog.
+func Bundle(bundle declcfg.Bundle, version string, catalog string) (map[string]any, error) {
+	props, err := property.Parse(bundle.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing properties of bundle %q: %w", bundle.Name, err)
+	}
+
+	properties := make([]any, 0, len(bundle.Properties))
+	for _, p := range bundle.Properties {
+		var value any
+		if err := json.Unmarshal(p.Value, &value); err != nil {
+			return nil, fmt.Errorf("error parsing property %q of bundle %q: %w", p.Type, bundle.Name, err)
+		}
+		properties = append(properties, map[string]any{"type": p.Type, "value": value})
+	}
+	providedGVKs := make([]any, 0, len(props.GVKs))
+	for _, gvk := range props.GVKs {
+		providedGVKs = append(providedGVKs, map[string]any{"group": gvk.Group, "version": gvk.Version, "kind": gvk.Kind})
+	}
+	installModes := []any{}
+	for _, csv := range props.CSVMetadatas {
+		for _, im := range csv.InstallModes {
+			if im.Supported {
+				installModes = append(installModes, string(im.Type))
+			}
+		}
+	}
+
+	return map[string]any{
+		"name":         bundle.Name,
+		"package":      bundle.Package,
+		"version":      version,
+		"catalog":      catalog,
+		"properties":   properties,
+		"providedGVKs": providedGVKs,
+		"installModes": installModes,
+	}, nil
+}
+
+// Checker evaluates the ClusterExtensionInstallPolicies of the cluster.
+type Checker struct {
+	// Client lists the ClusterExtensionInstallPolicies, and gets the namespaces of
+	// ClusterExtensions.
+	Client client.Reader
+}
+
+// Check evaluates the ClusterExtensionInstallPolicies against the ClusterExtension
+// and the given bundle variable, as described by Policies.Evaluate. It returns a
+// *ViolationError when the ClusterExtension does not satisfy them.
+func (c *Checker) Check(ctx context.Context, ext *ocv1.ClusterExtension, bundle map[string]any) error {
+	var list ocv1.ClusterExtensionInstallPolicyList
+	if err := c.Client.List(ctx, &list); err != nil {
+		return fmt.Errorf("error listing install policies: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return nil
+	}
+	policies, err := Compile(list.Items)
+	if err != nil {
+		return err
+	}
+
+	// The namespace may not exist yet when the ClusterExtension is admitted. Its
+	// labels are empty until it is created.
+	var ns corev1.Namespace
+	if err := c.Client.Get(ctx, client.ObjectKey{Name: ext.Spec.Namespace}, &ns); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error getting namespace %q: %w", ext.Spec.Namespace, err)
+	}
+	return policies.Evaluate(ext, ns.Labels, bundle)
+}
+
+// IsViolation returns whether err reports install policy violations.
+func IsViolation(err error) bool {
+	var verr *ViolationError
+	return errors.As(err, &verr)
+}
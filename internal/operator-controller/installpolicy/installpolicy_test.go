@@ -0,0 +1,285 @@
+package installpolicy_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/installpolicy"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/scheme"
+)
+
+func installPolicy(name string, selector *metav1.LabelSelector, validations ...ocv1.InstallPolicyValidation) ocv1.ClusterExtensionInstallPolicy {
+	return ocv1.ClusterExtensionInstallPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ocv1.ClusterExtensionInstallPolicySpec{
+			NamespaceSelector: selector,
+			Validations:       validations,
+		},
+	}
+}
+
+func clusterExtension(packageName string, policy ocv1.UpgradeConstraintPolicy) *ocv1.ClusterExtension {
+	return &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: ocv1.ClusterExtensionSpec{
+			Namespace: "team-a",
+			Source: ocv1.SourceConfig{
+				SourceType: ocv1.SourceTypeCatalog,
+				Catalog: &ocv1.CatalogFilter{
+					PackageName:             packageName,
+					UpgradeConstraintPolicy: policy,
+				},
+			},
+		},
+	}
+}
+
+var (
+	noSelfCertified = ocv1.InstallPolicyValidation{
+		Expression: `!has(object.spec.source.catalog.upgradeConstraintPolicy) || object.spec.source.catalog.upgradeConstraintPolicy != 'SelfCertified'`,
+		Message:    "SelfCertified upgrades are not allowed",
+	}
+	onlyTrustedCatalog = ocv1.InstallPolicyValidation{
+		Expression: `bundle.catalog == 'trusted'`,
+		Message:    "bundles must be installed from the trusted catalog",
+	}
+	allowedPackages = ocv1.InstallPolicyValidation{
+		Expression: `object.spec.source.catalog.packageName in ['foo', 'bar']`,
+		Message:    "only foo and bar may be installed",
+	}
+)
+
+func TestCompile(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		validation ocv1.InstallPolicyValidation
+		selector   *metav1.LabelSelector
+		wantErr    string
+	}{
+		{
+			name:       "valid",
+			validation: noSelfCertified,
+		},
+		{
+			name:       "syntax error",
+			validation: ocv1.InstallPolicyValidation{Expression: "object.spec ==", Message: "invalid"},
+			wantErr:    `ClusterExtensionInstallPolicy "p" is invalid: spec.validations[0].expression: ERROR`,
+		},
+		{
+			name:       "undeclared variable",
+			validation: ocv1.InstallPolicyValidation{Expression: "request.user == 'admin'", Message: "invalid"},
+			wantErr:    "undeclared reference to 'request'",
+		},
+		{
+			name:       "not a bool",
+			validation: ocv1.InstallPolicyValidation{Expression: "'foo'", Message: "invalid"},
+			wantErr:    "spec.validations[0].expression: must evaluate to a bool, not string",
+		},
+		{
+			name:       "invalid namespace selector",
+			validation: noSelfCertified,
+			selector:   &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}}},
+			wantErr:    "spec.namespaceSelector:",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := installpolicy.Compile([]ocv1.ClusterExtensionInstallPolicy{installPolicy("p", tc.selector, tc.validation)})
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	teamA := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	for _, tc := range []struct {
+		name            string
+		policies        []ocv1.ClusterExtensionInstallPolicy
+		ext             *ocv1.ClusterExtension
+		namespaceLabels map[string]string
+		bundle          map[string]any
+		want            []installpolicy.Violation
+	}{
+		{
+			name:     "satisfied",
+			policies: []ocv1.ClusterExtensionInstallPolicy{installPolicy("p", nil, noSelfCertified, allowedPackages)},
+			ext:      clusterExtension("foo", ocv1.UpgradeConstraintPolicyCatalogProvided),
+		},
+		{
+			name: "violated",
+			policies: []ocv1.ClusterExtensionInstallPolicy{
+				installPolicy("p", nil, noSelfCertified),
+				installPolicy("q", nil, allowedPackages),
+			},
+			ext: clusterExtension("baz", ocv1.UpgradeConstraintPolicySelfCertified),
+			want: []installpolicy.Violation{
+				{Policy: "p", Message: "SelfCertified upgrades are not allowed"},
+				{Policy: "q", Message: "only foo and bar may be installed"},
+			},
+		},
+		{
+			name:            "namespace selector matches",
+			policies:        []ocv1.ClusterExtensionInstallPolicy{installPolicy("p", teamA, allowedPackages)},
+			ext:             clusterExtension("baz", ""),
+			namespaceLabels: map[string]string{"team": "a"},
+			want:            []installpolicy.Violation{{Policy: "p", Message: "only foo and bar may be installed"}},
+		},
+		{
+			name:            "namespace selector does not match",
+			policies:        []ocv1.ClusterExtensionInstallPolicy{installPolicy("p", teamA, allowedPackages)},
+			ext:             clusterExtension("baz", ""),
+			namespaceLabels: map[string]string{"team": "b"},
+		},
+		{
+			name:     "bundle validation skipped without bundle",
+			policies: []ocv1.ClusterExtensionInstallPolicy{installPolicy("p", nil, onlyTrustedCatalog)},
+			ext:      clusterExtension("foo", ""),
+		},
+		{
+			name:     "bundle validation satisfied",
+			policies: []ocv1.ClusterExtensionInstallPolicy{installPolicy("p", nil, onlyTrustedCatalog)},
+			ext:      clusterExtension("foo", ""),
+			bundle:   map[string]any{"catalog": "trusted"},
+		},
+		{
+			name:     "bundle validation violated",
+			policies: []ocv1.ClusterExtensionInstallPolicy{installPolicy("p", nil, onlyTrustedCatalog)},
+			ext:      clusterExtension("foo", ""),
+			bundle:   map[string]any{"catalog": "community"},
+			want:     []installpolicy.Violation{{Policy: "p", Message: "bundles must be installed from the trusted catalog"}},
+		},
+		{
+			name: "evaluation error",
+			policies: []ocv1.ClusterExtensionInstallPolicy{installPolicy("p", nil, ocv1.InstallPolicyValidation{
+				Expression: "object.spec.install.preflight.crdUpgradeSafety.enforcement == 'Strict'",
+				Message:    "CRD upgrade safety checks must be enforced",
+			})},
+			ext:  clusterExtension("foo", ""),
+			want: []installpolicy.Violation{{Policy: "p", Message: "CRD upgrade safety checks must be enforced (error evaluating expression: no such key: install)"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			policies, err := installpolicy.Compile(tc.policies)
+			require.NoError(t, err)
+
+			err = policies.Evaluate(tc.ext, tc.namespaceLabels, tc.bundle)
+			if tc.want == nil {
+				require.NoError(t, err)
+				return
+			}
+			var verr *installpolicy.ViolationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tc.want, verr.Violations)
+			assert.True(t, installpolicy.IsViolation(err))
+		})
+	}
+}
+
+func TestBundle(t *testing.T) {
+	csvMetadata, err := json.Marshal(map[string]any{
+		"installModes": []map[string]any{
+			{"type": "OwnNamespace", "supported": true},
+			{"type": "AllNamespaces", "supported": true},
+			{"type": "MultiNamespace", "supported": false},
+		},
+	})
+	require.NoError(t, err)
+	bundle := declcfg.Bundle{
+		Name:    "foo.v1.0.0",
+		Package: "foo",
+		Properties: []property.Property{
+			property.MustBuildPackage("foo", "1.0.0"),
+			property.MustBuildGVK("example.com", "v1", "Foo"),
+			{Type: property.TypeCSVMetadata, Value: csvMetadata},
+		},
+	}
+
+	got, err := installpolicy.Bundle(bundle, "1.0.0", "trusted")
+	require.NoError(t, err)
+	assert.Equal(t, "foo.v1.0.0", got["name"])
+	assert.Equal(t, "foo", got["package"])
+	assert.Equal(t, "1.0.0", got["version"])
+	assert.Equal(t, "trusted", got["catalog"])
+	assert.Equal(t, []any{map[string]any{"group": "example.com", "version": "v1", "kind": "Foo"}}, got["providedGVKs"])
+	assert.Equal(t, []any{"OwnNamespace", "AllNamespaces"}, got["installModes"])
+	assert.Len(t, got["properties"], 3)
+
+	policies, err := installpolicy.Compile([]ocv1.ClusterExtensionInstallPolicy{installPolicy("p", nil,
+		ocv1.InstallPolicyValidation{
+			Expression: `'AllNamespaces' in bundle.installModes && bundle.providedGVKs.all(gvk, gvk.group == 'example.com')`,
+			Message:    "bundles must support AllNamespaces and only provide example.com APIs",
+		},
+		ocv1.InstallPolicyValidation{
+			Expression: `bundle.properties.exists(p, p.type == 'olm.package' && p.value.version == bundle.version)`,
+			Message:    "bundles must have a package property",
+		},
+	)})
+	require.NoError(t, err)
+	require.NoError(t, policies.Evaluate(clusterExtension("foo", ""), nil, got))
+}
+
+func TestChecker(t *testing.T) {
+	onlyFoo := ocv1.InstallPolicyValidation{
+		Expression: `object.spec.source.catalog.packageName == 'foo'`,
+		Message:    "only foo may be installed",
+	}
+	policy := installPolicy("team-a", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}, onlyFoo)
+	invalid := installPolicy("invalid", nil, ocv1.InstallPolicyValidation{Expression: "object.spec ==", Message: "invalid"})
+
+	for _, tc := range []struct {
+		name    string
+		objects []ocv1.ClusterExtensionInstallPolicy
+		labels  map[string]string
+		wantErr string
+	}{
+		{
+			name: "no policies",
+		},
+		{
+			name:    "violated in labelled namespace",
+			objects: []ocv1.ClusterExtensionInstallPolicy{policy},
+			labels:  map[string]string{"team": "a"},
+			wantErr: `violates ClusterExtensionInstallPolicy "team-a": only foo may be installed`,
+		},
+		{
+			name:    "not applied in other namespace",
+			objects: []ocv1.ClusterExtensionInstallPolicy{policy},
+			labels:  map[string]string{"team": "b"},
+		},
+		{
+			name:    "invalid policy",
+			objects: []ocv1.ClusterExtensionInstallPolicy{invalid},
+			wantErr: `ClusterExtensionInstallPolicy "invalid" is invalid`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: tc.labels},
+			})
+			for i := range tc.objects {
+				builder = builder.WithObjects(&tc.objects[i])
+			}
+			checker := &installpolicy.Checker{Client: builder.Build()}
+
+			err := checker.Check(t.Context(), clusterExtension("bar", ""), nil)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
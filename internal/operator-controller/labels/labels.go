@@ -41,6 +41,15 @@ const (
 	// ClusterObjectSet.
 	BundleReferenceKey = "olm.operatorframework.io/bundle-reference"
 
+	// CatalogNameKey is the storage key used to record the name of the
+	// ClusterCatalog, or the key of the namespaced Catalog, that provided
+	// the bundle, in the chart annotations of Helm releases and in
+	// ClusterObjectSet annotations. Unlike the other storage keys, it is not
+	// a Helm release label, as the key of a namespaced Catalog may exceed
+	// the length of label values. It is only set for bundles resolved from
+	// a catalog.
+	CatalogNameKey = "olm.operatorframework.io/catalog-name"
+
 	// ServiceAccountNameKey is the annotation key used to record the name of
 	// the ServiceAccount configured on the owning ClusterExtension. It is
 	// applied as an annotation on ClusterObjectSet resources to
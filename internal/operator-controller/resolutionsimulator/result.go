@@ -0,0 +1,93 @@
+package resolutionsimulator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+)
+
+// Result is the outcome of a simulated resolution.
+type Result struct {
+	// Bundle is the resolved bundle, unless the resolution failed.
+	Bundle *Bundle `json:"bundle,omitempty"`
+	// Error is the error of the resolution, when it failed.
+	Error string `json:"error,omitempty"`
+	// Deprecations are the deprecations of the package, channels and bundles that
+	// the ClusterExtension reports in its deprecation conditions.
+	Deprecations []Deprecation `json:"deprecations,omitempty"`
+	// Packages are the statistics of the catalogs walked to resolve each package,
+	// including the bundles eliminated from each of them.
+	Packages []PackageStats `json:"packages"`
+}
+
+// Bundle is a resolved bundle.
+type Bundle struct {
+	ocv1.BundleMetadata `json:",inline"`
+	Package             string `json:"package"`
+	Image               string `json:"image"`
+	Catalog             string `json:"catalog"`
+}
+
+// Deprecation is a deprecation entry of the package of the resolved bundle.
+type Deprecation struct {
+	// Schema is the schema of the deprecated object: olm.package, olm.channel or olm.bundle.
+	Schema string `json:"schema"`
+	// Name is the name of the deprecated channel or bundle.
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+// PackageStats are the statistics of the catalogs walked to resolve a package.
+type PackageStats struct {
+	Package  string                 `json:"package"`
+	Catalogs []resolve.CatalogStats `json:"catalogs"`
+}
+
+// WriteText writes the result in a human-readable form.
+func (r *Result) WriteText(w io.Writer) error {
+	var sb strings.Builder
+	if r.Bundle != nil {
+		fmt.Fprintf(&sb, "Resolved bundle %q, version %s", r.Bundle.Name, r.Bundle.Version)
+		if r.Bundle.Release != nil {
+			fmt.Fprintf(&sb, ", release %s", *r.Bundle.Release)
+		}
+		fmt.Fprintf(&sb, ", of package %q from catalog %q\n", r.Bundle.Package, r.Bundle.Catalog)
+		fmt.Fprintf(&sb, "  image: %s\n", r.Bundle.Image)
+	} else {
+		fmt.Fprintf(&sb, "Resolution failed: %s\n", r.Error)
+	}
+
+	if len(r.Deprecations) > 0 {
+		sb.WriteString("\nDeprecations:\n")
+		for _, d := range r.Deprecations {
+			if d.Name == "" {
+				fmt.Fprintf(&sb, "  %s: %s\n", d.Schema, d.Message)
+			} else {
+				fmt.Fprintf(&sb, "  %s %q: %s\n", d.Schema, d.Name, d.Message)
+			}
+		}
+	}
+
+	for _, p := range r.Packages {
+		fmt.Fprintf(&sb, "\nCandidates of package %q:\n", p.Package)
+		for _, cs := range p.Catalogs {
+			if !cs.PackageFound {
+				fmt.Fprintf(&sb, "  catalog %q: package not found\n", cs.CatalogName)
+				continue
+			}
+			fmt.Fprintf(&sb, "  catalog %q: %d bundles, %d matched, %d denied\n", cs.CatalogName, cs.TotalBundles, cs.MatchedBundles, cs.DeniedBundles)
+			for _, e := range cs.Eliminated {
+				fmt.Fprintf(&sb, "    eliminated, %s: %s\n", e.Reason, strings.Join(e.Bundles, ", "))
+			}
+			if cs.Candidate != "" {
+				fmt.Fprintf(&sb, "    candidate: %s\n", cs.Candidate)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
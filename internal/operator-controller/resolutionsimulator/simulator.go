@@ -0,0 +1,310 @@
+// Package resolutionsimulator runs the resolution of a ClusterExtension offline,
+// against catalogs loaded from local directories or catalog images, and reports
+// how the candidate bundles were eliminated.
+package resolutionsimulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"go.podman.io/image/v5/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogmirror"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+)
+
+// catalogNameLabel is the label that catalogd sets to the name of each ClusterCatalog,
+// which ClusterExtensions commonly select catalogs by.
+const catalogNameLabel = "olm.operatorframework.io/metadata.name"
+
+// Objects are the objects that the resolution of a ClusterExtension depends on.
+type Objects struct {
+	ClusterExtension   *ocv1.ClusterExtension
+	ClusterCatalogs    []ocv1.ClusterCatalog
+	BundleDenyPolicies []ocv1.ClusterBundleDenyPolicy
+}
+
+// LoadObjects decodes a stream of YAML or JSON documents holding exactly one
+// ClusterExtension, and any number of ClusterCatalogs and ClusterBundleDenyPolicies.
+func LoadObjects(r io.Reader) (*Objects, error) {
+	objs := &Objects{}
+	decoder := apimachyaml.NewYAMLOrJSONDecoder(r, 1024)
+	for i := 0; ; i++ {
+		var uObj unstructured.Unstructured
+		err := decoder.Decode(&uObj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding object %d: %w", i, err)
+		}
+		if len(uObj.Object) == 0 {
+			continue
+		}
+
+		gvk := uObj.GroupVersionKind()
+		if gvk.GroupVersion() != ocv1.GroupVersion {
+			return nil, fmt.Errorf("unsupported object %d of kind %q", i, gvk.String())
+		}
+		switch gvk.Kind {
+		case ocv1.ClusterExtensionKind:
+			if objs.ClusterExtension != nil {
+				return nil, errors.New("more than one ClusterExtension found")
+			}
+			objs.ClusterExtension = &ocv1.ClusterExtension{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(uObj.Object, objs.ClusterExtension)
+		case "ClusterCatalog":
+			var cat ocv1.ClusterCatalog
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(uObj.Object, &cat)
+			objs.ClusterCatalogs = append(objs.ClusterCatalogs, cat)
+		case "ClusterBundleDenyPolicy":
+			var policy ocv1.ClusterBundleDenyPolicy
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(uObj.Object, &policy)
+			objs.BundleDenyPolicies = append(objs.BundleDenyPolicies, policy)
+		default:
+			return nil, fmt.Errorf("unsupported object %d of kind %q", i, gvk.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s %q: %w", gvk.Kind, uObj.GetName(), err)
+		}
+	}
+	if objs.ClusterExtension == nil {
+		return nil, errors.New("no ClusterExtension found")
+	}
+	return objs, nil
+}
+
+// Options configure a simulated resolution.
+type Options struct {
+	Objects
+	// CatalogSources map the names of catalogs to the directories of their FBC, or to
+	// their catalog images. Catalogs without a ClusterCatalog are resolved from with
+	// priority 0. ClusterCatalogs without a source are pulled from their image.
+	CatalogSources map[string]string
+	// InstalledBundle is the bundle installed for the ClusterExtension. When nil, the
+	// installed bundle of the status of the ClusterExtension is used, if any.
+	InstalledBundle *ocv1.BundleMetadata
+	// InstalledCatalog is the catalog that provided InstalledBundle.
+	InstalledCatalog string
+	// DeprecationMergeStrategy defines how the deprecations of a package provided by
+	// multiple catalogs are merged.
+	DeprecationMergeStrategy resolve.DeprecationMergeStrategy
+	// SourceCtx configures the pulls of catalog images.
+	SourceCtx *types.SystemContext
+}
+
+// Run loads the catalogs of opts, and resolves the bundle of its ClusterExtension.
+// A failed resolution is reported in the Error of the result; the returned error
+// reports failures to run the resolution.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	catalogs, err := loadCatalogs(ctx, opts.ClusterCatalogs, opts.CatalogSources, opts.SourceCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := opts.ClusterExtension
+	installedBundle, installedCatalog := opts.InstalledBundle, opts.InstalledCatalog
+	if installedBundle == nil && ext.Status.Install != nil {
+		installedBundle, installedCatalog = &ext.Status.Install.Bundle, ext.Status.Install.Catalog
+	}
+
+	result := &Result{}
+	resolver := &resolve.CatalogResolver{
+		WalkCatalogsFunc:        resolve.CatalogWalker(catalogs.list, catalogs.getPackage),
+		WalkCatalogPackagesFunc: resolve.CatalogPackagesWalker(catalogs.list, catalogs.listPackages),
+		Validations: []resolve.ValidationFunc{
+			resolve.NoDependencyValidation,
+		},
+		DeprecationMergeStrategy: opts.DeprecationMergeStrategy,
+		ReportStatsFunc: func(_ context.Context, packageName string, stats []resolve.CatalogStats) {
+			result.Packages = append(result.Packages, PackageStats{Package: packageName, Catalogs: stats})
+		},
+	}
+	if len(opts.BundleDenyPolicies) > 0 {
+		resolver.ListBundleDenyPoliciesFunc = func(context.Context) ([]ocv1.ClusterBundleDenyPolicy, error) {
+			return opts.BundleDenyPolicies, nil
+		}
+	}
+
+	bundle, vr, deprecation, catalog, err := resolver.Resolve(ctx, ext, installedBundle, installedCatalog)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Bundle = &Bundle{
+		BundleMetadata: bundleutil.MetadataFor(bundle.Name, *vr),
+		Package:        bundle.Package,
+		Image:          bundle.Image,
+		Catalog:        catalog,
+	}
+	result.Deprecations = deprecationsOf(ext, deprecation, bundle.Name, installedBundle)
+	return result, nil
+}
+
+// deprecationsOf returns the entries of deprecation that apply to the ClusterExtension,
+// as reported by its deprecation conditions, and those of the resolved bundle.
+func deprecationsOf(ext *ocv1.ClusterExtension, deprecation *declcfg.Deprecation, resolvedBundle string, installedBundle *ocv1.BundleMetadata) []Deprecation {
+	bundleNames := []string{resolvedBundle}
+	if installedBundle != nil {
+		bundleNames = append(bundleNames, installedBundle.Name)
+	}
+	entries := resolve.ApplicableDeprecationEntries(ext, deprecation, bundleNames...)
+
+	var deprecations []Deprecation
+	for _, entry := range slices.Concat(entries.Package, entries.Channel, entries.Bundle) {
+		deprecations = append(deprecations, Deprecation{Schema: entry.Reference.Schema, Name: entry.Reference.Name, Message: entry.Message})
+	}
+	return deprecations
+}
+
+// catalog is a catalog with its FBC, indexed by package.
+type catalog struct {
+	ocv1.ClusterCatalog
+	packages map[string]*declcfg.DeclarativeConfig
+}
+
+type catalogs []catalog
+
+// loadCatalogs loads the FBC of the ClusterCatalogs and of the catalogs of sources,
+// which take precedence over the images of the ClusterCatalogs.
+func loadCatalogs(ctx context.Context, clusterCatalogs []ocv1.ClusterCatalog, sources map[string]string, srcCtx *types.SystemContext) (catalogs, error) {
+	clusterCatalogs = slices.Clone(clusterCatalogs)
+	for name := range sources {
+		if !slices.ContainsFunc(clusterCatalogs, func(c ocv1.ClusterCatalog) bool { return c.Name == name }) {
+			cat := ocv1.ClusterCatalog{}
+			cat.SetName(name)
+			clusterCatalogs = append(clusterCatalogs, cat)
+		}
+	}
+	if len(clusterCatalogs) == 0 {
+		return nil, errors.New("no catalogs to resolve from")
+	}
+	slices.SortFunc(clusterCatalogs, func(a, b ocv1.ClusterCatalog) int { return strings.Compare(a.Name, b.Name) })
+
+	cats := make(catalogs, 0, len(clusterCatalogs))
+	for _, cc := range clusterCatalogs {
+		// catalogd labels every ClusterCatalog with its name.
+		labels := cc.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[catalogNameLabel] = cc.Name
+		cc.SetLabels(labels)
+
+		fbc, err := loadFBC(ctx, cc, sources[cc.Name], srcCtx)
+		if err != nil {
+			return nil, fmt.Errorf("error loading catalog %q: %w", cc.Name, err)
+		}
+		cats = append(cats, catalog{ClusterCatalog: cc, packages: splitPackages(fbc)})
+	}
+	return cats, nil
+}
+
+// loadFBC loads the FBC of a catalog from source, a directory or a catalog image,
+// or from the image of the ClusterCatalog when source is empty.
+func loadFBC(ctx context.Context, cc ocv1.ClusterCatalog, source string, srcCtx *types.SystemContext) (*declcfg.DeclarativeConfig, error) {
+	if source == "" {
+		if cc.Spec.Source.Image == nil || cc.Spec.Source.Image.Ref == "" {
+			return nil, errors.New("no catalog source, and no image in the ClusterCatalog")
+		}
+		source = cc.Spec.Source.Image.Ref
+	}
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return declcfg.LoadFS(ctx, os.DirFS(source))
+	}
+	return catalogmirror.LoadCatalog(ctx, source, srcCtx)
+}
+
+// splitPackages indexes the FBC of a catalog by package.
+func splitPackages(fbc *declcfg.DeclarativeConfig) map[string]*declcfg.DeclarativeConfig {
+	packages := map[string]*declcfg.DeclarativeConfig{}
+	pkg := func(name string) *declcfg.DeclarativeConfig {
+		if packages[name] == nil {
+			packages[name] = &declcfg.DeclarativeConfig{}
+		}
+		return packages[name]
+	}
+	for _, p := range fbc.Packages {
+		pkg(p.Name).Packages = append(pkg(p.Name).Packages, p)
+	}
+	for _, c := range fbc.Channels {
+		pkg(c.Package).Channels = append(pkg(c.Package).Channels, c)
+	}
+	for _, b := range fbc.Bundles {
+		pkg(b.Package).Bundles = append(pkg(b.Package).Bundles, b)
+	}
+	for _, d := range fbc.Deprecations {
+		pkg(d.Package).Deprecations = append(pkg(d.Package).Deprecations, d)
+	}
+	return packages
+}
+
+// list returns the catalogs selected by the label selector of opts. Namespaced
+// Catalogs are not supported.
+func (cs catalogs) list(_ context.Context, opts ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	selector := listOpts.LabelSelector
+	if selector == nil {
+		selector = k8slabels.Everything()
+	}
+	var selected []ocv1.ClusterCatalog
+	for _, c := range cs {
+		if selector.Matches(k8slabels.Set(c.GetLabels())) {
+			selected = append(selected, c.ClusterCatalog)
+		}
+	}
+	return selected, nil
+}
+
+// getPackage returns a copy of the FBC of a package of a catalog, which the
+// resolver may filter in place.
+func (cs catalogs) getPackage(_ context.Context, cat *ocv1.ClusterCatalog, pkgName string) (*declcfg.DeclarativeConfig, error) {
+	i := slices.IndexFunc(cs, func(c catalog) bool { return c.Name == cat.Name })
+	if i < 0 {
+		return nil, fmt.Errorf("catalog %q not found", cat.Name)
+	}
+	fbc, ok := cs[i].packages[pkgName]
+	if !ok {
+		return &declcfg.DeclarativeConfig{}, nil
+	}
+	return &declcfg.DeclarativeConfig{
+		Packages:     slices.Clone(fbc.Packages),
+		Channels:     slices.Clone(fbc.Channels),
+		Bundles:      slices.Clone(fbc.Bundles),
+		Deprecations: slices.Clone(fbc.Deprecations),
+	}, nil
+}
+
+// listPackages returns the names of the packages of a catalog with bundles that
+// provide gvk.
+func (cs catalogs) listPackages(_ context.Context, cat *ocv1.ClusterCatalog, gvk property.GVK) ([]string, error) {
+	i := slices.IndexFunc(cs, func(c catalog) bool { return c.Name == cat.Name })
+	if i < 0 {
+		return nil, fmt.Errorf("catalog %q not found", cat.Name)
+	}
+	var names []string
+	for name, fbc := range cs[i].packages {
+		if slices.ContainsFunc(fbc.Bundles, filter.ProvidesGVK(gvk)) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
@@ -0,0 +1,211 @@
+package resolutionsimulator_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolutionsimulator"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+)
+
+const objectsYAML = `
+apiVersion: olm.operatorframework.io/v1
+kind: ClusterExtension
+metadata:
+  name: argocd
+spec:
+  namespace: argocd
+  serviceAccount:
+    name: argocd-installer
+  source:
+    sourceType: Catalog
+    catalog:
+      packageName: argocd
+      channels: [stable]
+---
+apiVersion: olm.operatorframework.io/v1
+kind: ClusterCatalog
+metadata:
+  name: community
+spec:
+  priority: 10
+  source:
+    type: Image
+    image:
+      ref: quay.io/example/community-catalog:latest
+---
+apiVersion: olm.operatorframework.io/v1
+kind: ClusterBundleDenyPolicy
+metadata:
+  name: deny-argocd
+spec:
+  entries:
+  - packageName: argocd
+    versionRange: "1.0.0"
+    reason: CVE-2026-0001
+`
+
+func bundle(version string) declcfg.Bundle {
+	return declcfg.Bundle{
+		Package:    "argocd",
+		Name:       "argocd.v" + version,
+		Image:      "quay.io/example/argocd-bundle:v" + version,
+		Properties: []property.Property{property.MustBuildPackage("argocd", version)},
+	}
+}
+
+// writeCatalog writes the FBC of the argocd package to a directory, with the
+// bundles of versions in the stable channel.
+func writeCatalog(t *testing.T, versions ...string) string {
+	fbc := declcfg.DeclarativeConfig{
+		Packages: []declcfg.Package{{Schema: declcfg.SchemaPackage, Name: "argocd"}},
+		Deprecations: []declcfg.Deprecation{{
+			Schema:  declcfg.SchemaDeprecation,
+			Package: "argocd",
+			Entries: []declcfg.DeprecationEntry{
+				{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaChannel, Name: "stable"}, Message: "use the fast channel"},
+				{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaChannel, Name: "fast"}, Message: "fast is not deprecated"},
+				{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaBundle, Name: "argocd.v0.9.0"}, Message: "argocd.v0.9.0 is deprecated"},
+			},
+		}},
+	}
+	stable := declcfg.Channel{Schema: declcfg.SchemaChannel, Package: "argocd", Name: "stable"}
+	for _, v := range versions {
+		b := bundle(v)
+		b.Schema = declcfg.SchemaBundle
+		fbc.Bundles = append(fbc.Bundles, b)
+		stable.Entries = append(stable.Entries, declcfg.ChannelEntry{Name: b.Name, SkipRange: "<" + v})
+	}
+	fbc.Channels = append(fbc.Channels, stable)
+
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	require.NoError(t, declcfg.WriteJSON(fbc, &buf))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "catalog.json"), buf.Bytes(), 0600))
+	return dir
+}
+
+func TestLoadObjects(t *testing.T) {
+	objs, err := resolutionsimulator.LoadObjects(strings.NewReader(objectsYAML))
+	require.NoError(t, err)
+	require.Equal(t, "argocd", objs.ClusterExtension.Name)
+	require.Equal(t, "argocd", objs.ClusterExtension.Spec.Source.Catalog.PackageName)
+	require.Len(t, objs.ClusterCatalogs, 1)
+	require.Equal(t, int32(10), objs.ClusterCatalogs[0].Spec.Priority)
+	require.Len(t, objs.BundleDenyPolicies, 1)
+	require.Equal(t, "deny-argocd", objs.BundleDenyPolicies[0].Name)
+}
+
+func TestLoadObjectsErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		objects string
+		wantErr string
+	}{
+		{
+			name:    "no ClusterExtension",
+			objects: "apiVersion: olm.operatorframework.io/v1\nkind: ClusterCatalog\nmetadata:\n  name: community\n",
+			wantErr: "no ClusterExtension found",
+		},
+		{
+			name:    "more than one ClusterExtension",
+			objects: objectsYAML + "---\napiVersion: olm.operatorframework.io/v1\nkind: ClusterExtension\nmetadata:\n  name: other\n",
+			wantErr: "more than one ClusterExtension found",
+		},
+		{
+			name:    "unsupported kind",
+			objects: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n",
+			wantErr: `unsupported object 0 of kind "/v1, Kind=ConfigMap"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolutionsimulator.LoadObjects(strings.NewReader(tc.objects))
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	objs, err := resolutionsimulator.LoadObjects(strings.NewReader(objectsYAML))
+	require.NoError(t, err)
+
+	result, err := resolutionsimulator.Run(context.Background(), resolutionsimulator.Options{
+		Objects: *objs,
+		CatalogSources: map[string]string{
+			"community": writeCatalog(t, "0.9.0", "1.0.0", "1.1.0"),
+			"internal":  writeCatalog(t, "1.2.0"),
+		},
+		InstalledBundle:          &ocv1.BundleMetadata{Name: "argocd.v0.9.0", Version: "0.9.0"},
+		InstalledCatalog:         "community",
+		DeprecationMergeStrategy: resolve.DeprecationMergeHighestPriority,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &resolutionsimulator.Bundle{
+		BundleMetadata: ocv1.BundleMetadata{Name: "argocd.v1.1.0", Version: "1.1.0"},
+		Package:        "argocd",
+		Image:          "quay.io/example/argocd-bundle:v1.1.0",
+		Catalog:        "community",
+	}, result.Bundle)
+
+	var out bytes.Buffer
+	require.NoError(t, result.WriteText(&out))
+	require.Equal(t, `Resolved bundle "argocd.v1.1.0", version 1.1.0, of package "argocd" from catalog "community"
+  image: quay.io/example/argocd-bundle:v1.1.0
+
+Deprecations:
+  olm.channel "stable": use the fast channel (reported by catalog "community")
+  olm.bundle "argocd.v0.9.0": argocd.v0.9.0 is deprecated (reported by catalog "community")
+
+Candidates of package "argocd":
+  catalog "community": 3 bundles, 2 matched, 1 denied
+    eliminated, denied by ClusterBundleDenyPolicies: argocd.v1.0.0
+    eliminated, ranked below "argocd.v1.1.0": argocd.v0.9.0
+    candidate: argocd.v1.1.0
+  catalog "internal": 1 bundles, 1 matched, 0 denied
+    eliminated, not from catalog "community" of the installed bundle: argocd.v1.2.0
+    candidate: argocd.v1.2.0
+`, out.String())
+}
+
+func TestRunResolutionFailed(t *testing.T) {
+	ext := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd"},
+		Spec: ocv1.ClusterExtensionSpec{
+			Source: ocv1.SourceConfig{
+				SourceType: ocv1.SourceTypeCatalog,
+				Catalog:    &ocv1.CatalogFilter{PackageName: "argocd", Version: ">=2.0.0"},
+			},
+		},
+	}
+	result, err := resolutionsimulator.Run(context.Background(), resolutionsimulator.Options{
+		Objects:        resolutionsimulator.Objects{ClusterExtension: ext},
+		CatalogSources: map[string]string{"community": writeCatalog(t, "1.0.0")},
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.Bundle)
+	require.Contains(t, result.Error, `no bundles found for package "argocd" matching version ">=2.0.0"`)
+	require.Len(t, result.Packages, 1)
+	require.Equal(t, []resolve.Elimination{
+		{Reason: `not matching version ">=2.0.0"`, Bundles: []string{"argocd.v1.0.0"}},
+	}, result.Packages[0].Catalogs[0].Eliminated)
+}
+
+func TestRunNoCatalogSource(t *testing.T) {
+	objs, err := resolutionsimulator.LoadObjects(strings.NewReader(objectsYAML))
+	require.NoError(t, err)
+	objs.ClusterCatalogs[0].Spec.Source.Image = nil
+
+	_, err = resolutionsimulator.Run(context.Background(), resolutionsimulator.Options{Objects: *objs})
+	require.EqualError(t, err, `error loading catalog "community": no catalog source, and no image in the ClusterCatalog`)
+}
@@ -3,6 +3,7 @@ package resolve
 import (
 	"context"
 	"fmt"
+	"math"
 	"slices"
 	"sort"
 	"strings"
@@ -15,11 +16,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/compare"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
 	filterutil "github.com/operator-framework/operator-controller/internal/shared/util/filter"
 	slicesutil "github.com/operator-framework/operator-controller/internal/shared/util/slices"
 )
@@ -29,6 +32,18 @@ type ValidationFunc func(*declcfg.Bundle) error
 type CatalogResolver struct {
 	WalkCatalogsFunc func(context.Context, string, CatalogWalkFunc, ...client.ListOption) error
 	Validations      []ValidationFunc
+	// DeprecationMergeStrategy defines how the deprecations of a package provided
+	// by multiple catalogs are merged. It defaults to DeprecationMergeHighestPriority.
+	DeprecationMergeStrategy DeprecationMergeStrategy
+	// ListBundleDenyPoliciesFunc lists the ClusterBundleDenyPolicies whose denied
+	// bundles are excluded from resolution. When nil, no bundles are denied.
+	ListBundleDenyPoliciesFunc func(context.Context) ([]ocv1.ClusterBundleDenyPolicy, error)
+	// WalkCatalogPackagesFunc walks the packages of the catalogs that provide an API, to
+	// resolve ClusterExtensions whose source is a provided API. When nil, they cannot be resolved.
+	WalkCatalogPackagesFunc func(context.Context, property.GVK, CatalogPackagesWalkFunc, ...client.ListOption) error
+	// ReportStatsFunc, when set, is called with the statistics of the catalogs walked
+	// to resolve each package, including the bundles eliminated from each of them.
+	ReportStatsFunc func(ctx context.Context, packageName string, stats []CatalogStats)
 }
 
 type foundBundle struct {
@@ -38,87 +53,323 @@ type foundBundle struct {
 }
 
 // Resolve returns a Bundle from a catalog that needs to get installed on the cluster.
-func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
-	l := log.FromContext(ctx)
-	packageName := ext.Spec.Source.Catalog.PackageName
-	versionRange := ext.Spec.Source.Catalog.Version
-	channels := ext.Spec.Source.Catalog.Channels
+func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+	if ext.Spec.Source.SourceType == ocv1.SourceTypeProvidedAPI {
+		return r.resolveProvidedAPI(ctx, ext, installedBundle, installedCatalog)
+	}
 
-	// unless overridden, default to selecting all bundles
-	var selector = labels.Everything()
-	var err error
-	if ext.Spec.Source.Catalog != nil {
-		selector, err = metav1.LabelSelectorAsSelector(ext.Spec.Source.Catalog.Selector)
-		if err != nil {
-			return nil, nil, nil, fmt.Errorf("desired catalog selector is invalid: %w", err)
+	allowedPredicate, err := r.allowedPredicate(ctx, installedBundle)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	catalog := ext.Spec.Source.Catalog
+	resolved, err := r.resolvePackage(ctx, ext, packageQuery{
+		packageName:             catalog.PackageName,
+		versionRange:            catalog.Version,
+		releaseRange:            catalog.Release,
+		channels:                catalog.Channels,
+		selector:                catalog.Selector,
+		upgradeConstraintPolicy: catalog.UpgradeConstraintPolicy,
+		upgradeScope:            catalog.UpgradeScope,
+		catalogStickiness:       catalog.CatalogStickiness,
+	}, installedBundle, installedCatalog, allowedPredicate)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return r.validate(resolved)
+}
+
+// packageQuery selects the bundles of a package that may be resolved for a
+// ClusterExtension.
+type packageQuery struct {
+	packageName             string
+	versionRange            string
+	releaseRange            string
+	channels                []string
+	selector                *metav1.LabelSelector
+	upgradeConstraintPolicy ocv1.UpgradeConstraintPolicy
+	upgradeScope            ocv1.UpgradeScope
+	catalogStickiness       ocv1.CatalogStickiness
+	// predicates are additional predicates that the bundles must satisfy.
+	predicates []namedPredicate
+	// installedPackageOnly excludes the package from the catalogs that do not
+	// provide the installed bundle in it.
+	installedPackageOnly bool
+}
+
+// resolvedPackage is the bundle resolved from a package, with the deprecation
+// of the package merged from the catalogs that provide it.
+type resolvedPackage struct {
+	foundBundle
+	deprecation *declcfg.Deprecation
+}
+
+// allowedPredicate returns the predicate that excludes the bundles denied by
+// ClusterBundleDenyPolicies, or nil when no bundles are denied.
+func (r *CatalogResolver) allowedPredicate(ctx context.Context, installedBundle *ocv1.BundleMetadata) (filterutil.Predicate[declcfg.Bundle], error) {
+	if r.ListBundleDenyPoliciesFunc == nil {
+		return nil, nil
+	}
+	policies, err := r.ListBundleDenyPoliciesFunc(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing bundle deny policies: %w", err)
+	}
+	denials, err := NewBundleDenials(policies)
+	if err != nil {
+		return nil, fmt.Errorf("error applying bundle deny policies: %w", err)
+	}
+	return denials.Allowed(installedBundle), nil
+}
+
+// validate runs the validations against the resolved bundle, to ensure only valid
+// resolved bundles are being returned.
+func (r *CatalogResolver) validate(resolved *resolvedPackage) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+	resolvedBundle := resolved.bundle
+	resolvedBundleVersion, err := bundleutil.GetVersionAndRelease(*resolvedBundle)
+	if err != nil {
+		return nil, nil, nil, "", fmt.Errorf("error getting resolved bundle version for bundle %q: %w", resolvedBundle.Name, err)
+	}
+
+	// Open Question: Should we grab the first valid bundle earlier?
+	//        Answer: No, that would be a hidden resolution input, which we should avoid at all costs; the query can be
+	//                constrained in order to eliminate the invalid bundle from the resolution.
+	for _, validation := range r.Validations {
+		if err := validation(resolvedBundle); err != nil {
+			return nil, nil, nil, "", fmt.Errorf("validating bundle %q: %w", resolvedBundle.Name, err)
+		}
+	}
+	return resolvedBundle, resolvedBundleVersion, resolved.deprecation, resolved.catalog, nil
+}
+
+// CatalogStats describes the bundles of a package provided by a catalog, and how
+// they were eliminated while resolving the package.
+type CatalogStats struct {
+	CatalogName    string `json:"catalogName"`
+	PackageFound   bool   `json:"packageFound"`
+	TotalBundles   int    `json:"totalBundles"`
+	MatchedBundles int    `json:"matchedBundles"`
+	DeniedBundles  int    `json:"deniedBundles"`
+	// Eliminated lists the bundles eliminated by each step of the resolution, in order.
+	Eliminated []Elimination `json:"eliminated,omitempty"`
+	// Candidate is the bundle of the catalog that was ranked first, if any.
+	Candidate string `json:"candidate,omitempty"`
+
+	// recordEliminations is whether Eliminated and Candidate are recorded, which
+	// is only needed when the statistics are reported.
+	recordEliminations bool
+}
+
+// catalogCounts are the counts of the statistics of a catalog, which are logged
+// when resolving a package.
+type catalogCounts struct {
+	CatalogName    string `json:"catalogName"`
+	PackageFound   bool   `json:"packageFound"`
+	TotalBundles   int    `json:"totalBundles"`
+	MatchedBundles int    `json:"matchedBundles"`
+	DeniedBundles  int    `json:"deniedBundles"`
+}
+
+// countsOf returns the counts of the statistics of the catalogs.
+func countsOf(catStats []*CatalogStats) []catalogCounts {
+	return slicesutil.Map(catStats, func(cs *CatalogStats) catalogCounts {
+		return catalogCounts{
+			CatalogName:    cs.CatalogName,
+			PackageFound:   cs.PackageFound,
+			TotalBundles:   cs.TotalBundles,
+			MatchedBundles: cs.MatchedBundles,
+			DeniedBundles:  cs.DeniedBundles,
 		}
-		// A nothing (empty) selector selects everything
-		if selector == labels.Nothing() {
-			selector = labels.Everything()
+	})
+}
+
+// Elimination is a step of the resolution, and the bundles that it eliminated.
+type Elimination struct {
+	Reason  string   `json:"reason"`
+	Bundles []string `json:"bundles"`
+}
+
+// eliminate records bundles as eliminated for reason, when eliminations are recorded.
+func (cs *CatalogStats) eliminate(reason string, bundles ...string) {
+	if cs.recordEliminations && len(bundles) > 0 {
+		cs.Eliminated = append(cs.Eliminated, Elimination{Reason: reason, Bundles: bundles})
+	}
+}
+
+// filter removes the bundles that do not satisfy the predicate, and records them
+// as eliminated for reason, when eliminations are recorded.
+func (cs *CatalogStats) filter(bundles []declcfg.Bundle, p namedPredicate) []declcfg.Bundle {
+	if !cs.recordEliminations {
+		return filterutil.InPlace(bundles, p.predicate)
+	}
+	var eliminated []string
+	bundles = filterutil.InPlace(bundles, func(b declcfg.Bundle) bool {
+		if p.predicate(b) {
+			return true
 		}
+		eliminated = append(eliminated, b.Name)
+		return false
+	})
+	cs.eliminate(p.reason, eliminated...)
+	return bundles
+}
+
+// namedPredicate is a predicate on bundles, with the reason why the bundles
+// that do not satisfy it are eliminated.
+type namedPredicate struct {
+	reason    string
+	predicate filterutil.Predicate[declcfg.Bundle]
+}
+
+// versionReleaseReason is the reason why bundles that do not match the version
+// or release range are eliminated.
+func versionReleaseReason(versionRange, releaseRange string) string {
+	switch {
+	case releaseRange == "":
+		return fmt.Sprintf("not matching version %q", versionRange)
+	case versionRange == "":
+		return fmt.Sprintf("not matching release %q", releaseRange)
+	default:
+		return fmt.Sprintf("not matching version %q and release %q", versionRange, releaseRange)
+	}
+}
+
+// resolvePackage returns the bundle of a package that matches the query, from
+// the catalogs selected by the query.
+func (r *CatalogResolver) resolvePackage(ctx context.Context, ext *ocv1.ClusterExtension, q packageQuery, installedBundle *ocv1.BundleMetadata, installedCatalog string, allowedPredicate filterutil.Predicate[declcfg.Bundle]) (*resolvedPackage, error) {
+	l := log.FromContext(ctx)
+	packageName := q.packageName
+	versionRange := q.versionRange
+	releaseRange := q.releaseRange
+	channels := q.channels
+	upgradeScope := q.upgradeScope
+
+	// Upgrades stick to the catalog that provided the installed bundle, unless
+	// stickiness is disabled or that catalog is unknown.
+	var stickyCatalog string
+	stickiness := q.catalogStickiness
+	if installedBundle != nil && stickiness != ocv1.CatalogStickinessNone {
+		stickyCatalog = installedCatalog
+	}
+	var requiredCatalog string
+	if stickiness == ocv1.CatalogStickinessRequired {
+		requiredCatalog = stickyCatalog
+	}
+
+	// unless overridden, default to selecting all bundles
+	selector, err := metav1.LabelSelectorAsSelector(q.selector)
+	if err != nil {
+		return nil, fmt.Errorf("desired catalog selector is invalid: %w", err)
+	}
+	// A nothing (empty) selector selects everything
+	if selector == labels.Nothing() {
+		selector = labels.Everything()
 	}
 
 	var versionRangeConstraints bsemver.Range
 	if versionRange != "" {
 		versionRangeConstraints, err = compare.NewVersionRange(versionRange)
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("desired version range %q is invalid: %w", versionRange, err)
+			return nil, fmt.Errorf("desired version range %q is invalid: %w", versionRange, err)
+		}
+	}
+
+	var releaseRangeConstraints func(declcfg.Release) bool
+	if releaseRange != "" {
+		releaseRangeConstraints, err = compare.NewReleaseRange(releaseRange)
+		if err != nil {
+			return nil, fmt.Errorf("desired release range %q is invalid: %w", releaseRange, err)
 		}
 	}
 
-	type catStat struct {
-		CatalogName    string `json:"catalogName"`
-		PackageFound   bool   `json:"packageFound"`
-		TotalBundles   int    `json:"totalBundles"`
-		MatchedBundles int    `json:"matchedBundles"`
+	// The upgrade scope only restricts upgrades from an installed bundle.
+	var upgradeScopePredicate filterutil.Predicate[declcfg.Bundle]
+	if upgradeScope != "" && installedBundle != nil {
+		upgradeScopePredicate, err = filter.InUpgradeScope(*installedBundle, upgradeScope)
+		if err != nil {
+			return nil, fmt.Errorf("error applying upgrade scope: %w", err)
+		}
 	}
 
-	var catStats []*catStat
+	var catStats []*CatalogStats
 
 	var resolvedBundles []foundBundle
 	var priorDeprecation *declcfg.Deprecation
+	var catalogDeprecations []catalogDeprecation
+	var deniedBundles int
 
+	// The namespace of the ClusterExtension's service account limits which namespaced
+	// Catalogs are considered; ClusterCatalogs are always considered.
 	listOptions := []client.ListOption{
 		client.MatchingLabelsSelector{Selector: selector},
+		client.InNamespace(ext.Spec.Namespace),
 	}
 	if err := r.WalkCatalogsFunc(ctx, packageName, func(ctx context.Context, cat *ocv1.ClusterCatalog, packageFBC *declcfg.DeclarativeConfig, err error) error {
 		if err != nil {
 			return fmt.Errorf("error getting package %q from catalog %q: %w", packageName, cat.Name, err)
 		}
 
-		cs := catStat{CatalogName: cat.Name}
+		if requiredCatalog != "" && cat.Name != requiredCatalog {
+			return nil
+		}
+
+		cs := CatalogStats{CatalogName: cat.Name, recordEliminations: r.ReportStatsFunc != nil}
 		catStats = append(catStats, &cs)
 
 		if isFBCEmpty(packageFBC) {
 			return nil
 		}
+		if q.installedPackageOnly && installedBundle != nil && !providesBundle(packageFBC, installedBundle.Name) {
+			return nil
+		}
 
 		cs.PackageFound = true
 		cs.TotalBundles = len(packageFBC.Bundles)
 
-		var predicates []filterutil.Predicate[declcfg.Bundle]
+		cd := catalogDeprecation{catalog: cat.Name}
+		if len(packageFBC.Deprecations) > 0 {
+			cd.deprecation = &packageFBC.Deprecations[0]
+		}
+		catalogDeprecations = append(catalogDeprecations, cd)
+
+		predicates := slices.Clone(q.predicates)
 		if len(channels) > 0 {
 			channelSet := sets.New(channels...)
 			filteredChannels := slices.DeleteFunc(packageFBC.Channels, func(c declcfg.Channel) bool {
 				return !channelSet.Has(c.Name)
 			})
-			predicates = append(predicates, filter.InAnyChannel(filteredChannels...))
+			predicates = append(predicates, namedPredicate{fmt.Sprintf("not in channels %v", channels), filter.InAnyChannel(filteredChannels...)})
 		}
 
-		if versionRangeConstraints != nil {
-			predicates = append(predicates, filter.InSemverRange(versionRangeConstraints))
+		if versionRangeConstraints != nil || releaseRangeConstraints != nil {
+			predicates = append(predicates, namedPredicate{versionReleaseReason(versionRange, releaseRange), filter.InVersionReleaseRange(versionRangeConstraints, releaseRangeConstraints)})
 		}
 
-		if ext.Spec.Source.Catalog.UpgradeConstraintPolicy != ocv1.UpgradeConstraintPolicySelfCertified && installedBundle != nil {
+		if q.upgradeConstraintPolicy != ocv1.UpgradeConstraintPolicySelfCertified && installedBundle != nil {
 			successorPredicate, err := filter.SuccessorsOf(*installedBundle, packageFBC.Channels...)
 			if err != nil {
 				return fmt.Errorf("error finding upgrade edges: %w", err)
 			}
-			predicates = append(predicates, successorPredicate)
+			predicates = append(predicates, namedPredicate{fmt.Sprintf("not a successor of installed bundle %q", installedBundle.Name), successorPredicate})
+		}
+
+		if upgradeScopePredicate != nil {
+			predicates = append(predicates, namedPredicate{fmt.Sprintf("outside upgrade scope %q", upgradeScope), upgradeScopePredicate})
 		}
 
-		// Apply the predicates to get the candidate bundles
-		packageFBC.Bundles = filterutil.InPlace(packageFBC.Bundles, filterutil.And(predicates...))
+		// Apply the predicates one at a time to get the candidate bundles, recording
+		// the bundles that each of them eliminates
+		for _, p := range predicates {
+			packageFBC.Bundles = cs.filter(packageFBC.Bundles, p)
+		}
+
+		// Exclude denied bundles separately, to report how many candidates were denied
+		if allowedPredicate != nil {
+			candidates := len(packageFBC.Bundles)
+			packageFBC.Bundles = cs.filter(packageFBC.Bundles, namedPredicate{"denied by ClusterBundleDenyPolicies", allowedPredicate})
+			cs.DeniedBundles = candidates - len(packageFBC.Bundles)
+			deniedBundles += cs.DeniedBundles
+		}
 		cs.MatchedBundles = len(packageFBC.Bundles)
 		if len(packageFBC.Bundles) == 0 {
 			return nil
@@ -144,6 +395,10 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 		})
 
 		thisBundle := packageFBC.Bundles[0]
+		if cs.recordEliminations {
+			cs.Candidate = thisBundle.Name
+			cs.eliminate(fmt.Sprintf("ranked below %q", thisBundle.Name), slicesutil.Map(packageFBC.Bundles[1:], func(b declcfg.Bundle) string { return b.Name })...)
+		}
 
 		if len(resolvedBundles) != 0 {
 			// We've already found one or more package candidates
@@ -151,9 +406,13 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 			priorIsDeprecated := isDeprecated(*resolvedBundles[len(resolvedBundles)-1].bundle, priorDeprecation)
 			if currentIsDeprecated && !priorIsDeprecated {
 				// Skip this deprecated package and retain the non-deprecated package(s)
+				cs.eliminate(deprecatedCandidateReason, thisBundle.Name)
 				return nil
 			} else if !currentIsDeprecated && priorIsDeprecated {
 				// Our package candidates so far were deprecated and this one is not; clear the lists
+				for _, prior := range resolvedBundles {
+					statsOf(catStats, prior.catalog).eliminate(deprecatedCandidateReason, prior.bundle.Name)
+				}
 				resolvedBundles = []foundBundle{}
 			}
 		}
@@ -163,7 +422,30 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 		priorDeprecation = thisDeprecation
 		return nil
 	}, listOptions...); err != nil {
-		return nil, nil, nil, fmt.Errorf("error walking catalogs: %w", err)
+		return nil, fmt.Errorf("error walking catalogs: %w", err)
+	}
+	if r.ReportStatsFunc != nil {
+		defer func() {
+			stats := make([]CatalogStats, 0, len(catStats))
+			for _, cs := range catStats {
+				reported := *cs
+				reported.recordEliminations = false
+				stats = append(stats, reported)
+			}
+			r.ReportStatsFunc(ctx, packageName, stats)
+		}()
+	}
+
+	// Prefer the catalog that provided the installed bundle over catalogs of any priority
+	if stickyCatalog != "" {
+		if i := slices.IndexFunc(resolvedBundles, func(b foundBundle) bool { return b.catalog == stickyCatalog }); i >= 0 {
+			for j, other := range resolvedBundles {
+				if j != i {
+					statsOf(catStats, other.catalog).eliminate(fmt.Sprintf("not from catalog %q of the installed bundle", stickyCatalog), other.bundle.Name)
+				}
+			}
+			resolvedBundles = []foundBundle{resolvedBundles[i]}
+		}
 	}
 
 	// Resolve for priority
@@ -173,47 +455,55 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 		// If the top two bundles do not have the same priority, then priority breaks the tie
 		// Reduce resolvedBundles to just the first item (highest priority)
 		if resolvedBundles[0].priority != resolvedBundles[1].priority {
+			for _, other := range resolvedBundles[1:] {
+				statsOf(catStats, other.catalog).eliminate(fmt.Sprintf("lower catalog priority than %q", resolvedBundles[0].catalog), other.bundle.Name)
+			}
 			resolvedBundles = []foundBundle{resolvedBundles[0]}
 		}
 	}
 
 	// Check for ambiguity
 	if len(resolvedBundles) != 1 {
-		l.Info("resolution failed", "stats", catStats)
-		return nil, nil, nil, resolutionError{
+		l.Info("resolution failed", "stats", countsOf(catStats))
+		return nil, resolutionError{
 			PackageName:     packageName,
 			Version:         versionRange,
+			Release:         releaseRange,
 			Channels:        channels,
+			UpgradeScope:    upgradeScope,
+			Catalog:         requiredCatalog,
 			InstalledBundle: installedBundle,
 			ResolvedBundles: resolvedBundles,
+			DeniedBundles:   deniedBundles,
 		}
 	}
-	resolvedBundle := resolvedBundles[0].bundle
-	resolvedBundleVersion, err := bundleutil.GetVersionAndRelease(*resolvedBundle)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error getting resolved bundle version for bundle %q: %w", resolvedBundle.Name, err)
-	}
 
-	// Run validations against the resolved bundle to ensure only valid resolved bundles are being returned
-	// Open Question: Should we grab the first valid bundle earlier?
-	//        Answer: No, that would be a hidden resolution input, which we should avoid at all costs; the query can be
-	//                constrained in order to eliminate the invalid bundle from the resolution.
-	for _, validation := range r.Validations {
-		if err := validation(resolvedBundle); err != nil {
-			return nil, nil, nil, fmt.Errorf("validating bundle %q: %w", resolvedBundle.Name, err)
-		}
-	}
+	l.V(4).Info("resolution succeeded", "stats", countsOf(catStats))
+	return &resolvedPackage{
+		foundBundle: resolvedBundles[0],
+		deprecation: mergeDeprecations(r.DeprecationMergeStrategy, resolvedBundles[0].catalog, catalogDeprecations),
+	}, nil
+}
+
+// deprecatedCandidateReason is the reason why a deprecated candidate is eliminated
+// in favor of the candidates of other catalogs.
+const deprecatedCandidateReason = "deprecated, while other catalogs provide bundles that are not"
 
-	l.V(4).Info("resolution succeeded", "stats", catStats)
-	return resolvedBundle, resolvedBundleVersion, priorDeprecation, nil
+// statsOf returns the statistics of the named catalog.
+func statsOf(catStats []*CatalogStats, catalogName string) *CatalogStats {
+	return catStats[slices.IndexFunc(catStats, func(cs *CatalogStats) bool { return cs.CatalogName == catalogName })]
 }
 
 type resolutionError struct {
 	PackageName     string
 	Version         string
+	Release         string
 	Channels        []string
+	UpgradeScope    ocv1.UpgradeScope
+	Catalog         string
 	InstalledBundle *ocv1.BundleMetadata
 	ResolvedBundles []foundBundle
+	DeniedBundles   int
 }
 
 func (rei resolutionError) Error() string {
@@ -232,10 +522,22 @@ func (rei resolutionError) Error() string {
 		sb.WriteString(fmt.Sprintf("matching version %q ", rei.Version))
 	}
 
+	if rei.Release != "" {
+		sb.WriteString(fmt.Sprintf("matching release %q ", rei.Release))
+	}
+
 	if len(rei.Channels) > 0 {
 		sb.WriteString(fmt.Sprintf("in channels %v ", rei.Channels))
 	}
 
+	if rei.InstalledBundle != nil && rei.UpgradeScope != "" {
+		sb.WriteString(fmt.Sprintf("within upgrade scope %q ", rei.UpgradeScope))
+	}
+
+	if rei.Catalog != "" {
+		sb.WriteString(fmt.Sprintf("in catalog %q ", rei.Catalog))
+	}
+
 	matchedCatalogs := make([]string, 0, len(rei.ResolvedBundles))
 	for _, r := range rei.ResolvedBundles {
 		matchedCatalogs = append(matchedCatalogs, r.catalog)
@@ -245,6 +547,10 @@ func (rei resolutionError) Error() string {
 		sb.WriteString(fmt.Sprintf("in multiple catalogs with the same priority %v ", matchedCatalogs))
 	}
 
+	if len(rei.ResolvedBundles) == 0 && rei.DeniedBundles > 0 {
+		sb.WriteString(fmt.Sprintf("(excluding %d bundles denied by ClusterBundleDenyPolicies) ", rei.DeniedBundles))
+	}
+
 	return strings.TrimSpace(sb.String())
 }
 
@@ -260,6 +566,45 @@ func isDeprecated(bundle declcfg.Bundle, deprecation *declcfg.Deprecation) bool
 	return false
 }
 
+// namespacedCatalogPriority is the priority of namespaced Catalogs during resolution,
+// which do not support a priority of their own, so that tenants cannot take precedence
+// over the ClusterCatalogs of cluster administrators.
+const namespacedCatalogPriority = math.MinInt32
+
+// CatalogLister returns a function that lists the ClusterCatalogs matching the given
+// options. When namespacedCatalogs is set and the options select a namespace, the
+// namespaced Catalogs in that namespace are listed as well, as ClusterCatalogs named
+// after their keys, with namespacedCatalogPriority.
+func CatalogLister(cl client.Reader, namespacedCatalogs bool) func(context.Context, ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+	return func(ctx context.Context, opts ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+		listOpts := &client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		namespace := listOpts.Namespace
+		listOpts.Namespace = ""
+
+		var clusterCatalogs ocv1.ClusterCatalogList
+		if err := cl.List(ctx, &clusterCatalogs, listOpts); err != nil {
+			return nil, err
+		}
+		catalogs := clusterCatalogs.Items
+		if !namespacedCatalogs || namespace == "" {
+			return catalogs, nil
+		}
+
+		var namespaced ocv1.CatalogList
+		listOpts.Namespace = namespace
+		if err := cl.List(ctx, &namespaced, listOpts); err != nil {
+			return nil, err
+		}
+		for i := range namespaced.Items {
+			catalog := catalogutil.AsClusterCatalog(&namespaced.Items[i])
+			catalog.Spec.Priority = namespacedCatalogPriority
+			catalogs = append(catalogs, *catalog)
+		}
+		return catalogs, nil
+	}
+}
+
 type CatalogWalkFunc func(context.Context, *ocv1.ClusterCatalog, *declcfg.DeclarativeConfig, error) error
 
 func CatalogWalker(
@@ -267,24 +612,11 @@ func CatalogWalker(
 	getPackage func(context.Context, *ocv1.ClusterCatalog, string) (*declcfg.DeclarativeConfig, error),
 ) func(ctx context.Context, packageName string, f CatalogWalkFunc, catalogListOpts ...client.ListOption) error {
 	return func(ctx context.Context, packageName string, f CatalogWalkFunc, catalogListOpts ...client.ListOption) error {
-		l := log.FromContext(ctx)
-		catalogs, err := listCatalogs(ctx, catalogListOpts...)
+		catalogs, err := listAvailableCatalogs(ctx, listCatalogs, catalogListOpts...)
 		if err != nil {
-			return fmt.Errorf("error listing catalogs: %w", err)
+			return err
 		}
 
-		// Remove disabled catalogs from consideration
-		catalogs = slices.DeleteFunc(catalogs, func(c ocv1.ClusterCatalog) bool {
-			if c.Spec.AvailabilityMode == ocv1.AvailabilityModeUnavailable {
-				l.Info("excluding ClusterCatalog from resolution process since it is disabled", "catalog", c.Name)
-				return true
-			}
-			return false
-		})
-
-		availableCatalogNames := slicesutil.Map(catalogs, func(c ocv1.ClusterCatalog) string { return c.Name })
-		l.Info("using ClusterCatalogs for resolution", "catalogs", availableCatalogNames)
-
 		for i := range catalogs {
 			cat := &catalogs[i]
 
@@ -300,6 +632,29 @@ func CatalogWalker(
 	}
 }
 
+// listAvailableCatalogs lists the catalogs matching the given options, excluding
+// the catalogs that are disabled.
+func listAvailableCatalogs(ctx context.Context, listCatalogs func(context.Context, ...client.ListOption) ([]ocv1.ClusterCatalog, error), catalogListOpts ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+	l := log.FromContext(ctx)
+	catalogs, err := listCatalogs(ctx, catalogListOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing catalogs: %w", err)
+	}
+
+	// Remove disabled catalogs from consideration
+	catalogs = slices.DeleteFunc(catalogs, func(c ocv1.ClusterCatalog) bool {
+		if c.Spec.AvailabilityMode == ocv1.AvailabilityModeUnavailable {
+			l.Info("excluding ClusterCatalog from resolution process since it is disabled", "catalog", c.Name)
+			return true
+		}
+		return false
+	})
+
+	availableCatalogNames := slicesutil.Map(catalogs, func(c ocv1.ClusterCatalog) string { return c.Name })
+	l.Info("using ClusterCatalogs for resolution", "catalogs", availableCatalogNames)
+	return catalogs, nil
+}
+
 func isFBCEmpty(fbc *declcfg.DeclarativeConfig) bool {
 	if fbc == nil {
 		return true
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"testing"
 
 	bsemver "github.com/blang/semver/v4"
@@ -14,18 +17,21 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 	"github.com/operator-framework/operator-registry/alpha/property"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/scheme"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
 )
 
 func TestInvalidClusterExtensionVersionRange(t *testing.T) {
 	r := CatalogResolver{}
 	pkgName := randPkg()
 	ce := buildFooClusterExtension(pkgName, []string{}, "foobar", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, `desired version range "foobar" is invalid: improper constraint: "foobar"`)
 }
 
@@ -35,7 +41,7 @@ func TestErrorWalkingCatalogs(t *testing.T) {
 	}}
 	pkgName := randPkg()
 	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, "error walking catalogs: fake error")
 }
 
@@ -48,7 +54,7 @@ func TestErrorGettingPackage(t *testing.T) {
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	pkgName := randPkg()
 	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, fmt.Sprintf(`error walking catalogs: error getting package %q from catalog "a": fake error`, pkgName))
 }
 
@@ -67,7 +73,7 @@ func TestPackageDoesNotExist(t *testing.T) {
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	pkgName := randPkg()
 	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, fmt.Sprintf(`no bundles found for package %q`, pkgName))
 }
 
@@ -86,11 +92,11 @@ func TestPackageExists(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "3.0.0"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("3.0.0")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestValidationFailed(t *testing.T) {
@@ -115,7 +121,7 @@ func TestValidationFailed(t *testing.T) {
 		},
 	}
 	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.Error(t, err)
 }
 
@@ -134,7 +140,7 @@ func TestVersionDoesNotExist(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{}, "4.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, fmt.Sprintf(`no bundles found for package %q matching version "4.0.0"`, pkgName))
 }
 
@@ -153,11 +159,11 @@ func TestVersionExists(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0 <2.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.0.2")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestChannelDoesNotExist(t *testing.T) {
@@ -175,7 +181,7 @@ func TestChannelDoesNotExist(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{"stable"}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, fmt.Sprintf(`no bundles found for package %q in channels [stable]`, pkgName))
 }
 
@@ -194,11 +200,11 @@ func TestChannelExists(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{"beta"}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.0.2")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestChannelExistsButNotVersion(t *testing.T) {
@@ -216,7 +222,7 @@ func TestChannelExistsButNotVersion(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{"beta"}, "3.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, fmt.Sprintf(`no bundles found for package %q matching version "3.0.0" in channels [beta]`, pkgName))
 }
 
@@ -235,7 +241,7 @@ func TestVersionExistsButNotChannel(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{"stable"}, "1.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, fmt.Sprintf(`no bundles found for package %q matching version "1.0.0" in channels [stable]`, pkgName))
 }
 
@@ -254,11 +260,11 @@ func TestChannelAndVersionExist(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{"alpha"}, "0.1.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "0.1.0"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("0.1.0")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestPreferNonDeprecated(t *testing.T) {
@@ -276,11 +282,11 @@ func TestPreferNonDeprecated(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{}, ">=0.1.0 <=1.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "0.1.0"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("0.1.0")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestAcceptDeprecated(t *testing.T) {
@@ -298,11 +304,11 @@ func TestAcceptDeprecated(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0 <=1.0.1", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "1.0.1"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.0.1")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestPackageVariationsBetweenCatalogs(t *testing.T) {
@@ -381,7 +387,7 @@ func TestPackageVariationsBetweenCatalogs(t *testing.T) {
 
 	t.Run("when bundle candidates for a package are deprecated in all but one catalog", func(t *testing.T) {
 		ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0 <=1.0.3", ocv1.UpgradeConstraintPolicyCatalogProvided)
-		gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+		gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 		require.NoError(t, err)
 		// We choose the only non-deprecated package
 		assert.Equal(t, genBundle(pkgName, "1.0.2").Name, gotBundle.Name)
@@ -391,7 +397,7 @@ func TestPackageVariationsBetweenCatalogs(t *testing.T) {
 
 	t.Run("when bundle candidates are found and deprecated in multiple catalogs", func(t *testing.T) {
 		ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0 <=1.0.1", ocv1.UpgradeConstraintPolicyCatalogProvided)
-		gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+		gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 		require.Error(t, err)
 		// We will not make a decision on which catalog to use
 		require.ErrorContains(t, err, "in multiple catalogs with the same priority [b c]")
@@ -402,7 +408,7 @@ func TestPackageVariationsBetweenCatalogs(t *testing.T) {
 
 	t.Run("when bundle candidates are found and not deprecated in multiple catalogs", func(t *testing.T) {
 		ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0 <=1.0.4", ocv1.UpgradeConstraintPolicyCatalogProvided)
-		gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+		gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 		require.Error(t, err)
 		// We will not make a decision on which catalog to use
 		require.ErrorContains(t, err, "in multiple catalogs with the same priority [d f]")
@@ -413,7 +419,7 @@ func TestPackageVariationsBetweenCatalogs(t *testing.T) {
 
 	t.Run("highest semver bundle is chosen when candidates are all from the same catalog", func(t *testing.T) {
 		ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.4 <=1.0.5", ocv1.UpgradeConstraintPolicyCatalogProvided)
-		gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+		gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 		require.NoError(t, err)
 		// Bundles within one catalog for a package will be sorted by semver and deprecation and the best is returned
 		assert.Equal(t, genBundle(pkgName, "1.0.5").Name, gotBundle.Name)
@@ -442,11 +448,11 @@ func TestUpgradeFoundLegacy(t *testing.T) {
 		Version: "0.1.0",
 	}
 	// 0.1.0 => 1.0.2 would not be allowed using semver semantics
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, installedBundle)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, installedBundle, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.0.2")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestUpgradeNotFoundLegacy(t *testing.T) {
@@ -469,7 +475,7 @@ func TestUpgradeNotFoundLegacy(t *testing.T) {
 		Version: "0.1.0",
 	}
 	// 0.1.0 only upgrades to 1.0.x with its legacy upgrade edges, so this fails.
-	_, _, _, err := r.Resolve(context.Background(), ce, installedBundle)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, installedBundle, "")
 	assert.EqualError(t, err, fmt.Sprintf(`error upgrading from currently installed version "0.1.0": no bundles found for package %q matching version "<1.0.0 >=2.0.0"`, pkgName))
 }
 
@@ -494,11 +500,11 @@ func TestDowngradeFound(t *testing.T) {
 	}
 	// 1.0.2 => 0.1.0 is a downgrade, but it is allowed because of the upgrade constraint policy.
 	//   note: we chose 0.1.0 because 1.0.0 and 1.0.1 are deprecated.
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, installedBundle)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, installedBundle, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "0.1.0"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("0.1.0")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestDowngradeNotFound(t *testing.T) {
@@ -521,10 +527,120 @@ func TestDowngradeNotFound(t *testing.T) {
 		Version: "1.0.2",
 	}
 	// Downgrades are allowed via the upgrade constraint policy, but there is no bundle in the specified range.
-	_, _, _, err := r.Resolve(context.Background(), ce, installedBundle)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, installedBundle, "")
 	assert.EqualError(t, err, fmt.Sprintf(`error upgrading from currently installed version "1.0.2": no bundles found for package %q matching version ">0.1.0 <1.0.0"`, pkgName))
 }
 
+func TestUpgradeScopeFound(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+	installedBundle := &ocv1.BundleMetadata{
+		Name:    bundleName(pkgName, "1.0.0"),
+		Version: "1.0.0",
+	}
+
+	// 1.0.0 => 2.0.0 is allowed by the upgrade edges of the catalog, but not by the upgrade scope.
+	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	ce.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopeNoMajor
+	gotBundle, gotVersion, _, _, err := r.Resolve(context.Background(), ce, installedBundle, "")
+	require.NoError(t, err)
+	assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
+	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.0.2")}, *gotVersion)
+
+	// The upgrade scope does not apply to the initial installation.
+	gotBundle, _, _, _, err = r.Resolve(context.Background(), ce, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, genBundle(pkgName, "3.0.0"), *gotBundle)
+}
+
+func TestUpgradeScopeNotFound(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+	ce := buildFooClusterExtension(pkgName, []string{}, ">2.0.0", ocv1.UpgradeConstraintPolicySelfCertified)
+	ce.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopePatchOnly
+	installedBundle := &ocv1.BundleMetadata{
+		Name:    bundleName(pkgName, "2.0.0"),
+		Version: "2.0.0",
+	}
+	// The upgrade scope also applies when upgrade edges are ignored, so 2.0.0 => 3.0.0 is not allowed.
+	_, _, _, _, err := r.Resolve(context.Background(), ce, installedBundle, "")
+	assert.EqualError(t, err, fmt.Sprintf(`error upgrading from currently installed version "2.0.0": no bundles found for package %q matching version ">2.0.0" within upgrade scope "PatchOnly"`, pkgName))
+}
+
+func TestReleaseRange(t *testing.T) {
+	pkgName := randPkg()
+	// Rebuilds of 1.4.2 carry their release in the build metadata of the version.
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return &declcfg.DeclarativeConfig{
+				Packages: []declcfg.Package{{Name: pkgName}},
+				Channels: []declcfg.Channel{{Package: pkgName, Name: "stable", Entries: []declcfg.ChannelEntry{
+					{Name: bundleName(pkgName, "1.4.2+1")},
+					{Name: bundleName(pkgName, "1.4.2+2"), Replaces: bundleName(pkgName, "1.4.2+1")},
+					{Name: bundleName(pkgName, "1.4.2+3"), Replaces: bundleName(pkgName, "1.4.2+2")},
+					{Name: bundleName(pkgName, "1.5.0+1"), Replaces: bundleName(pkgName, "1.4.2+3")},
+				}}},
+				Bundles: []declcfg.Bundle{
+					genBundle(pkgName, "1.4.2+1"),
+					genBundle(pkgName, "1.4.2+2"),
+					genBundle(pkgName, "1.4.2+3"),
+					genBundle(pkgName, "1.5.0+1"),
+				},
+			}, nil, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+
+	t.Run("minimum release", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "1.4.2", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.Release = ">=2"
+		gotBundle, gotVersion, _, _, err := r.Resolve(context.Background(), ce, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "1.4.2+3"), *gotBundle)
+		assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.4.2"), Release: declcfg.Release{bsemver.PRVersion{VersionNum: 3, IsNum: true}}}, *gotVersion)
+	})
+
+	t.Run("exact release", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "1.4.2", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.Release = "2"
+		gotBundle, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "1.4.2+2"), *gotBundle)
+	})
+
+	t.Run("release applies to all versions", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.Release = ">=2"
+		gotBundle, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "1.4.2+3"), *gotBundle)
+	})
+
+	t.Run("no bundle of the release", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "1.4.2", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.Release = ">=4"
+		_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
+		assert.EqualError(t, err, fmt.Sprintf(`no bundles found for package %q matching version "1.4.2" matching release ">=4"`, pkgName))
+	})
+
+	t.Run("invalid release range", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.Release = "=>4"
+		_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
+		assert.ErrorContains(t, err, `desired release range "=>4" is invalid`)
+	})
+}
+
 func TestCatalogWalker(t *testing.T) {
 	t.Run("error listing catalogs", func(t *testing.T) {
 		w := CatalogWalker(
@@ -666,6 +782,16 @@ func packageDeprecation(pkg string) declcfg.Deprecation {
 	}
 }
 
+// reportedDeprecation returns the deprecation as it is merged from the given catalog.
+func reportedDeprecation(deprecation declcfg.Deprecation, catalog string) declcfg.Deprecation {
+	entries := slices.Clone(deprecation.Entries)
+	for i := range entries {
+		entries[i].Message += fmt.Sprintf(" (reported by catalog %q)", catalog)
+	}
+	deprecation.Entries = entries
+	return deprecation
+}
+
 func genPackage(pkg string) *declcfg.DeclarativeConfig {
 	return &declcfg.DeclarativeConfig{
 		Packages: []declcfg.Package{{Name: pkg}},
@@ -720,7 +846,7 @@ func TestInvalidClusterExtensionCatalogMatchExpressions(t *testing.T) {
 			},
 		},
 	}
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.EqualError(t, err, "desired catalog selector is invalid: \"bad\" is not a valid label selector operator")
 }
 
@@ -746,7 +872,7 @@ func TestInvalidClusterExtensionCatalogMatchLabelsName(t *testing.T) {
 			},
 		},
 	}
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.ErrorContains(t, err, "desired catalog selector is invalid: key: Invalid value:")
 }
 
@@ -772,7 +898,7 @@ func TestInvalidClusterExtensionCatalogMatchLabelsValue(t *testing.T) {
 			},
 		},
 	}
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	assert.ErrorContains(t, err, "desired catalog selector is invalid: values[0][name]: Invalid value:")
 }
 
@@ -792,7 +918,7 @@ func TestClusterExtensionMatchLabel(t *testing.T) {
 		MatchLabels: map[string]string{"olm.operatorframework.io/metadata.name": "b"},
 	}
 
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 }
 
@@ -812,7 +938,7 @@ func TestClusterExtensionNoMatchLabel(t *testing.T) {
 		MatchLabels: map[string]string{"olm.operatorframework.io/metadata.name": "a"},
 	}
 
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.Error(t, err)
 	require.ErrorContains(t, err, fmt.Sprintf("no bundles found for package %q", pkgName))
 }
@@ -852,11 +978,80 @@ func TestUnequalPriority(t *testing.T) {
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 
 	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, gotVersion, _, err := r.Resolve(context.Background(), ce, nil)
+	_, gotVersion, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	require.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.0.0")}, *gotVersion)
 }
 
+func TestReportStats(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), &ocv1.ClusterCatalogSpec{Priority: 1}, nil
+		},
+		"b": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+		"c": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return &declcfg.DeclarativeConfig{}, nil, nil
+		},
+	}
+	var gotPackage string
+	var gotStats []CatalogStats
+	r := CatalogResolver{
+		WalkCatalogsFunc: w.WalkCatalogs,
+		ReportStatsFunc: func(_ context.Context, packageName string, stats []CatalogStats) {
+			gotPackage = packageName
+			gotStats = stats
+		},
+	}
+	ce := buildFooClusterExtension(pkgName, []string{"alpha"}, "<=1.0.2", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	gotBundle, _, _, gotCatalog, err := r.Resolve(context.Background(), ce, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
+	assert.Equal(t, "a", gotCatalog)
+
+	catalogStats := func(name string, eliminated ...Elimination) CatalogStats {
+		return CatalogStats{
+			CatalogName:    name,
+			PackageFound:   true,
+			TotalBundles:   6,
+			MatchedBundles: 4,
+			Eliminated: append([]Elimination{
+				{Reason: "not in channels [alpha]", Bundles: []string{bundleName(pkgName, "3.0.0")}},
+				{Reason: `not matching version "<=1.0.2"`, Bundles: []string{bundleName(pkgName, "2.0.0")}},
+				{Reason: fmt.Sprintf("ranked below %q", bundleName(pkgName, "1.0.2")), Bundles: []string{bundleName(pkgName, "0.1.0"), bundleName(pkgName, "1.0.1"), bundleName(pkgName, "1.0.0")}},
+			}, eliminated...),
+			Candidate: bundleName(pkgName, "1.0.2"),
+		}
+	}
+	slices.SortFunc(gotStats, func(a, b CatalogStats) int { return strings.Compare(a.CatalogName, b.CatalogName) })
+	assert.Equal(t, pkgName, gotPackage)
+	assert.Equal(t, []CatalogStats{
+		catalogStats("a"),
+		catalogStats("b", Elimination{Reason: `lower catalog priority than "a"`, Bundles: []string{bundleName(pkgName, "1.0.2")}}),
+		{CatalogName: "c"},
+	}, gotStats)
+}
+
+func TestCatalogStatsRecordEliminations(t *testing.T) {
+	bundles := []declcfg.Bundle{{Name: "b1"}, {Name: "b2"}}
+	notB2 := namedPredicate{"is b2", func(b declcfg.Bundle) bool { return b.Name != "b2" }}
+
+	cs := CatalogStats{CatalogName: "a", PackageFound: true, TotalBundles: 2, MatchedBundles: 1, DeniedBundles: 1}
+	assert.Equal(t, []declcfg.Bundle{{Name: "b1"}}, cs.filter(slices.Clone(bundles), notB2))
+	cs.eliminate("other", "b1")
+	assert.Empty(t, cs.Eliminated)
+
+	cs.recordEliminations = true
+	assert.Equal(t, []declcfg.Bundle{{Name: "b1"}}, cs.filter(slices.Clone(bundles), notB2))
+	cs.eliminate("other", "b1")
+	assert.Equal(t, []Elimination{{Reason: "is b2", Bundles: []string{"b2"}}, {Reason: "other", Bundles: []string{"b1"}}}, cs.Eliminated)
+
+	// Only the counts are logged, whether eliminations are recorded or not.
+	assert.Equal(t, []catalogCounts{{CatalogName: "a", PackageFound: true, TotalBundles: 2, MatchedBundles: 1, DeniedBundles: 1}}, countsOf([]*CatalogStats{&cs}))
+}
+
 func TestMultiplePriority(t *testing.T) {
 	pkgName := randPkg()
 	w := staticCatalogWalker{
@@ -873,7 +1068,7 @@ func TestMultiplePriority(t *testing.T) {
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 
 	ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0 <=1.0.1", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.Error(t, err)
 	require.ErrorContains(t, err, "in multiple catalogs with the same priority [a b c]")
 	assert.Nil(t, gotBundle)
@@ -881,6 +1076,160 @@ func TestMultiplePriority(t *testing.T) {
 	assert.Nil(t, gotDeprecation)
 }
 
+func TestCatalogStickiness(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), &ocv1.ClusterCatalogSpec{Priority: 1}, nil
+		},
+		"b": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), &ocv1.ClusterCatalogSpec{Priority: 0}, nil
+		},
+		"c": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), &ocv1.ClusterCatalogSpec{Priority: 1}, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+	installedBundle := &ocv1.BundleMetadata{
+		Name:    bundleName(pkgName, "1.0.0"),
+		Version: "1.0.0",
+	}
+	buildExtension := func(stickiness ocv1.CatalogStickiness, catalogs ...string) *ocv1.ClusterExtension {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.CatalogStickiness = stickiness
+		if len(catalogs) > 0 {
+			ce.Spec.Source.Catalog.Selector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "olm.operatorframework.io/metadata.name",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   catalogs,
+				}},
+			}
+		}
+		return ce
+	}
+
+	t.Run("preferred by default over catalogs of higher priority", func(t *testing.T) {
+		gotBundle, _, _, gotCatalog, err := r.Resolve(context.Background(), buildExtension(""), installedBundle, "b")
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "2.0.0"), *gotBundle)
+		assert.Equal(t, "b", gotCatalog)
+	})
+
+	t.Run("preferred falls back to the other catalogs", func(t *testing.T) {
+		gotBundle, _, _, gotCatalog, err := r.Resolve(context.Background(), buildExtension(ocv1.CatalogStickinessPreferred, "a"), installedBundle, "b")
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "2.0.0"), *gotBundle)
+		assert.Equal(t, "a", gotCatalog)
+	})
+
+	t.Run("required", func(t *testing.T) {
+		_, _, _, gotCatalog, err := r.Resolve(context.Background(), buildExtension(ocv1.CatalogStickinessRequired), installedBundle, "b")
+		require.NoError(t, err)
+		assert.Equal(t, "b", gotCatalog)
+
+		_, _, _, _, err = r.Resolve(context.Background(), buildExtension(ocv1.CatalogStickinessRequired, "a"), installedBundle, "b")
+		assert.EqualError(t, err, fmt.Sprintf(`error upgrading from currently installed version "1.0.0": no bundles found for package %q in catalog "b"`, pkgName))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		_, _, _, _, err := r.Resolve(context.Background(), buildExtension(ocv1.CatalogStickinessNone), installedBundle, "b")
+		require.ErrorContains(t, err, "in multiple catalogs with the same priority [a b c]")
+	})
+
+	t.Run("no effect on the initial installation", func(t *testing.T) {
+		ce := buildExtension(ocv1.CatalogStickinessRequired, "a")
+		_, _, _, gotCatalog, err := r.Resolve(context.Background(), ce, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, "a", gotCatalog)
+	})
+}
+
+func TestDeprecationMergeStrategy(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			fbc := genPackage(pkgName)
+			fbc.Deprecations = nil
+			return fbc, &ocv1.ClusterCatalogSpec{Priority: 1}, nil
+		},
+		"b": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), &ocv1.ClusterCatalogSpec{Priority: 0}, nil
+		},
+	}
+	installedBundle := &ocv1.BundleMetadata{
+		Name:    bundleName(pkgName, "1.0.0"),
+		Version: "1.0.0",
+	}
+	ce := buildFooClusterExtension(pkgName, []string{}, "1.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
+
+	// The installed bundle is only deprecated in the catalog of lower priority.
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+	_, _, gotDeprecation, gotCatalog, err := r.Resolve(context.Background(), ce, installedBundle, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", gotCatalog)
+	assert.Nil(t, gotDeprecation)
+
+	r.DeprecationMergeStrategy = DeprecationMergeUnion
+	_, _, gotDeprecation, gotCatalog, err = r.Resolve(context.Background(), ce, installedBundle, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", gotCatalog)
+	require.NotNil(t, gotDeprecation)
+	assert.Equal(t, fmt.Sprintf(`bundle %s is deprecated (reported by catalog "b")`, bundleName(pkgName, "1.0.0")), gotDeprecation.Entries[0].Message)
+}
+
+func TestBundleDenyPolicies(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+	}
+	policies := []ocv1.ClusterBundleDenyPolicy{
+		denyPolicy("cves", "",
+			ocv1.BundleDenyEntry{PackageName: pkgName, VersionRange: "1.0.2", Reason: "CVE-1"},
+			ocv1.BundleDenyEntry{PackageName: pkgName, VersionRange: ">=2.0.0", Reason: "CVE-2"},
+		),
+	}
+	r := CatalogResolver{
+		WalkCatalogsFunc: w.WalkCatalogs,
+		ListBundleDenyPoliciesFunc: func(context.Context) ([]ocv1.ClusterBundleDenyPolicy, error) {
+			return policies, nil
+		},
+	}
+	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	installedBundle := &ocv1.BundleMetadata{
+		Name:    bundleName(pkgName, "1.0.2"),
+		Version: "1.0.2",
+	}
+
+	// Denied bundles are not installed.
+	gotBundle, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, genBundle(pkgName, "0.1.0"), *gotBundle)
+
+	// The denied installed bundle remains installed without remediation.
+	gotBundle, _, _, _, err = r.Resolve(context.Background(), ce, installedBundle, "")
+	require.NoError(t, err)
+	assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
+
+	// With remediation, resolution fails until an allowed successor is available.
+	policies[0].Spec.Remediation = ocv1.BundleDenyRemediationUpgrade
+	_, _, _, _, err = r.Resolve(context.Background(), ce, installedBundle, "")
+	assert.EqualError(t, err, fmt.Sprintf(`error upgrading from currently installed version "1.0.2": no bundles found for package %q (excluding 2 bundles denied by ClusterBundleDenyPolicies)`, pkgName))
+
+	policies[0].Spec.Entries = policies[0].Spec.Entries[:1]
+	gotBundle, _, _, _, err = r.Resolve(context.Background(), ce, installedBundle, "")
+	require.NoError(t, err)
+	assert.Equal(t, genBundle(pkgName, "2.0.0"), *gotBundle)
+
+	r.ListBundleDenyPoliciesFunc = func(context.Context) ([]ocv1.ClusterBundleDenyPolicy, error) {
+		return nil, errors.New("fake error")
+	}
+	_, _, _, _, err = r.Resolve(context.Background(), ce, installedBundle, "")
+	assert.EqualError(t, err, "error listing bundle deny policies: fake error")
+}
+
 func TestMultipleChannels(t *testing.T) {
 	pkgName := randPkg()
 	w := staticCatalogWalker{
@@ -896,11 +1245,11 @@ func TestMultipleChannels(t *testing.T) {
 	}
 	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
 	ce := buildFooClusterExtension(pkgName, []string{"beta", "alpha"}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, gotDeprecation, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, gotDeprecation, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, genBundle(pkgName, "2.0.0"), *gotBundle)
 	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("2.0.0")}, *gotVersion)
-	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
+	assert.Equal(t, ptr.To(reportedDeprecation(packageDeprecation(pkgName), "c")), gotDeprecation)
 }
 
 func TestAllCatalogsDisabled(t *testing.T) {
@@ -929,7 +1278,7 @@ func TestAllCatalogsDisabled(t *testing.T) {
 	}
 
 	ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	_, _, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no bundles found for package")
 }
@@ -969,8 +1318,54 @@ func TestSomeCatalogsDisabled(t *testing.T) {
 	}
 
 	ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
-	gotBundle, gotVersion, _, err := r.Resolve(context.Background(), ce, nil)
+	gotBundle, gotVersion, _, _, err := r.Resolve(context.Background(), ce, nil, "")
 	require.NoError(t, err)
 	require.NotNil(t, gotBundle)
 	require.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("3.0.0")}, *gotVersion)
 }
+
+func TestCatalogLister(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&ocv1.ClusterCatalog{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+		&ocv1.Catalog{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "tenant"}},
+		&ocv1.Catalog{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "tenant"}},
+	).Build()
+
+	for _, tc := range []struct {
+		name               string
+		namespacedCatalogs bool
+		opts               []client.ListOption
+		expectedNames      []string
+	}{
+		{
+			name:               "namespaced catalogs in the selected namespace are listed",
+			namespacedCatalogs: true,
+			opts:               []client.ListOption{client.InNamespace("team-a")},
+			expectedNames:      []string{"cluster", "team-a_tenant"},
+		},
+		{
+			name:               "namespaced catalogs are not listed without a namespace",
+			namespacedCatalogs: true,
+			expectedNames:      []string{"cluster"},
+		},
+		{
+			name:          "namespaced catalogs are not listed when they are disabled",
+			opts:          []client.ListOption{client.InNamespace("team-a")},
+			expectedNames: []string{"cluster"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			catalogs, err := CatalogLister(cl, tc.namespacedCatalogs)(context.Background(), tc.opts...)
+			require.NoError(t, err)
+			names := make([]string, 0, len(catalogs))
+			for _, c := range catalogs {
+				assert.Empty(t, c.Namespace)
+				if _, _, namespaced := catalogutil.SplitNamespacedKey(c.Name); namespaced {
+					assert.Equal(t, int32(math.MinInt32), c.Spec.Priority)
+				}
+				names = append(names, c.Name)
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	bsemver "github.com/blang/semver/v4"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/compare"
+	filterutil "github.com/operator-framework/operator-controller/internal/shared/util/filter"
+)
+
+// BundleDenyPolicyLister returns a function that lists the ClusterBundleDenyPolicies.
+func BundleDenyPolicyLister(cl client.Reader) func(context.Context) ([]ocv1.ClusterBundleDenyPolicy, error) {
+	return func(ctx context.Context) ([]ocv1.ClusterBundleDenyPolicy, error) {
+		var policies ocv1.ClusterBundleDenyPolicyList
+		if err := cl.List(ctx, &policies); err != nil {
+			return nil, err
+		}
+		return policies.Items, nil
+	}
+}
+
+// Denial is the denial of a bundle by an entry of a ClusterBundleDenyPolicy.
+type Denial struct {
+	Policy      string
+	Reason      string
+	Remediation ocv1.BundleDenyRemediation
+}
+
+func (d Denial) String() string {
+	return fmt.Sprintf("denied by ClusterBundleDenyPolicy %q: %s", d.Policy, d.Reason)
+}
+
+// BundleDenials matches bundles against the entries of ClusterBundleDenyPolicies.
+type BundleDenials struct {
+	entries []denyEntry
+}
+
+type denyEntry struct {
+	Denial
+	packageName  string
+	versionRange bsemver.Range
+	imageDigest  string
+}
+
+// NewBundleDenials returns the BundleDenials for the given policies. It returns an
+// error when a version range of the policies is invalid, so that invalid policies
+// deny resolution rather than allow denied bundles.
+func NewBundleDenials(policies []ocv1.ClusterBundleDenyPolicy) (*BundleDenials, error) {
+	d := &BundleDenials{}
+	for _, policy := range policies {
+		remediation := policy.Spec.Remediation
+		if remediation == "" {
+			remediation = ocv1.BundleDenyRemediationNone
+		}
+		for _, entry := range policy.Spec.Entries {
+			de := denyEntry{
+				Denial:      Denial{Policy: policy.Name, Reason: entry.Reason, Remediation: remediation},
+				packageName: entry.PackageName,
+				imageDigest: entry.ImageDigest,
+			}
+			if entry.VersionRange != "" {
+				versionRange, err := compare.NewVersionRange(entry.VersionRange)
+				if err != nil {
+					return nil, fmt.Errorf("version range %q of ClusterBundleDenyPolicy %q is invalid: %w", entry.VersionRange, policy.Name, err)
+				}
+				de.versionRange = versionRange
+			}
+			d.entries = append(d.entries, de)
+		}
+	}
+	return d, nil
+}
+
+// For returns the denials of the bundle of the given package with the given version
+// and image. A nil version only matches entries without a version range.
+func (d *BundleDenials) For(packageName string, version *bsemver.Version, image string) []Denial {
+	var denials []Denial
+	for _, e := range d.entries {
+		if e.packageName != "" && e.packageName != packageName {
+			continue
+		}
+		if e.versionRange != nil && (version == nil || !e.versionRange(*version)) {
+			continue
+		}
+		if e.imageDigest != "" && !strings.HasSuffix(image, "@"+e.imageDigest) {
+			continue
+		}
+		denials = append(denials, e.Denial)
+	}
+	return denials
+}
+
+// ForBundle returns the denials of the given catalog bundle.
+func (d *BundleDenials) ForBundle(bundle declcfg.Bundle) []Denial {
+	var version *bsemver.Version
+	if vr, err := bundleutil.GetVersionAndRelease(bundle); err == nil {
+		version = &vr.Version
+	}
+	return d.For(bundle.Package, version, bundle.Image)
+}
+
+// ForInstalled returns the denials of the installed bundle of the given package,
+// pulled from the given image.
+func (d *BundleDenials) ForInstalled(packageName string, installedBundle ocv1.BundleMetadata, image string) []Denial {
+	var version *bsemver.Version
+	if v, err := bsemver.Parse(installedBundle.Version); err == nil {
+		version = &v
+	}
+	return d.For(packageName, version, image)
+}
+
+// Allowed returns a predicate that matches the bundles that are not denied. The
+// installed bundle remains allowed unless a policy that denies it remediates it,
+// so that denying an installed bundle does not fail resolution by itself.
+func (d *BundleDenials) Allowed(installedBundle *ocv1.BundleMetadata) filterutil.Predicate[declcfg.Bundle] {
+	return func(bundle declcfg.Bundle) bool {
+		denials := d.ForBundle(bundle)
+		if len(denials) == 0 {
+			return true
+		}
+		if installedBundle == nil || bundle.Name != installedBundle.Name {
+			return false
+		}
+		return !Remediates(denials)
+	}
+}
+
+// Remediates returns whether any of the denials upgrades away from the denied bundle.
+func Remediates(denials []Denial) bool {
+	return slices.ContainsFunc(denials, func(d Denial) bool {
+		return d.Remediation == ocv1.BundleDenyRemediationUpgrade
+	})
+}
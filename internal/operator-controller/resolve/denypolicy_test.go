@@ -0,0 +1,131 @@
+package resolve
+
+import (
+	"testing"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+const testDigest = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+func denyPolicy(name string, remediation ocv1.BundleDenyRemediation, entries ...ocv1.BundleDenyEntry) ocv1.ClusterBundleDenyPolicy {
+	return ocv1.ClusterBundleDenyPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       ocv1.ClusterBundleDenyPolicySpec{Entries: entries, Remediation: remediation},
+	}
+}
+
+func TestBundleDenials(t *testing.T) {
+	denials, err := NewBundleDenials([]ocv1.ClusterBundleDenyPolicy{
+		denyPolicy("cves", "",
+			ocv1.BundleDenyEntry{PackageName: "foo", VersionRange: ">=1.0.0, <1.0.2", Reason: "CVE-1"},
+			ocv1.BundleDenyEntry{ImageDigest: testDigest, Reason: "CVE-2"},
+		),
+		denyPolicy("retired", ocv1.BundleDenyRemediationUpgrade,
+			ocv1.BundleDenyEntry{PackageName: "bar", Reason: "retired"},
+		),
+	})
+	require.NoError(t, err)
+
+	v := func(s string) *bsemver.Version {
+		v := bsemver.MustParse(s)
+		return &v
+	}
+	for _, tc := range []struct {
+		name        string
+		packageName string
+		version     *bsemver.Version
+		image       string
+		want        []Denial
+	}{
+		{
+			name:        "in version range",
+			packageName: "foo",
+			version:     v("1.0.1"),
+			want:        []Denial{{Policy: "cves", Reason: "CVE-1", Remediation: ocv1.BundleDenyRemediationNone}},
+		},
+		{
+			name:        "out of version range",
+			packageName: "foo",
+			version:     v("1.0.2"),
+		},
+		{
+			name:        "unknown version",
+			packageName: "foo",
+		},
+		{
+			name:        "other package",
+			packageName: "baz",
+			version:     v("1.0.1"),
+		},
+		{
+			name:        "image digest",
+			packageName: "baz",
+			image:       "quay.io/baz/bundle@" + testDigest,
+			want:        []Denial{{Policy: "cves", Reason: "CVE-2", Remediation: ocv1.BundleDenyRemediationNone}},
+		},
+		{
+			name:        "image tag",
+			packageName: "baz",
+			image:       "quay.io/baz/bundle:v1",
+		},
+		{
+			name:        "whole package",
+			packageName: "bar",
+			version:     v("3.0.0"),
+			want:        []Denial{{Policy: "retired", Reason: "retired", Remediation: ocv1.BundleDenyRemediationUpgrade}},
+		},
+		{
+			name:        "multiple entries",
+			packageName: "foo",
+			version:     v("1.0.0"),
+			image:       "quay.io/foo/bundle@" + testDigest,
+			want: []Denial{
+				{Policy: "cves", Reason: "CVE-1", Remediation: ocv1.BundleDenyRemediationNone},
+				{Policy: "cves", Reason: "CVE-2", Remediation: ocv1.BundleDenyRemediationNone},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, denials.For(tc.packageName, tc.version, tc.image))
+		})
+	}
+}
+
+func TestBundleDenialsInvalidVersionRange(t *testing.T) {
+	_, err := NewBundleDenials([]ocv1.ClusterBundleDenyPolicy{
+		denyPolicy("cves", "", ocv1.BundleDenyEntry{PackageName: "foo", VersionRange: "not-a-range", Reason: "CVE-1"}),
+	})
+	assert.ErrorContains(t, err, `version range "not-a-range" of ClusterBundleDenyPolicy "cves" is invalid`)
+}
+
+func TestBundleDenialsAllowed(t *testing.T) {
+	installed := &ocv1.BundleMetadata{Name: bundleName("foo", "1.0.0"), Version: "1.0.0"}
+
+	for _, tc := range []struct {
+		name            string
+		remediation     ocv1.BundleDenyRemediation
+		installedBundle *ocv1.BundleMetadata
+		bundle          string
+		want            bool
+	}{
+		{name: "not denied", bundle: "2.0.0", installedBundle: installed, want: true},
+		{name: "denied", bundle: "1.0.1", installedBundle: installed, want: false},
+		{name: "denied without installed bundle", bundle: "1.0.0", want: false},
+		{name: "installed bundle without remediation", bundle: "1.0.0", installedBundle: installed, want: true},
+		{name: "installed bundle with remediation", remediation: ocv1.BundleDenyRemediationUpgrade, bundle: "1.0.0", installedBundle: installed, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			denials, err := NewBundleDenials([]ocv1.ClusterBundleDenyPolicy{
+				denyPolicy("cves", tc.remediation, ocv1.BundleDenyEntry{PackageName: "foo", VersionRange: "<2.0.0", Reason: "CVE-1"}),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, denials.Allowed(tc.installedBundle)(genBundle("foo", tc.bundle)))
+		})
+	}
+}
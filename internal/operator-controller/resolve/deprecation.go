@@ -0,0 +1,144 @@
+package resolve
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// DeprecationMergeStrategy defines how the deprecations of a package are merged
+// when the package is provided by multiple catalogs.
+type DeprecationMergeStrategy string
+
+const (
+	// DeprecationMergeHighestPriority uses the deprecations of the catalog that the
+	// bundle is resolved from, which wins over the other catalogs that provide the
+	// package by priority, or by catalog stickiness.
+	DeprecationMergeHighestPriority DeprecationMergeStrategy = "HighestPriority"
+
+	// DeprecationMergeUnion merges the deprecations of all catalogs that provide
+	// the package, so that anything deprecated in any catalog is reported.
+	DeprecationMergeUnion DeprecationMergeStrategy = "Union"
+)
+
+// DeprecationMergeStrategies are the supported deprecation merge strategies.
+var DeprecationMergeStrategies = []DeprecationMergeStrategy{DeprecationMergeHighestPriority, DeprecationMergeUnion}
+
+// catalogDeprecation is the deprecation of a package in a catalog that provides it.
+type catalogDeprecation struct {
+	catalog string
+	// deprecation is nil when the catalog provides no deprecations for the package.
+	deprecation *declcfg.Deprecation
+}
+
+// mergeDeprecations merges the deprecations of a package from the catalogs that
+// provide it according to strategy, which defaults to DeprecationMergeHighestPriority,
+// given the catalog that the bundle is resolved from. The messages of the merged
+// entries name the catalogs that reported them. It returns nil when no entries remain.
+func mergeDeprecations(strategy DeprecationMergeStrategy, resolvedCatalog string, catalogDeprecations []catalogDeprecation) *declcfg.Deprecation {
+	merged := catalogDeprecations
+	if strategy != DeprecationMergeUnion {
+		merged = slices.DeleteFunc(slices.Clone(catalogDeprecations), func(cd catalogDeprecation) bool {
+			return cd.catalog != resolvedCatalog
+		})
+	}
+
+	type entryKey struct {
+		schema, name, message string
+	}
+	var (
+		result   *declcfg.Deprecation
+		keys     []entryKey
+		catalogs = map[entryKey][]string{}
+	)
+	for _, cd := range merged {
+		if cd.deprecation == nil {
+			continue
+		}
+		if result == nil {
+			result = &declcfg.Deprecation{Schema: cd.deprecation.Schema, Package: cd.deprecation.Package}
+		}
+		for _, entry := range cd.deprecation.Entries {
+			key := entryKey{entry.Reference.Schema, entry.Reference.Name, entry.Message}
+			if _, ok := catalogs[key]; !ok {
+				keys = append(keys, key)
+				result.Entries = append(result.Entries, entry)
+			}
+			if !slices.Contains(catalogs[key], cd.catalog) {
+				catalogs[key] = append(catalogs[key], cd.catalog)
+			}
+		}
+	}
+	if result == nil || len(result.Entries) == 0 {
+		return nil
+	}
+
+	for i, key := range keys {
+		if result.Entries[i].Message != "" {
+			result.Entries[i].Message += reportedBy(catalogs[key])
+		}
+	}
+	return result
+}
+
+func reportedBy(catalogs []string) string {
+	slices.Sort(catalogs)
+	quoted := make([]string, 0, len(catalogs))
+	for _, c := range catalogs {
+		quoted = append(quoted, fmt.Sprintf("%q", c))
+	}
+	if len(quoted) == 1 {
+		return fmt.Sprintf(" (reported by catalog %s)", quoted[0])
+	}
+	return fmt.Sprintf(" (reported by catalogs %s)", strings.Join(quoted, ", "))
+}
+
+// DeprecationEntries are the entries of the deprecation of a package that apply to
+// a ClusterExtension, by the schema of the deprecated object.
+type DeprecationEntries struct {
+	Package []declcfg.DeprecationEntry
+	Channel []declcfg.DeprecationEntry
+	Bundle  []declcfg.DeprecationEntry
+}
+
+// ApplicableDeprecationEntries filters the entries of deprecation down to the ones that
+// apply to ext: the deprecations of its package, of the channels it may be installed
+// from, and of the named bundles. Empty bundle names are ignored.
+func ApplicableDeprecationEntries(ext *ocv1.ClusterExtension, deprecation *declcfg.Deprecation, bundleNames ...string) DeprecationEntries {
+	var entries DeprecationEntries
+	if deprecation == nil {
+		return entries
+	}
+
+	channelSet := sets.New[string]()
+	if ext.Spec.Source.Catalog != nil {
+		channelSet.Insert(ext.Spec.Source.Catalog.Channels...)
+	}
+	bundleSet := sets.New(bundleNames...)
+	bundleSet.Delete("")
+
+	for _, entry := range deprecation.Entries {
+		switch entry.Reference.Schema {
+		case declcfg.SchemaPackage:
+			entries.Package = append(entries.Package, entry)
+		case declcfg.SchemaChannel:
+			// Include channel deprecations if:
+			// 1. No channels specified (channelSet empty) - any channel could be auto-selected
+			// 2. The deprecated channel matches one of the specified channels
+			if len(channelSet) == 0 || channelSet.Has(entry.Reference.Name) {
+				entries.Channel = append(entries.Channel, entry)
+			}
+		case declcfg.SchemaBundle:
+			if bundleSet.Has(entry.Reference.Name) {
+				entries.Bundle = append(entries.Bundle, entry)
+			}
+		}
+	}
+	return entries
+}
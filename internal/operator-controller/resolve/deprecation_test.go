@@ -0,0 +1,115 @@
+package resolve
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+func TestMergeDeprecations(t *testing.T) {
+	packageEntry := func(message string) declcfg.DeprecationEntry {
+		return declcfg.DeprecationEntry{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaPackage}, Message: message}
+	}
+	bundleEntry := func(name, message string) declcfg.DeprecationEntry {
+		return declcfg.DeprecationEntry{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaBundle, Name: name}, Message: message}
+	}
+	deprecation := func(entries ...declcfg.DeprecationEntry) *declcfg.Deprecation {
+		return &declcfg.Deprecation{Schema: declcfg.SchemaDeprecation, Package: "foo", Entries: entries}
+	}
+
+	for _, tc := range []struct {
+		name                string
+		strategy            DeprecationMergeStrategy
+		resolvedCatalog     string
+		catalogDeprecations []catalogDeprecation
+		want                *declcfg.Deprecation
+	}{
+		{
+			name:            "single catalog names the reporting catalog",
+			resolvedCatalog: "a",
+			catalogDeprecations: []catalogDeprecation{
+				{catalog: "a", deprecation: deprecation(packageEntry("use bar"))},
+			},
+			want: deprecation(packageEntry(`use bar (reported by catalog "a")`)),
+		},
+		{
+			name:            "highest priority uses the resolved catalog",
+			strategy:        DeprecationMergeHighestPriority,
+			resolvedCatalog: "a",
+			catalogDeprecations: []catalogDeprecation{
+				{catalog: "a", deprecation: deprecation(bundleEntry("foo.v1.0.0", "use foo.v1.0.1"))},
+				{catalog: "b", deprecation: deprecation(packageEntry("use bar"))},
+			},
+			want: deprecation(bundleEntry("foo.v1.0.0", `use foo.v1.0.1 (reported by catalog "a")`)),
+		},
+		{
+			name:            "highest priority without deprecations in the resolved catalog",
+			resolvedCatalog: "a",
+			catalogDeprecations: []catalogDeprecation{
+				{catalog: "a"},
+				{catalog: "b", deprecation: deprecation(packageEntry("use bar"))},
+			},
+			want: nil,
+		},
+		{
+			name:            "union merges the entries of all catalogs",
+			strategy:        DeprecationMergeUnion,
+			resolvedCatalog: "a",
+			catalogDeprecations: []catalogDeprecation{
+				{catalog: "c", deprecation: deprecation(bundleEntry("foo.v1.0.0", "use foo.v1.0.1"), packageEntry(""))},
+				{catalog: "a"},
+				{catalog: "b", deprecation: deprecation(packageEntry("use bar"), bundleEntry("foo.v1.0.0", "use foo.v1.0.1"))},
+			},
+			want: deprecation(
+				bundleEntry("foo.v1.0.0", `use foo.v1.0.1 (reported by catalogs "b", "c")`),
+				packageEntry(""),
+				packageEntry(`use bar (reported by catalog "b")`),
+			),
+		},
+		{
+			name:     "no catalogs",
+			strategy: DeprecationMergeUnion,
+			want:     nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, mergeDeprecations(tc.strategy, tc.resolvedCatalog, tc.catalogDeprecations))
+		})
+	}
+}
+
+func TestApplicableDeprecationEntries(t *testing.T) {
+	entry := func(schema, name string) declcfg.DeprecationEntry {
+		return declcfg.DeprecationEntry{Reference: declcfg.PackageScopedReference{Schema: schema, Name: name}, Message: schema + " " + name}
+	}
+	deprecation := &declcfg.Deprecation{Schema: declcfg.SchemaDeprecation, Package: "foo", Entries: []declcfg.DeprecationEntry{
+		entry(declcfg.SchemaBundle, "foo.v1.0.0"),
+		entry(declcfg.SchemaChannel, "stable"),
+		entry(declcfg.SchemaPackage, ""),
+		entry(declcfg.SchemaChannel, "fast"),
+		entry(declcfg.SchemaBundle, "foo.v2.0.0"),
+	}}
+	ext := func(channels ...string) *ocv1.ClusterExtension {
+		return &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{Source: ocv1.SourceConfig{
+			SourceType: ocv1.SourceTypeCatalog,
+			Catalog:    &ocv1.CatalogFilter{PackageName: "foo", Channels: channels},
+		}}}
+	}
+
+	assert.Equal(t, DeprecationEntries{}, ApplicableDeprecationEntries(ext(), nil, "foo.v1.0.0"))
+
+	assert.Equal(t, DeprecationEntries{
+		Package: []declcfg.DeprecationEntry{entry(declcfg.SchemaPackage, "")},
+		Channel: []declcfg.DeprecationEntry{entry(declcfg.SchemaChannel, "stable"), entry(declcfg.SchemaChannel, "fast")},
+	}, ApplicableDeprecationEntries(ext(), deprecation, ""), "any channel applies when none is specified")
+
+	assert.Equal(t, DeprecationEntries{
+		Package: []declcfg.DeprecationEntry{entry(declcfg.SchemaPackage, "")},
+		Channel: []declcfg.DeprecationEntry{entry(declcfg.SchemaChannel, "fast")},
+		Bundle:  []declcfg.DeprecationEntry{entry(declcfg.SchemaBundle, "foo.v1.0.0"), entry(declcfg.SchemaBundle, "foo.v2.0.0")},
+	}, ApplicableDeprecationEntries(ext("fast"), deprecation, "foo.v2.0.0", "foo.v1.0.0"))
+}
@@ -0,0 +1,212 @@
+package resolve
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
+)
+
+// resolveProvidedAPI returns the bundle that provides the API of a ClusterExtension
+// whose source is a provided API, whatever its package. Each package with bundles
+// that provide the API is resolved like a catalog source, and the resolved bundles are ranked
+// like the bundles of a package provided by several catalogs: bundles that are not
+// deprecated are preferred, then bundles from the catalogs with the highest priority.
+// Upgrades are only resolved from the package of the installed bundle.
+func (r *CatalogResolver) resolveProvidedAPI(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+	if r.WalkCatalogPackagesFunc == nil {
+		return nil, nil, nil, "", errors.New("resolving bundles by provided API is not supported")
+	}
+
+	l := log.FromContext(ctx)
+	source := ext.Spec.Source.ProvidedAPI
+	gvk := schema.GroupVersionKind{Group: source.Group, Version: source.Version, Kind: source.Kind}
+
+	// unless overridden, default to selecting all catalogs
+	selector, err := metav1.LabelSelectorAsSelector(source.Selector)
+	if err != nil {
+		return nil, nil, nil, "", fmt.Errorf("desired catalog selector is invalid: %w", err)
+	}
+	// A nothing (empty) selector selects everything
+	if selector == labels.Nothing() {
+		selector = labels.Everything()
+	}
+
+	providedGVK := property.GVK{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+	pkgNames := sets.New[string]()
+	if err := r.WalkCatalogPackagesFunc(ctx, providedGVK, func(_ context.Context, cat *ocv1.ClusterCatalog, catalogPkgNames []string, err error) error {
+		if err != nil {
+			return fmt.Errorf("error listing packages of catalog %q: %w", cat.Name, err)
+		}
+		pkgNames.Insert(catalogPkgNames...)
+		return nil
+	}, client.MatchingLabelsSelector{Selector: selector}, client.InNamespace(ext.Spec.Namespace)); err != nil {
+		return nil, nil, nil, "", fmt.Errorf("error walking catalogs: %w", err)
+	}
+
+	allowedPredicate, err := r.allowedPredicate(ctx, installedBundle)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	// Not every bundle of a package provides the API, so the resolution of each
+	// package is only logged when debugging.
+	pkgCtx := log.IntoContext(ctx, l.V(4))
+	providesGVK := namedPredicate{
+		reason:    fmt.Sprintf("not providing %q", gvk.String()),
+		predicate: filter.ProvidesGVK(providedGVK),
+	}
+
+	var candidates []resolvedPackage
+	var deniedBundles int
+	for _, pkgName := range sets.List(pkgNames) {
+		resolved, err := r.resolvePackage(pkgCtx, ext, packageQuery{
+			packageName:             pkgName,
+			versionRange:            source.BundleVersion,
+			selector:                source.Selector,
+			upgradeConstraintPolicy: source.UpgradeConstraintPolicy,
+			predicates:              []namedPredicate{providesGVK},
+			installedPackageOnly:    true,
+		}, installedBundle, installedCatalog, allowedPredicate)
+		var resErr resolutionError
+		if errors.As(err, &resErr) && len(resErr.ResolvedBundles) == 0 {
+			// No bundle of the package provides the API and matches the source.
+			deniedBundles += resErr.DeniedBundles
+			continue
+		}
+		if err != nil {
+			return nil, nil, nil, "", err
+		}
+		candidates = append(candidates, *resolved)
+	}
+
+	// Prefer the packages whose resolved bundle is not deprecated
+	if slices.ContainsFunc(candidates, func(c resolvedPackage) bool { return !c.isDeprecated() }) {
+		candidates = slices.DeleteFunc(candidates, resolvedPackage.isDeprecated)
+	}
+
+	// Resolve for priority
+	if len(candidates) > 1 {
+		highest := slices.MaxFunc(candidates, func(a, b resolvedPackage) int { return cmp.Compare(a.priority, b.priority) }).priority
+		candidates = slices.DeleteFunc(candidates, func(c resolvedPackage) bool { return c.priority != highest })
+	}
+
+	// Check for ambiguity
+	if len(candidates) != 1 {
+		packages := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			packages = append(packages, c.bundle.Package)
+		}
+		l.Info("resolution failed", "providedAPI", gvk.String(), "packages", packages)
+		return nil, nil, nil, "", providedAPIResolutionError{
+			GVK:             gvk,
+			Version:         source.BundleVersion,
+			InstalledBundle: installedBundle,
+			Packages:        packages,
+			DeniedBundles:   deniedBundles,
+		}
+	}
+	return r.validate(&candidates[0])
+}
+
+// isDeprecated returns whether the resolved bundle, or its package, is deprecated.
+func (rp resolvedPackage) isDeprecated() bool {
+	if rp.deprecation == nil {
+		return false
+	}
+	return isDeprecated(*rp.bundle, rp.deprecation) || slices.ContainsFunc(rp.deprecation.Entries, func(entry declcfg.DeprecationEntry) bool {
+		return entry.Reference.Schema == declcfg.SchemaPackage
+	})
+}
+
+// providesBundle returns whether a package provides a bundle, either in its
+// bundles or in the entries of its channels.
+func providesBundle(packageFBC *declcfg.DeclarativeConfig, bundleName string) bool {
+	if slices.ContainsFunc(packageFBC.Bundles, func(b declcfg.Bundle) bool { return b.Name == bundleName }) {
+		return true
+	}
+	for _, ch := range packageFBC.Channels {
+		if slices.ContainsFunc(ch.Entries, func(e declcfg.ChannelEntry) bool { return e.Name == bundleName }) {
+			return true
+		}
+	}
+	return false
+}
+
+type providedAPIResolutionError struct {
+	GVK             schema.GroupVersionKind
+	Version         string
+	InstalledBundle *ocv1.BundleMetadata
+	Packages        []string
+	DeniedBundles   int
+}
+
+func (e providedAPIResolutionError) Error() string {
+	var sb strings.Builder
+	if e.InstalledBundle != nil {
+		sb.WriteString(fmt.Sprintf("error upgrading from currently installed version %q: ", e.InstalledBundle.Version))
+	}
+
+	if len(e.Packages) > 1 {
+		sb.WriteString(fmt.Sprintf("found bundles providing %q ", e.GVK.String()))
+	} else {
+		sb.WriteString(fmt.Sprintf("no bundles found providing %q ", e.GVK.String()))
+	}
+
+	if e.Version != "" {
+		sb.WriteString(fmt.Sprintf("matching version %q ", e.Version))
+	}
+
+	if len(e.Packages) > 1 {
+		packages := slices.Sorted(slices.Values(e.Packages)) // sort for consistent error message
+		sb.WriteString(fmt.Sprintf("in multiple packages with the same priority %v ", packages))
+	}
+
+	if len(e.Packages) == 0 && e.DeniedBundles > 0 {
+		sb.WriteString(fmt.Sprintf("(excluding %d bundles denied by ClusterBundleDenyPolicies) ", e.DeniedBundles))
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// CatalogPackagesWalkFunc is called with the names of the packages of a catalog
+// that provide an API, or with the error listing them.
+type CatalogPackagesWalkFunc func(context.Context, *ocv1.ClusterCatalog, []string, error) error
+
+// CatalogPackagesWalker returns a function that walks the packages with bundles
+// that provide an API, in the available catalogs matching the given options.
+func CatalogPackagesWalker(
+	listCatalogs func(context.Context, ...client.ListOption) ([]ocv1.ClusterCatalog, error),
+	listPackages func(context.Context, *ocv1.ClusterCatalog, property.GVK) ([]string, error),
+) func(ctx context.Context, gvk property.GVK, f CatalogPackagesWalkFunc, catalogListOpts ...client.ListOption) error {
+	return func(ctx context.Context, gvk property.GVK, f CatalogPackagesWalkFunc, catalogListOpts ...client.ListOption) error {
+		catalogs, err := listAvailableCatalogs(ctx, listCatalogs, catalogListOpts...)
+		if err != nil {
+			return err
+		}
+
+		for i := range catalogs {
+			cat := &catalogs[i]
+			pkgNames, listErr := listPackages(ctx, cat, gvk)
+			if walkErr := f(ctx, cat, pkgNames, listErr); walkErr != nil {
+				return walkErr
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,309 @@
+package resolve
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
+)
+
+type staticCatalog struct {
+	spec     ocv1.ClusterCatalogSpec
+	packages map[string]*declcfg.DeclarativeConfig
+}
+
+// staticCatalogPackages walks the packages of catalogs, by catalog name.
+type staticCatalogPackages map[string]staticCatalog
+
+func (c staticCatalogPackages) catalogs(opts ...client.ListOption) []*ocv1.ClusterCatalog {
+	options := client.ListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToList(&options)
+	}
+	var catalogs []*ocv1.ClusterCatalog
+	for _, name := range slices.Sorted(maps.Keys(c)) {
+		cat := &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"olm.operatorframework.io/metadata.name": name}},
+			Spec:       c[name].spec,
+		}
+		if options.LabelSelector != nil && !options.LabelSelector.Matches(labels.Set(cat.Labels)) {
+			continue
+		}
+		catalogs = append(catalogs, cat)
+	}
+	return catalogs
+}
+
+func (c staticCatalogPackages) WalkCatalogs(ctx context.Context, pkgName string, f CatalogWalkFunc, opts ...client.ListOption) error {
+	for _, cat := range c.catalogs(opts...) {
+		fbc, ok := c[cat.Name].packages[pkgName]
+		if !ok {
+			fbc = &declcfg.DeclarativeConfig{}
+		}
+		// Resolution filters the bundles of the package in place.
+		fbc = &declcfg.DeclarativeConfig{Packages: fbc.Packages, Channels: slices.Clone(fbc.Channels), Bundles: slices.Clone(fbc.Bundles), Deprecations: fbc.Deprecations}
+		if err := f(ctx, cat, fbc, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c staticCatalogPackages) WalkCatalogPackages(ctx context.Context, gvk property.GVK, f CatalogPackagesWalkFunc, opts ...client.ListOption) error {
+	for _, cat := range c.catalogs(opts...) {
+		var pkgNames []string
+		for _, pkgName := range slices.Sorted(maps.Keys(c[cat.Name].packages)) {
+			if slices.ContainsFunc(c[cat.Name].packages[pkgName].Bundles, filter.ProvidesGVK(gvk)) {
+				pkgNames = append(pkgNames, pkgName)
+			}
+		}
+		if err := f(ctx, cat, pkgNames, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var certificateGVK = property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// genAPIPackage returns a package whose bundles provide the given API.
+func genAPIPackage(pkg string, gvk property.GVK, versions ...string) *declcfg.DeclarativeConfig {
+	fbc := &declcfg.DeclarativeConfig{
+		Packages: []declcfg.Package{{Name: pkg}},
+		Channels: []declcfg.Channel{{Package: pkg, Name: "stable"}},
+	}
+	for i, version := range versions {
+		entry := declcfg.ChannelEntry{Name: bundleName(pkg, version)}
+		if i > 0 {
+			entry.Replaces = bundleName(pkg, versions[i-1])
+		}
+		fbc.Channels[0].Entries = append(fbc.Channels[0].Entries, entry)
+		b := genBundle(pkg, version)
+		b.Properties = append(b.Properties, property.MustBuildGVK(gvk.Group, gvk.Version, gvk.Kind))
+		fbc.Bundles = append(fbc.Bundles, b)
+	}
+	return fbc
+}
+
+func buildProvidedAPIClusterExtension(gvk property.GVK, bundleVersion string) *ocv1.ClusterExtension {
+	return &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+		Spec: ocv1.ClusterExtensionSpec{
+			Namespace:      "default",
+			ServiceAccount: ocv1.ServiceAccountReference{Name: "default"},
+			Source: ocv1.SourceConfig{
+				SourceType: ocv1.SourceTypeProvidedAPI,
+				ProvidedAPI: &ocv1.ProvidedAPIFilter{
+					Group:         gvk.Group,
+					Version:       gvk.Version,
+					Kind:          gvk.Kind,
+					BundleVersion: bundleVersion,
+				},
+			},
+		},
+	}
+}
+
+func TestProvidedAPI(t *testing.T) {
+	issuerGVK := property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"}
+	deprecated := func(fbc *declcfg.DeclarativeConfig) *declcfg.DeclarativeConfig {
+		fbc.Deprecations = []declcfg.Deprecation{{
+			Package: fbc.Packages[0].Name,
+			Entries: []declcfg.DeprecationEntry{{
+				Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaPackage},
+				Message:   "package is deprecated",
+			}},
+		}}
+		return fbc
+	}
+
+	for _, tc := range []struct {
+		name            string
+		catalogs        staticCatalogPackages
+		bundleVersion   string
+		installedBundle *ocv1.BundleMetadata
+		expectBundle    string
+		expectVersion   string
+		expectCatalog   string
+		expectErr       string
+	}{
+		{
+			name: "single package provides the API",
+			catalogs: staticCatalogPackages{
+				"a": {packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-manager": genAPIPackage("cert-manager", certificateGVK, "1.0.0", "1.1.0"),
+					"issuer":       genAPIPackage("issuer", issuerGVK, "1.0.0"),
+				}},
+			},
+			expectBundle:  bundleName("cert-manager", "1.1.0"),
+			expectVersion: "1.1.0",
+			expectCatalog: "a",
+		},
+		{
+			name: "bundle version range",
+			catalogs: staticCatalogPackages{
+				"a": {packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-manager": genAPIPackage("cert-manager", certificateGVK, "1.0.0", "1.1.0"),
+				}},
+			},
+			bundleVersion: "1.0.x",
+			expectBundle:  bundleName("cert-manager", "1.0.0"),
+			expectVersion: "1.0.0",
+			expectCatalog: "a",
+		},
+		{
+			name: "several packages provide the API",
+			catalogs: staticCatalogPackages{
+				"a": {packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-manager": genAPIPackage("cert-manager", certificateGVK, "1.0.0"),
+				}},
+				"b": {packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-utils": genAPIPackage("cert-utils", certificateGVK, "2.0.0"),
+				}},
+			},
+			expectErr: `found bundles providing "cert-manager.io/v1, Kind=Certificate" in multiple packages with the same priority [cert-manager cert-utils]`,
+		},
+		{
+			name: "catalog priority breaks the tie",
+			catalogs: staticCatalogPackages{
+				"a": {packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-manager": genAPIPackage("cert-manager", certificateGVK, "1.0.0"),
+				}},
+				"b": {spec: ocv1.ClusterCatalogSpec{Priority: 1}, packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-utils": genAPIPackage("cert-utils", certificateGVK, "2.0.0"),
+				}},
+			},
+			expectBundle:  bundleName("cert-utils", "2.0.0"),
+			expectVersion: "2.0.0",
+			expectCatalog: "b",
+		},
+		{
+			name: "deprecated packages are not preferred",
+			catalogs: staticCatalogPackages{
+				"a": {packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-manager": genAPIPackage("cert-manager", certificateGVK, "1.0.0"),
+				}},
+				"b": {spec: ocv1.ClusterCatalogSpec{Priority: 1}, packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-utils": deprecated(genAPIPackage("cert-utils", certificateGVK, "2.0.0")),
+				}},
+			},
+			expectBundle:  bundleName("cert-manager", "1.0.0"),
+			expectVersion: "1.0.0",
+			expectCatalog: "a",
+		},
+		{
+			name: "no package provides the API",
+			catalogs: staticCatalogPackages{
+				"a": {packages: map[string]*declcfg.DeclarativeConfig{
+					"issuer": genAPIPackage("issuer", issuerGVK, "1.0.0"),
+				}},
+			},
+			expectErr: `no bundles found providing "cert-manager.io/v1, Kind=Certificate"`,
+		},
+		{
+			name: "upgrades stay in the package of the installed bundle",
+			catalogs: staticCatalogPackages{
+				"a": {packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-manager": genAPIPackage("cert-manager", certificateGVK, "1.0.0", "1.1.0"),
+				}},
+				"b": {spec: ocv1.ClusterCatalogSpec{Priority: 1}, packages: map[string]*declcfg.DeclarativeConfig{
+					"cert-utils": genAPIPackage("cert-utils", certificateGVK, "2.0.0"),
+				}},
+			},
+			installedBundle: &ocv1.BundleMetadata{Name: bundleName("cert-manager", "1.0.0"), Version: "1.0.0"},
+			expectBundle:    bundleName("cert-manager", "1.1.0"),
+			expectVersion:   "1.1.0",
+			expectCatalog:   "a",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := CatalogResolver{
+				WalkCatalogsFunc:        tc.catalogs.WalkCatalogs,
+				WalkCatalogPackagesFunc: tc.catalogs.WalkCatalogPackages,
+			}
+			ce := buildProvidedAPIClusterExtension(certificateGVK, tc.bundleVersion)
+			gotBundle, gotVersion, _, gotCatalog, err := r.Resolve(context.Background(), ce, tc.installedBundle, "")
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				assert.Nil(t, gotBundle)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectBundle, gotBundle.Name)
+			assert.Equal(t, bsemver.MustParse(tc.expectVersion), gotVersion.Version)
+			assert.Equal(t, tc.expectCatalog, gotCatalog)
+		})
+	}
+}
+
+func TestProvidedAPIResolvesOnlyProvidingPackages(t *testing.T) {
+	catalogs := staticCatalogPackages{
+		"a": {packages: map[string]*declcfg.DeclarativeConfig{
+			"cert-manager": genAPIPackage("cert-manager", certificateGVK, "1.0.0"),
+			"issuer":       genAPIPackage("issuer", property.GVK{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"}, "1.0.0"),
+		}},
+	}
+	var walked []string
+	r := CatalogResolver{
+		WalkCatalogsFunc: func(ctx context.Context, pkgName string, f CatalogWalkFunc, opts ...client.ListOption) error {
+			walked = append(walked, pkgName)
+			return catalogs.WalkCatalogs(ctx, pkgName, f, opts...)
+		},
+		WalkCatalogPackagesFunc: catalogs.WalkCatalogPackages,
+	}
+	gotBundle, _, _, _, err := r.Resolve(context.Background(), buildProvidedAPIClusterExtension(certificateGVK, ""), nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, bundleName("cert-manager", "1.0.0"), gotBundle.Name)
+	assert.Equal(t, []string{"cert-manager"}, walked)
+}
+
+func TestProvidedAPINotSupported(t *testing.T) {
+	r := CatalogResolver{WalkCatalogsFunc: staticCatalogPackages{}.WalkCatalogs}
+	_, _, _, _, err := r.Resolve(context.Background(), buildProvidedAPIClusterExtension(certificateGVK, ""), nil, "")
+	require.EqualError(t, err, "resolving bundles by provided API is not supported")
+}
+
+func TestCatalogPackagesWalker(t *testing.T) {
+	listCatalogs := func(context.Context, ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+		return []ocv1.ClusterCatalog{
+			{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: ocv1.ClusterCatalogSpec{AvailabilityMode: ocv1.AvailabilityModeUnavailable}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "c"}},
+		}, nil
+	}
+
+	t.Run("success", func(t *testing.T) {
+		w := CatalogPackagesWalker(listCatalogs, func(_ context.Context, cat *ocv1.ClusterCatalog, gvk property.GVK) ([]string, error) {
+			return []string{cat.Name + "-" + gvk.Kind}, nil
+		})
+		seen := map[string][]string{}
+		require.NoError(t, w(context.Background(), certificateGVK, func(_ context.Context, cat *ocv1.ClusterCatalog, pkgNames []string, err error) error {
+			seen[cat.Name] = pkgNames
+			return err
+		}))
+		assert.Equal(t, map[string][]string{"a": {"a-Certificate"}, "c": {"c-Certificate"}}, seen)
+	})
+
+	t.Run("error listing packages", func(t *testing.T) {
+		w := CatalogPackagesWalker(listCatalogs, func(context.Context, *ocv1.ClusterCatalog, property.GVK) ([]string, error) {
+			return nil, errors.New("fake error listing packages")
+		})
+		assert.EqualError(t, w(context.Background(), certificateGVK, func(_ context.Context, _ *ocv1.ClusterCatalog, _ []string, err error) error {
+			return err
+		}), "fake error listing packages")
+	})
+}
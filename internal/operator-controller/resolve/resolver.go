@@ -8,12 +8,17 @@ import (
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 )
 
+// Resolver resolves the bundle to install for a ClusterExtension. Along with the
+// bundle, its version and release, and the deprecation of its package, it returns
+// the name of the catalog that provided the bundle, or "" when the bundle was not
+// resolved from a catalog. installedCatalog is the name of the catalog that provided
+// installedBundle, or "" when it is unknown.
 type Resolver interface {
-	Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error)
+	Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error)
 }
 
-type Func func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error)
+type Func func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error)
 
-func (f Func) Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
-	return f(ctx, ext, installedBundle)
+func (f Func) Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata, installedCatalog string) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, string, error) {
+	return f(ctx, ext, installedBundle, installedCatalog)
 }
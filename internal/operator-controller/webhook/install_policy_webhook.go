@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/installpolicy"
+)
+
+// ClusterExtension wraps the external v1.ClusterExtension type and implements admission.Validator.
+// It rejects ClusterExtensions that do not satisfy the validations of ClusterExtensionInstallPolicies
+// that do not access the resolved bundle, which is not known at admission.
+type ClusterExtension struct {
+	// Checker evaluates the ClusterExtensionInstallPolicies.
+	Checker *installpolicy.Checker
+}
+
+// ValidateCreate rejects the ClusterExtension if it does not satisfy the install policies.
+func (r *ClusterExtension) ValidateCreate(ctx context.Context, obj *ocv1.ClusterExtension) (admission.Warnings, error) {
+	return nil, r.Checker.Check(ctx, obj, nil)
+}
+
+// ValidateUpdate rejects the ClusterExtension if its spec changed and it does not satisfy
+// the install policies.
+func (r *ClusterExtension) ValidateUpdate(ctx context.Context, oldObj, newObj *ocv1.ClusterExtension) (admission.Warnings, error) {
+	// Allow deleting ClusterExtensions that violate policies created after them, and
+	// updating their metadata, e.g. their finalizers.
+	if newObj.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) {
+		return nil, nil
+	}
+	return nil, r.Checker.Check(ctx, newObj, nil)
+}
+
+// ValidateDelete never rejects.
+func (r *ClusterExtension) ValidateDelete(context.Context, *ocv1.ClusterExtension) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// SetupWebhookWithManager sets up the webhook with the manager.
+func (r *ClusterExtension) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &ocv1.ClusterExtension{}).
+		WithValidator(r).
+		Complete()
+}
+
+// ClusterExtensionInstallPolicy wraps the external v1.ClusterExtensionInstallPolicy type and
+// implements admission.Validator. It rejects policies with invalid CEL expressions, which
+// would otherwise block all installations.
+type ClusterExtensionInstallPolicy struct{}
+
+// ValidateCreate rejects the policy if it is invalid.
+func (r *ClusterExtensionInstallPolicy) ValidateCreate(_ context.Context, obj *ocv1.ClusterExtensionInstallPolicy) (admission.Warnings, error) {
+	return nil, validateInstallPolicy(obj)
+}
+
+// ValidateUpdate rejects the policy if it is invalid.
+func (r *ClusterExtensionInstallPolicy) ValidateUpdate(_ context.Context, _, newObj *ocv1.ClusterExtensionInstallPolicy) (admission.Warnings, error) {
+	return nil, validateInstallPolicy(newObj)
+}
+
+// ValidateDelete never rejects.
+func (r *ClusterExtensionInstallPolicy) ValidateDelete(context.Context, *ocv1.ClusterExtensionInstallPolicy) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// SetupWebhookWithManager sets up the webhook with the manager.
+func (r *ClusterExtensionInstallPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &ocv1.ClusterExtensionInstallPolicy{}).
+		WithValidator(r).
+		Complete()
+}
+
+func validateInstallPolicy(p *ocv1.ClusterExtensionInstallPolicy) error {
+	if err := installpolicy.Validate(p); err != nil {
+		return fmt.Errorf("invalid ClusterExtensionInstallPolicy: %w", err)
+	}
+	return nil
+}
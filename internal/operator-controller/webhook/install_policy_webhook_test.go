@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/installpolicy"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/scheme"
+)
+
+func TestClusterExtensionValidation(t *testing.T) {
+	policy := &ocv1.ClusterExtensionInstallPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-packages"},
+		Spec: ocv1.ClusterExtensionInstallPolicySpec{
+			Validations: []ocv1.InstallPolicyValidation{
+				{Expression: "object.spec.source.catalog.packageName == 'foo'", Message: "only foo may be installed"},
+				{Expression: "bundle.catalog == 'trusted'", Message: "only the trusted catalog may be used"},
+			},
+		},
+	}
+	ext := func(packageName string) *ocv1.ClusterExtension {
+		return &ocv1.ClusterExtension{
+			ObjectMeta: metav1.ObjectMeta{Name: "ext"},
+			Spec: ocv1.ClusterExtensionSpec{
+				Namespace: "ns",
+				Source: ocv1.SourceConfig{
+					SourceType: ocv1.SourceTypeCatalog,
+					Catalog:    &ocv1.CatalogFilter{PackageName: packageName},
+				},
+			},
+		}
+	}
+	deleting := ext("bar")
+	deleting.DeletionTimestamp = &metav1.Time{}
+
+	w := &ClusterExtension{Checker: &installpolicy.Checker{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(policy).Build(),
+	}}
+
+	_, err := w.ValidateCreate(context.Background(), ext("foo"))
+	require.NoError(t, err, "validations that access the bundle must not be evaluated at admission")
+
+	_, err = w.ValidateCreate(context.Background(), ext("bar"))
+	require.EqualError(t, err, `violates ClusterExtensionInstallPolicy "allowed-packages": only foo may be installed`)
+
+	_, err = w.ValidateUpdate(context.Background(), ext("foo"), ext("bar"))
+	require.Error(t, err)
+
+	_, err = w.ValidateUpdate(context.Background(), ext("bar"), deleting)
+	require.NoError(t, err)
+
+	withFinalizer := ext("bar")
+	withFinalizer.Finalizers = []string{"olm.operatorframework.io/cleanup-unpack-cache"}
+	_, err = w.ValidateUpdate(context.Background(), ext("bar"), withFinalizer)
+	require.NoError(t, err, "updates that leave the spec unchanged must not be rejected")
+
+	_, err = w.ValidateDelete(context.Background(), ext("bar"))
+	require.NoError(t, err)
+}
+
+func TestClusterExtensionInstallPolicyValidation(t *testing.T) {
+	policy := func(expression string) *ocv1.ClusterExtensionInstallPolicy {
+		return &ocv1.ClusterExtensionInstallPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec: ocv1.ClusterExtensionInstallPolicySpec{
+				Validations: []ocv1.InstallPolicyValidation{{Expression: expression, Message: "message"}},
+			},
+		}
+	}
+	w := &ClusterExtensionInstallPolicy{}
+
+	_, err := w.ValidateCreate(context.Background(), policy("object.spec.namespace != 'kube-system'"))
+	require.NoError(t, err)
+
+	_, err = w.ValidateCreate(context.Background(), policy("object.spec.namespace !="))
+	assert.ErrorContains(t, err, "invalid ClusterExtensionInstallPolicy: spec.validations[0].expression")
+
+	_, err = w.ValidateUpdate(context.Background(), policy("true"), policy("size(object.spec.namespace)"))
+	assert.ErrorContains(t, err, "must evaluate to a bool")
+}
@@ -27,8 +27,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -124,10 +126,14 @@ func logNamespacedName(nn types.NamespacedName) string {
 }
 
 // SetupWithManager sets up the controller with the Manager.
+//
+// The controllers only write the local auth file, so they run on every replica
+// regardless of leadership: replicas that are not the leader pull images too.
 func (r *PullSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	_, err := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Secret{}).
 		Named("pull-secret-controller").
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
 		WithEventFilter(newSecretPredicate(r)).
 		Build(r)
 	if err != nil {
@@ -137,6 +143,7 @@ func (r *PullSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	_, err = ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.ServiceAccount{}).
 		Named("service-account-controller").
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
 		WithEventFilter(newNamespacedPredicate(r.ServiceAccountKey)).
 		Build(r)
 
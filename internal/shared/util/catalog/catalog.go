@@ -0,0 +1,39 @@
+package catalog
+
+import (
+	"strings"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// namespacedKeySeparator separates the namespace and name of a namespaced Catalog in
+// its key. It can occur neither in namespaces, which are DNS labels, nor in the names
+// of Catalogs and ClusterCatalogs, which are DNS subdomains.
+const namespacedKeySeparator = "_"
+
+// NamespacedKey returns the key of a namespaced Catalog. Catalog content is cached,
+// stored and reported in metrics under the name of a ClusterCatalog, and under the
+// key of a namespaced Catalog, which never collides with the name of a ClusterCatalog.
+func NamespacedKey(namespace, name string) string {
+	return namespace + namespacedKeySeparator + name
+}
+
+// SplitNamespacedKey returns the namespace and name of the namespaced Catalog with
+// the given key, or false if key is the name of a ClusterCatalog.
+func SplitNamespacedKey(key string) (string, string, bool) {
+	return strings.Cut(key, namespacedKeySeparator)
+}
+
+// AsClusterCatalog returns a ClusterCatalog with the metadata, spec and status of a
+// namespaced Catalog, named after its key, so that it can be unpacked, served and
+// resolved from by the code that handles ClusterCatalogs.
+func AsClusterCatalog(c *ocv1.Catalog) *ocv1.ClusterCatalog {
+	cc := &ocv1.ClusterCatalog{
+		ObjectMeta: *c.ObjectMeta.DeepCopy(),
+		Spec:       *c.Spec.DeepCopy(),
+		Status:     *c.Status.DeepCopy(),
+	}
+	cc.Name = NamespacedKey(c.Namespace, c.Name)
+	cc.Namespace = ""
+	return cc
+}
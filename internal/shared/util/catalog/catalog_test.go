@@ -0,0 +1,60 @@
+package catalog_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	catalogutil "github.com/operator-framework/operator-controller/internal/shared/util/catalog"
+)
+
+func TestNamespacedKey(t *testing.T) {
+	key := catalogutil.NamespacedKey("team-a", "my.catalog")
+	require.Equal(t, "team-a_my.catalog", key)
+
+	namespace, name, ok := catalogutil.SplitNamespacedKey(key)
+	require.True(t, ok)
+	require.Equal(t, "team-a", namespace)
+	require.Equal(t, "my.catalog", name)
+
+	_, _, ok = catalogutil.SplitNamespacedKey("operatorhubio")
+	require.False(t, ok)
+}
+
+func TestAsClusterCatalog(t *testing.T) {
+	c := &ocv1.Catalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-catalog",
+			Namespace:  "team-a",
+			Generation: 3,
+			Labels:     map[string]string{"tier": "dev"},
+			Finalizers: []string{"olm.operatorframework.io/delete-server-cache"},
+		},
+		Spec: ocv1.ClusterCatalogSpec{
+			Priority: 10,
+			Source: ocv1.CatalogSource{
+				Type:  ocv1.SourceTypeImage,
+				Image: &ocv1.ImageSource{Ref: "quay.io/team-a/catalog:latest"},
+			},
+		},
+		Status: ocv1.ClusterCatalogStatus{
+			URLs: &ocv1.ClusterCatalogURLs{Base: "https://catalogd-service.olmv1-system.svc/catalogs/namespaces/team-a/my-catalog"},
+		},
+	}
+
+	cc := catalogutil.AsClusterCatalog(c)
+	require.Equal(t, "team-a_my-catalog", cc.Name)
+	require.Empty(t, cc.Namespace)
+	require.Equal(t, c.Generation, cc.Generation)
+	require.Equal(t, c.Labels, cc.Labels)
+	require.Equal(t, c.Finalizers, cc.Finalizers)
+	require.Equal(t, c.Spec, cc.Spec)
+	require.Equal(t, c.Status, cc.Status)
+
+	cc.Labels["tier"] = "prod"
+	cc.Spec.Source.Image.Ref = "quay.io/team-a/catalog:v2"
+	require.Equal(t, "dev", c.Labels["tier"])
+	require.Equal(t, "quay.io/team-a/catalog:latest", c.Spec.Source.Image.Ref)
+}
@@ -0,0 +1,48 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+	"go.podman.io/image/v5/copy"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Mirror copies the image referenced by src to dest, along with all the images of
+// the platforms it is built for when it is an image index, and returns the digest of
+// the copied manifest. Digests are preserved, so that references by digest to the
+// source image are valid for the copy. Signatures are only copied to registries, as
+// OCI layouts cannot store them.
+func Mirror(ctx context.Context, src, dest types.ImageReference, srcCtx, destCtx *types.SystemContext) (digest.Digest, error) {
+	l := log.FromContext(ctx)
+
+	policyContext, err := loadPolicyContext(srcCtx, l)
+	if err != nil {
+		return "", fmt.Errorf("error loading policy context: %w", err)
+	}
+	defer func() {
+		if err := policyContext.Destroy(); err != nil {
+			l.Error(err, "error destroying policy context")
+		}
+	}()
+
+	manifestBlob, err := copy.Image(ctx, policyContext, dest, src, &copy.Options{
+		SourceCtx:          srcCtx,
+		DestinationCtx:     destCtx,
+		ImageListSelection: copy.CopyAllImages,
+		PreserveDigests:    true,
+		RemoveSignatures:   dest.Transport().Name() != docker.Transport.Name(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error copying image: %w", err)
+	}
+	dgst, err := manifest.Digest(manifestBlob)
+	if err != nil {
+		return "", fmt.Errorf("error getting digest of manifest: %w", err)
+	}
+	return dgst, nil
+}
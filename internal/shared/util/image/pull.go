@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"iter"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -24,7 +26,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/features"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	"github.com/operator-framework/operator-controller/internal/shared/util/http"
 )
 
@@ -35,7 +39,17 @@ type Puller interface {
 var insecurePolicy = []byte(`{"default":[{"type":"insecureAcceptAnything"}]}`)
 
 type ContainersImagePuller struct {
+	// SourceCtxFunc returns the SystemContext of a pull. The PullOptions of the
+	// context of the pull, if any, are merged into it.
 	SourceCtxFunc func(context.Context) (*types.SystemContext, error)
+	// PulledBytesFunc, if set, is called with the number of bytes pulled from the
+	// registry whenever an image is pulled for an owner. It is not called for images
+	// that are already cached.
+	PulledBytesFunc func(ownerID string, n int64)
+	// MaxUncompressedSize, if positive, is the maximum total size of the uncompressed
+	// layers of an image. Applying an image with larger layers fails with a terminal
+	// error with reason LimitExceeded.
+	MaxUncompressedSize int64
 }
 
 func (p *ContainersImagePuller) Pull(ctx context.Context, ownerID string, ref string, cache Cache) (fs.FS, reference.Canonical, time.Time, error) {
@@ -52,6 +66,21 @@ func (p *ContainersImagePuller) Pull(ctx context.Context, ownerID string, ref st
 	l := log.FromContext(ctx, "ref", dockerRef.String())
 	ctx = log.IntoContext(ctx, l)
 
+	if opts := PullOptionsFromContext(ctx); opts != nil {
+		optsDir, err := os.MkdirTemp("", fmt.Sprintf("pull-options-%s-", ownerID))
+		if err != nil {
+			return nil, nil, time.Time{}, fmt.Errorf("error creating temporary directory: %w", err)
+		}
+		defer func() {
+			if err := os.RemoveAll(optsDir); err != nil {
+				l.Error(err, "error removing temporary pull options directory")
+			}
+		}()
+		if srcCtx, err = opts.apply(srcCtx, optsDir); err != nil {
+			return nil, nil, time.Time{}, fmt.Errorf("error applying pull options: %w", err)
+		}
+	}
+
 	fsys, canonicalRef, modTime, err := p.pull(ctx, ownerID, dockerRef, cache, srcCtx)
 	if err != nil {
 		// Log any CertificateVerificationErrors, and log Docker Certificates if necessary
@@ -159,6 +188,13 @@ func (p *ContainersImagePuller) pull(ctx context.Context, ownerID string, docker
 		return nil, nil, time.Time{}, fmt.Errorf("error copying image: %w", err)
 	}
 	l.Info("pulled image")
+	if p.PulledBytesFunc != nil {
+		if n, err := dirSize(layoutDir); err != nil {
+			l.Error(err, "error determining size of pulled image")
+		} else {
+			p.PulledBytesFunc(ownerID, n)
+		}
+	}
 
 	//////////////////////////////////////////////////////
 	//
@@ -181,6 +217,23 @@ func (p *ContainersImagePuller) pull(ctx context.Context, ownerID string, docker
 	return fsys, canonicalRef, modTime, nil
 }
 
+// dirSize returns the total size of the regular files in dir.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
 func resolveCanonicalRef(ctx context.Context, imgRef types.ImageReference, srcCtx *types.SystemContext) (reference.Canonical, error) {
 	if canonicalRef, ok := imgRef.DockerReference().(reference.Canonical); ok {
 		return canonicalRef, nil
@@ -236,6 +289,8 @@ func (p *ContainersImagePuller) applyImage(ctx context.Context, ownerID string,
 		return nil, time.Time{}, err
 	}
 
+	limiter := &uncompressedSizeLimiter{max: p.MaxUncompressedSize}
+
 	layerIter := iter.Seq[LayerData](func(yield func(LayerData) bool) {
 		for i, layerInfo := range img.LayerInfos() {
 			ld := LayerData{Index: i, MediaType: layerInfo.MediaType}
@@ -257,14 +312,52 @@ func (p *ContainersImagePuller) applyImage(ctx context.Context, ownerID string,
 			}
 			defer decompressed.Close()
 
-			ld.Reader = decompressed
+			ld.Reader = limiter.reader(decompressed)
 			if !yield(ld) {
 				return
 			}
 		}
 	})
 
-	return cache.Store(ctx, ownerID, srcRef, canonicalRef, *ociImg, layerIter)
+	fsys, modTime, err := cache.Store(ctx, ownerID, srcRef, canonicalRef, *ociImg, layerIter)
+	if limiter.exceeded() {
+		// The error returned by the reader may not be wrapped by the cache.
+		return nil, time.Time{}, limiter.err()
+	}
+	return fsys, modTime, err
+}
+
+// uncompressedSizeLimiter fails reads of the layers of an image once their total
+// size exceeds max, unless max is zero.
+type uncompressedSizeLimiter struct {
+	max  int64
+	read int64
+}
+
+func (l *uncompressedSizeLimiter) reader(r io.Reader) io.Reader {
+	return &limitedLayerReader{r: r, limiter: l}
+}
+
+func (l *uncompressedSizeLimiter) exceeded() bool {
+	return l.max > 0 && l.read > l.max
+}
+
+func (l *uncompressedSizeLimiter) err() error {
+	return errorutil.NewTerminalError(ocv1.ReasonLimitExceeded, fmt.Errorf("uncompressed size of image layers exceeds the limit of %d bytes", l.max))
+}
+
+type limitedLayerReader struct {
+	r       io.Reader
+	limiter *uncompressedSizeLimiter
+}
+
+func (r *limitedLayerReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.limiter.read += int64(n)
+	if r.limiter.exceeded() {
+		return n, r.limiter.err()
+	}
+	return n, err
 }
 
 func loadPolicyContext(sourceContext *types.SystemContext, l logr.Logger) (*signature.PolicyContext, error) {
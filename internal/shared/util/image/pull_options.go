@@ -0,0 +1,180 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"go.podman.io/image/v5/pkg/sysregistriesv2"
+	"go.podman.io/image/v5/types"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// PullOptions are settings of a single image pull. ContainersImagePuller merges them
+// into the SystemContext returned by its SourceCtxFunc for the pulls made with a
+// context returned by WithPullOptions.
+type PullOptions struct {
+	// AuthConfig holds registry credentials in the format of a docker config.json.
+	// For the registries it holds credentials for, they take precedence over the
+	// credentials in the auth file of the SystemContext.
+	AuthConfig []byte
+	// Registries configure the mirrors and TLS verification of registries. For the
+	// prefixes they specify, they take precedence over the registry configuration
+	// of the host.
+	Registries []RegistryOptions
+}
+
+// RegistryOptions configure how the images under Prefix are pulled.
+type RegistryOptions struct {
+	Prefix   string
+	Mirrors  []string
+	Insecure bool
+}
+
+type pullOptionsKey struct{}
+
+// WithPullOptions returns a context that applies opts to the image pulls made with it.
+func WithPullOptions(ctx context.Context, opts *PullOptions) context.Context {
+	return context.WithValue(ctx, pullOptionsKey{}, opts)
+}
+
+// PullOptionsFromContext returns the PullOptions of a context returned by
+// WithPullOptions, or nil.
+func PullOptionsFromContext(ctx context.Context) *PullOptions {
+	opts, _ := ctx.Value(pullOptionsKey{}).(*PullOptions)
+	return opts
+}
+
+// NewPullOptions returns the PullOptions configured by cfg, reading its pull secret
+// from the given namespace. It returns nil if cfg is nil.
+func NewPullOptions(ctx context.Context, c client.Reader, namespace string, cfg *ocv1.ImagePullConfig) (*PullOptions, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	opts := &PullOptions{}
+	if cfg.PullSecret != "" {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: cfg.PullSecret}, secret); err != nil {
+			return nil, fmt.Errorf("error getting pull secret %s/%s: %w", namespace, cfg.PullSecret, err)
+		}
+		authConfig, err := authConfigFromSecret(secret)
+		if err != nil {
+			return nil, fmt.Errorf("error reading pull secret %s/%s: %w", namespace, cfg.PullSecret, err)
+		}
+		opts.AuthConfig = authConfig
+	}
+	for _, r := range cfg.Registries {
+		opts.Registries = append(opts.Registries, RegistryOptions{
+			Prefix:   r.Prefix,
+			Mirrors:  r.Mirrors,
+			Insecure: r.Insecure,
+		})
+	}
+	return opts, nil
+}
+
+// authConfigFromSecret returns the credentials of an image pull secret in the
+// format of a docker config.json.
+func authConfigFromSecret(secret *corev1.Secret) ([]byte, error) {
+	if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
+		return data, nil
+	}
+	if data, ok := secret.Data[corev1.DockerConfigKey]; ok {
+		var auths map[string]json.RawMessage
+		if err := json.Unmarshal(data, &auths); err != nil {
+			return nil, err
+		}
+		return json.Marshal(dockerConfig{Auths: auths})
+	}
+	return nil, fmt.Errorf("secret has neither a %s nor a %s key", corev1.DockerConfigJsonKey, corev1.DockerConfigKey)
+}
+
+type dockerConfig struct {
+	Auths map[string]json.RawMessage `json:"auths"`
+}
+
+// apply writes the auth file and registries configuration of o into dir, and
+// returns a copy of srcCtx that uses them.
+func (o *PullOptions) apply(srcCtx *types.SystemContext, dir string) (*types.SystemContext, error) {
+	merged := *srcCtx
+
+	if len(o.AuthConfig) > 0 {
+		auths := map[string]json.RawMessage{}
+		if srcCtx.AuthFilePath != "" {
+			data, err := os.ReadFile(srcCtx.AuthFilePath)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("error reading auth file: %w", err)
+			}
+			if err == nil {
+				var global dockerConfig
+				if err := json.Unmarshal(data, &global); err != nil {
+					return nil, fmt.Errorf("error parsing auth file: %w", err)
+				}
+				for registry, auth := range global.Auths {
+					auths[registry] = auth
+				}
+			}
+		}
+		var pullAuths dockerConfig
+		if err := json.Unmarshal(o.AuthConfig, &pullAuths); err != nil {
+			return nil, fmt.Errorf("error parsing pull credentials: %w", err)
+		}
+		for registry, auth := range pullAuths.Auths {
+			auths[registry] = auth
+		}
+
+		data, err := json.Marshal(dockerConfig{Auths: auths})
+		if err != nil {
+			return nil, err
+		}
+		merged.AuthFilePath = filepath.Join(dir, "auth.json")
+		if err := os.WriteFile(merged.AuthFilePath, data, 0600); err != nil {
+			return nil, fmt.Errorf("error writing auth file: %w", err)
+		}
+	}
+
+	if len(o.Registries) > 0 {
+		// Registries are written to a drop-in, so that they are merged with the main
+		// registries configuration and replace its registries with the same prefixes.
+		// The drop-in directory replaces that of the host for this pull.
+		conf := sysregistriesv2.V2RegistriesConf{}
+		for _, r := range o.Registries {
+			registry := sysregistriesv2.Registry{
+				Prefix: r.Prefix,
+				Endpoint: sysregistriesv2.Endpoint{
+					Location: r.Prefix,
+					Insecure: r.Insecure,
+				},
+			}
+			for _, mirror := range r.Mirrors {
+				registry.Mirrors = append(registry.Mirrors, sysregistriesv2.Endpoint{
+					Location: mirror,
+					Insecure: r.Insecure,
+				})
+			}
+			conf.Registries = append(conf.Registries, registry)
+		}
+
+		merged.SystemRegistriesConfDirPath = filepath.Join(dir, "registries.conf.d")
+		if err := os.Mkdir(merged.SystemRegistriesConfDirPath, 0700); err != nil {
+			return nil, fmt.Errorf("error creating registries configuration directory: %w", err)
+		}
+		f, err := os.Create(filepath.Join(merged.SystemRegistriesConfDirPath, "pull-options.conf"))
+		if err != nil {
+			return nil, fmt.Errorf("error creating registries configuration: %w", err)
+		}
+		if err := errors.Join(toml.NewEncoder(f).Encode(conf), f.Close()); err != nil {
+			return nil, fmt.Errorf("error writing registries configuration: %w", err)
+		}
+	}
+
+	return &merged, nil
+}
@@ -0,0 +1,159 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containerd/containerd/archive"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/pkg/sysregistriesv2"
+	"go.podman.io/image/v5/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	fsutil "github.com/operator-framework/operator-controller/internal/shared/util/fs"
+)
+
+func TestContainersImagePuller_PullWithOptions(t *testing.T) {
+	tagRef, _, shutdown := setupRegistry(t)
+	defer shutdown()
+	host := reference.Domain(tagRef)
+
+	configDir := t.TempDir()
+	policyPath := filepath.Join(configDir, "policy.json")
+	require.NoError(t, os.WriteFile(policyPath, insecurePolicy, 0600))
+	registriesConfPath := filepath.Join(configDir, "registries.conf")
+	f, err := os.Create(registriesConfPath)
+	require.NoError(t, err)
+	require.NoError(t, toml.NewEncoder(f).Encode(sysregistriesv2.V2RegistriesConf{}))
+	require.NoError(t, f.Close())
+
+	puller := ContainersImagePuller{
+		SourceCtxFunc: func(context.Context) (*types.SystemContext, error) {
+			return &types.SystemContext{
+				SignaturePolicyPath:      policyPath,
+				SystemRegistriesConfPath: registriesConfPath,
+			}, nil
+		},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		ref       string
+		opts      *PullOptions
+		expectErr bool
+	}{
+		{
+			name:      "fails without options for a registry that requires insecure access",
+			ref:       tagRef.String(),
+			expectErr: true,
+		},
+		{
+			name: "pulls from an insecure registry",
+			ref:  tagRef.String(),
+			opts: &PullOptions{Registries: []RegistryOptions{{Prefix: host, Insecure: true}}},
+		},
+		{
+			name: "pulls from a mirror",
+			ref:  "mirrored.example.com/test-repo/test-image:test-tag",
+			opts: &PullOptions{Registries: []RegistryOptions{{
+				Prefix:   "mirrored.example.com",
+				Mirrors:  []string{host},
+				Insecure: true,
+			}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.opts != nil {
+				ctx = WithPullOptions(ctx, tc.opts)
+			}
+			cache := &diskCache{
+				basePath: t.TempDir(),
+				filterFunc: func(ctx context.Context, named reference.Named, image ocispecv1.Image) (archive.Filter, error) {
+					return forceOwnershipRWX(), nil
+				},
+			}
+			fsys, _, _, err := puller.Pull(ctx, "owner", tc.ref, cache)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := fs.ReadFile(fsys, testFileName)
+			require.NoError(t, err)
+			assert.Equal(t, testFileContents, string(data))
+			require.NoError(t, fsutil.DeleteReadOnlyRecursive(cache.basePath))
+		})
+	}
+}
+
+func TestPullOptionsMergesCredentials(t *testing.T) {
+	dir := t.TempDir()
+	globalAuthPath := filepath.Join(dir, "global.json")
+	require.NoError(t, os.WriteFile(globalAuthPath, []byte(`{"auths":{"a.example.com":{"auth":"Z2xvYmFs"},"b.example.com":{"auth":"Z2xvYmFs"}}}`), 0600))
+
+	opts := &PullOptions{AuthConfig: []byte(`{"auths":{"b.example.com":{"auth":"cHVsbA=="}}}`)}
+	srcCtx, err := opts.apply(&types.SystemContext{AuthFilePath: globalAuthPath}, dir)
+	require.NoError(t, err)
+	require.NotEqual(t, globalAuthPath, srcCtx.AuthFilePath)
+
+	data, err := os.ReadFile(srcCtx.AuthFilePath)
+	require.NoError(t, err)
+	var merged dockerConfig
+	require.NoError(t, json.Unmarshal(data, &merged))
+	assert.JSONEq(t, `{"auth":"Z2xvYmFs"}`, string(merged.Auths["a.example.com"]))
+	assert.JSONEq(t, `{"auth":"cHVsbA=="}`, string(merged.Auths["b.example.com"]))
+}
+
+func TestNewPullOptions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dockerconfigjson"},
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"quay.io":{"auth":"YTpi"}}}`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dockercfg"},
+			Data:       map[string][]byte{corev1.DockerConfigKey: []byte(`{"quay.io":{"auth":"YTpi"}}`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "opaque"},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		},
+	).Build()
+
+	opts, err := NewPullOptions(context.Background(), cl, "ns", nil)
+	require.NoError(t, err)
+	assert.Nil(t, opts)
+
+	for _, name := range []string{"dockerconfigjson", "dockercfg"} {
+		t.Run(name, func(t *testing.T) {
+			opts, err := NewPullOptions(context.Background(), cl, "ns", &ocv1.ImagePullConfig{
+				PullSecret: name,
+				Registries: []ocv1.RegistryConfig{{Prefix: "quay.io", Mirrors: []string{"mirror.example.com"}}},
+			})
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"auths":{"quay.io":{"auth":"YTpi"}}}`, string(opts.AuthConfig))
+			assert.Equal(t, []RegistryOptions{{Prefix: "quay.io", Mirrors: []string{"mirror.example.com"}}}, opts.Registries)
+		})
+	}
+
+	_, err = NewPullOptions(context.Background(), cl, "ns", &ocv1.ImagePullConfig{PullSecret: "opaque"})
+	require.ErrorContains(t, err, "error reading pull secret ns/opaque")
+
+	_, err = NewPullOptions(context.Background(), cl, "other", &ocv1.ImagePullConfig{PullSecret: "dockercfg"})
+	require.ErrorContains(t, err, "error getting pull secret other/dockercfg")
+}
@@ -26,6 +26,8 @@ import (
 	"go.podman.io/image/v5/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	fsutil "github.com/operator-framework/operator-controller/internal/shared/util/fs"
 )
 
@@ -262,6 +264,48 @@ func TestContainersImagePuller_Pull(t *testing.T) {
 	}
 }
 
+func TestContainersImagePuller_PullMaxUncompressedSize(t *testing.T) {
+	myTagRef, _, shutdown := setupRegistry(t)
+	defer shutdown()
+
+	for _, tc := range []struct {
+		name    string
+		max     int64
+		wantErr bool
+	}{
+		{name: "succeeds without limit", max: 0},
+		{name: "succeeds below limit", max: 1 << 20},
+		{name: "returns terminal error above limit", max: 1, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := &diskCache{
+				basePath: t.TempDir(),
+				filterFunc: func(ctx context.Context, named reference.Named, image ocispecv1.Image) (archive.Filter, error) {
+					return forceOwnershipRWX(), nil
+				},
+			}
+			defer func() { require.NoError(t, fsutil.DeleteReadOnlyRecursive(cache.basePath)) }()
+			puller := ContainersImagePuller{
+				SourceCtxFunc:       buildSourceContextFunc(t, myTagRef),
+				MaxUncompressedSize: tc.max,
+			}
+			fsys, _, _, err := puller.Pull(context.Background(), "myOwner", myTagRef.String(), cache)
+			if !tc.wantErr {
+				require.NoError(t, err)
+				actualFileData, err := fs.ReadFile(fsys, testFileName)
+				require.NoError(t, err)
+				assert.Equal(t, testFileContents, string(actualFileData))
+				return
+			}
+			require.ErrorContains(t, err, "exceeds the limit of 1 bytes")
+			reason, ok := errorutil.ExtractTerminalReason(err)
+			require.True(t, ok)
+			require.Equal(t, ocv1.ReasonLimitExceeded, reason)
+			require.Nil(t, fsys)
+		})
+	}
+}
+
 func setupRegistry(t *testing.T) (reference.NamedTagged, reference.Canonical, func()) {
 	server := httptest.NewServer(registry.New())
 	serverURL, err := url.Parse(server.URL)
@@ -14,6 +14,7 @@ import (
 	os "os"
 	reflect "reflect"
 
+	digest "github.com/opencontainers/go-digest"
 	server "github.com/operator-framework/operator-controller/internal/catalogd/server"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -58,6 +59,21 @@ func (mr *MockCatalogStoreMockRecorder) GetCatalogData(catalog any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogData", reflect.TypeOf((*MockCatalogStore)(nil).GetCatalogData), catalog)
 }
 
+// GetCatalogDigest mocks base method.
+func (m *MockCatalogStore) GetCatalogDigest(catalog string) (digest.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogDigest", catalog)
+	ret0, _ := ret[0].(digest.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogDigest indicates an expected call of GetCatalogDigest.
+func (mr *MockCatalogStoreMockRecorder) GetCatalogDigest(catalog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogDigest", reflect.TypeOf((*MockCatalogStore)(nil).GetCatalogDigest), catalog)
+}
+
 // GetCatalogFS mocks base method.
 func (m *MockCatalogStore) GetCatalogFS(catalog string) (fs.FS, error) {
 	m.ctrl.T.Helper()
@@ -73,6 +89,22 @@ func (mr *MockCatalogStoreMockRecorder) GetCatalogFS(catalog any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogFS", reflect.TypeOf((*MockCatalogStore)(nil).GetCatalogFS), catalog)
 }
 
+// GetEncodedCatalogData mocks base method.
+func (m *MockCatalogStore) GetEncodedCatalogData(catalog, encoding string) (*os.File, os.FileInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEncodedCatalogData", catalog, encoding)
+	ret0, _ := ret[0].(*os.File)
+	ret1, _ := ret[1].(os.FileInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEncodedCatalogData indicates an expected call of GetEncodedCatalogData.
+func (mr *MockCatalogStoreMockRecorder) GetEncodedCatalogData(catalog, encoding any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncodedCatalogData", reflect.TypeOf((*MockCatalogStore)(nil).GetEncodedCatalogData), catalog, encoding)
+}
+
 // GetIndex mocks base method.
 func (m *MockCatalogStore) GetIndex(catalog string) (server.Index, error) {
 	m.ctrl.T.Helper()
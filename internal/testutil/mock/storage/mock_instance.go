@@ -15,6 +15,7 @@ import (
 	http "net/http"
 	reflect "reflect"
 
+	digest "github.com/opencontainers/go-digest"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -84,6 +85,36 @@ func (mr *MockInstanceMockRecorder) Delete(catalog any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInstance)(nil).Delete), catalog)
 }
 
+// GetCatalogDigest mocks base method.
+func (m *MockInstance) GetCatalogDigest(catalog string) (digest.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogDigest", catalog)
+	ret0, _ := ret[0].(digest.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogDigest indicates an expected call of GetCatalogDigest.
+func (mr *MockInstanceMockRecorder) GetCatalogDigest(catalog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogDigest", reflect.TypeOf((*MockInstance)(nil).GetCatalogDigest), catalog)
+}
+
+// GetPackageNames mocks base method.
+func (m *MockInstance) GetPackageNames(catalog string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPackageNames", catalog)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPackageNames indicates an expected call of GetPackageNames.
+func (mr *MockInstanceMockRecorder) GetPackageNames(catalog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackageNames", reflect.TypeOf((*MockInstance)(nil).GetPackageNames), catalog)
+}
+
 // StorageServerHandler mocks base method.
 func (m *MockInstance) StorageServerHandler() http.Handler {
 	m.ctrl.T.Helper()
@@ -99,15 +130,15 @@ func (mr *MockInstanceMockRecorder) StorageServerHandler() *gomock.Call {
 }
 
 // Store mocks base method.
-func (m *MockInstance) Store(ctx context.Context, catalog string, fsys fs.FS) error {
+func (m *MockInstance) Store(ctx context.Context, catalog string, dgst digest.Digest, fsys fs.FS) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Store", ctx, catalog, fsys)
+	ret := m.ctrl.Call(m, "Store", ctx, catalog, dgst, fsys)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Store indicates an expected call of Store.
-func (mr *MockInstanceMockRecorder) Store(ctx, catalog, fsys any) *gomock.Call {
+func (mr *MockInstanceMockRecorder) Store(ctx, catalog, dgst, fsys any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockInstance)(nil).Store), ctx, catalog, fsys)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockInstance)(nil).Store), ctx, catalog, dgst, fsys)
 }